@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -17,9 +18,12 @@ import (
 )
 
 var (
-	version = micro.Version("v0.0.0")
-	store   *graph.Graph
-	config  microConfig.Config
+	version     = micro.Version("v0.0.0")
+	store       *graph.Graph
+	wal         *graph.WAL
+	snapshotter *graph.Snapshotter
+	storage     *graph.BoltStorage
+	config      microConfig.Config
 )
 
 func parseArgs() {
@@ -33,6 +37,17 @@ func parseArgs() {
 	flag.String("addr", ":", "Address to accept client connections on")
 	flag.String("name", "draft.srv", "Service name")
 	flag.String("dump", "", "Load a dump (.draft) file")
+	flag.Int("retention-versions", 0, "Number of earlier versions of the graph kept for as of reads")
+	flag.Duration("retention-age", 0, "How far back in time the graph can be read as of")
+	flag.String("wal-path", "", "Write-ahead log file, replayed on startup and recording every change")
+	flag.String("wal-fsync", "always", "When the write-ahead log is flushed to disk (always, interval or never)")
+	flag.Duration("wal-interval", graph.DefaultSyncInterval, "Interval the write-ahead log is flushed to disk at with -wal-fsync interval")
+	flag.String("snapshot-dir", "", "Directory snapshots of the graph are saved to and restored from on startup")
+	flag.Duration("snapshot-interval", 0, "Time between snapshots of the graph")
+	flag.Int("snapshot-changes", 0, "Number of changes to the graph between snapshots")
+	flag.Int("snapshot-retain", 3, "Number of snapshots kept")
+	flag.Duration("tx-timeout", 5*time.Minute, "Time a transaction can be unused for before it is rolled back, 0 never rolls it back")
+	flag.String("storage-path", "", "Bolt database file the graph is stored in, rather than in memory")
 	flag.Parse()
 
 	err = config.Load(
@@ -46,11 +61,69 @@ func parseArgs() {
 }
 
 func init() {
-	store = graph.New()
 	parseArgs()
 
+	opts := []graph.Option{
+		graph.WithRetention(
+			graph.RetentionPolicy{
+				Versions: config.Get("retention", "versions").Int(0),
+				Age:      config.Get("retention", "age").Duration(0),
+			},
+		),
+	}
+
+	// The LSN of the last write-ahead log record in the restored snapshot.
+	var lsn uint64
+
+	dir := config.Get("snapshot", "dir").String("")
+	path := config.Get("wal", "path").String("")
+
+	if storagePath := config.Get("storage", "path").String(""); storagePath != "" {
+		// Every change is written to the database in a transaction as it
+		// is committed, so the database is durable on its own. Restoring a
+		// snapshot adds its nodes and edges to a new graph, which would
+		// add them again on top of those already in the database, and
+		// without snapshots the write-ahead log would only grow, writing
+		// every change a second time.
+		if dir != "" || path != "" {
+			log.Fatal("Snapshots and the write-ahead log can not be used with -storage-path, the database is already durable")
+		}
+
+		var err error
+		storage, err = graph.OpenBoltStorage(storagePath)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		opts = append(opts, graph.WithStorage(storage))
+		store = graph.New(opts...)
+		log.Printf("Opened %d nodes and %d edges in %s", store.NodeCount(), store.EdgeCount(), storagePath)
+	}
+
+	if dir != "" {
+		var err error
+		store, lsn, err = graph.RestoreSnapshot(dir, opts...)
+		switch {
+		case err == nil:
+			log.Printf("Restored %d nodes and %d edges from %s", store.NodeCount(), store.EdgeCount(), dir)
+		case errors.Is(err, graph.ErrNoSnapshot):
+			store = nil
+		default:
+			log.Fatal(err)
+		}
+	}
+
+	if store == nil {
+		store = graph.New(opts...)
+	}
+
+	restored := store.NodeCount() > 0 || store.EdgeCount() > 0
 	dump := config.Get("dump").String("")
-	if dump != "" {
+	if dump != "" && restored {
+		log.Printf("Not loading %s, the graph is restored from a snapshot or storage", dump)
+	}
+
+	if dump != "" && !restored {
 		log.Printf("Loading from %s", dump)
 		data, err := ioutil.ReadFile(dump)
 		if err != nil {
@@ -62,18 +135,136 @@ func init() {
 			log.Fatal(err)
 		}
 
-		if err := load(store, dump); err != nil {
+		if err := load(store, &dump); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if path != "" {
+		var err error
+		wal, err = openWAL(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := replay(store, wal, lsn); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if dir != "" {
+		var err error
+		snapshotter, err = graph.NewSnapshotter(store, dir, graph.SnapshotPolicy{
+			Interval: config.Get("snapshot", "interval").Duration(0),
+			Changes:  config.Get("snapshot", "changes").Int(0),
+			Retain:   config.Get("snapshot", "retain").Int(3),
+		})
+
+		if err != nil {
 			log.Fatal(err)
 		}
+
+		// The loaded dump is not in the write-ahead log.
+		if dump != "" && !restored {
+			if _, err := snapshotter.Save(); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+}
+
+// parseSyncPolicy returns the write-ahead log sync policy with the name.
+func parseSyncPolicy(name string) (graph.SyncPolicy, error) {
+	for _, policy := range []graph.SyncPolicy{graph.ALWAYS, graph.INTERVAL, graph.NEVER} {
+		if policy.String() == name {
+			return policy, nil
+		}
+	}
+	return graph.ALWAYS, fmt.Errorf("Unknown write-ahead log sync policy %q", name)
+}
+
+// openWAL opens the write-ahead log at the path with the configured sync policy.
+func openWAL(path string) (*graph.WAL, error) {
+	policy, err := parseSyncPolicy(config.Get("wal", "fsync").String("always"))
+	if err != nil {
+		return nil, err
+	}
+
+	interval := config.Get("wal", "interval").Duration(graph.DefaultSyncInterval)
+	return graph.OpenWAL(path, graph.WithSyncPolicy(policy, interval))
+}
+
+// replay the write-ahead log records after the LSN into the graph and log
+// every further change to it.
+func replay(g *graph.Graph, wal *graph.WAL, lsn uint64) error {
+	start := time.Now()
+
+	count, err := wal.ReplayAfter(g, lsn)
+	if err != nil {
+		return fmt.Errorf("[replay] %s", err)
+	}
+
+	g.SetWAL(wal)
+	log.Printf("Replayed %d write-ahead log records in %s", count, time.Now().Sub(start))
+	return nil
+}
+
+// watchWAL checks the write-ahead log at the interval until stop is closed,
+// and logs the error once the log stops writing, as every further change
+// to the graph is then rejected.
+func watchWAL(wal *graph.WAL, interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := wal.Err(); err != nil {
+				log.Printf("Write-ahead log stopped, changes are rejected until restarted: %s", err)
+				return
+			}
+		}
 	}
 }
 
 // load a dump into the graph.
-func load(g *graph.Graph, dump pb.DumpResp) error {
+func load(g *graph.Graph, dump *pb.DumpResp) error {
 	start := time.Now()
 
+	for _, def := range dump.Indexes {
+		create := g.CreateIndex
+		if def.Type == pb.IndexType_RANGE {
+			create = g.CreateRangeIndex
+		}
+
+		if err := create(def.Label, def.Property); err != nil {
+			return fmt.Errorf("[load] %s", err)
+		}
+	}
+
+	for _, c := range dump.Constraints {
+		if err := g.CreateConstraint(convertConstraint(c)); err != nil {
+			return fmt.Errorf("[load] %s", err)
+		}
+	}
+
+	for _, def := range dump.SearchIndexes {
+		search := graph.SearchIndexDef{
+			Name:       def.Name,
+			Type:       graph.ItemType(def.Type),
+			Labels:     def.Labels,
+			Properties: def.Properties,
+		}
+
+		if err := g.CreateSearchIndex(search); err != nil {
+			return fmt.Errorf("[load] %s", err)
+		}
+	}
+
 	for _, node := range dump.Nodes {
-		if _, err := g.AddNode(node.Uid, node.Label, convertServicePropsToGraphKVs(node.Properties)...); err != nil {
+		if _, err := g.AddNode(node.Uid, node.Labels, convertServicePropsToGraphKVs(node.Properties)...); err != nil {
 			return fmt.Errorf("[load] %s", err)
 		}
 	}
@@ -84,6 +275,13 @@ func load(g *graph.Graph, dump pb.DumpResp) error {
 		}
 	}
 
+	// Edge schemas are set last as the dump may contain edges not fitting them.
+	for _, schema := range dump.EdgeSchemas {
+		if err := g.SetEdgeSchema(convertEdgeSchema(schema)); err != nil {
+			return fmt.Errorf("[load] %s", err)
+		}
+	}
+
 	log.Printf("Loaded %d nodes and %d edges in %s", g.NodeCount(), g.EdgeCount(), time.Now().Sub(start))
 	return nil
 }
@@ -96,19 +294,39 @@ func run() error {
 		micro.Address(config.Get("addr").String("0.0.0.0:")),
 	)
 
-	pb.RegisterGraphHandler(mservice.Server(), &server{graph: store})
-	return mservice.Run()
+	srv := &server{graph: store, txs: make(map[string]*openTx)}
+	stop := make(chan struct{})
+	go srv.expireTxs(config.Get("tx", "timeout").Duration(5*time.Minute), stop)
+
+	if wal != nil {
+		go watchWAL(wal, time.Second, stop)
+	}
+
+	pb.RegisterGraphHandler(mservice.Server(), srv)
+	err := mservice.Run()
+	close(stop)
 
-	// c := make(chan os.Signal, 1)
+	// The service stops on SIGINT and SIGTERM, save a last snapshot before
+	// closing the write-ahead log.
+	if snapshotter != nil {
+		if serr := snapshotter.Close(); serr != nil && err == nil {
+			err = serr
+		}
+	}
 
-	// go func() {
-	// 	<-c
-	// 	var b bytes.Buffer
-	// 	server.Save(&b)
-	// 	ioutil.WriteFile("../web/example/dump.draft", b.Bytes(), 0644)
-	// }()
+	if wal != nil {
+		if werr := wal.Close(); werr != nil && err == nil {
+			err = werr
+		}
+	}
+
+	if storage != nil {
+		if serr := storage.Close(); serr != nil && err == nil {
+			err = serr
+		}
+	}
 
-	// signal.Notify(c, os.Interrupt)
+	return err
 }
 
 // main is the main entrypoint.
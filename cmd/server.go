@@ -2,11 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"sync"
 
 	"github.com/jenmud/draft/graph"
 	pb "github.com/jenmud/draft/service"
+	microErrors "github.com/micro/go-micro/v2/errors"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -23,8 +27,77 @@ func convertServicePropsToGraphKVs(props map[string][]byte) []graph.KV {
 	return kvs
 }
 
+// serviceError converts schema constraint and edge schema violations and
+// version conflicts into a precondition failed error, which gRPC clients
+// receive as FailedPrecondition, and reads of versions of the graph which
+// are not retained into a not found error. Any other errors are returned
+// as is.
+func serviceError(err error) error {
+	if errors.Is(err, graph.ErrNotRetained) {
+		return microErrors.New(config.Get("name").String("draft.srv"), err.Error(), http.StatusNotFound)
+	}
+
+	if errors.Is(err, graph.ErrNotVersioned) {
+		return microErrors.New(config.Get("name").String("draft.srv"), err.Error(), http.StatusBadRequest)
+	}
+
+	var constraintErr graph.ConstraintError
+	var schemaErr graph.EdgeSchemaError
+	var versionErr graph.VersionError
+	if errors.As(err, &constraintErr) || errors.As(err, &schemaErr) || errors.As(err, &versionErr) {
+		return microErrors.New(config.Get("name").String("draft.srv"), err.Error(), http.StatusPreconditionFailed)
+	}
+	return err
+}
+
+// convertConstraint converts a service constraint into a graph constraint.
+func convertConstraint(c *pb.Constraint) graph.Constraint {
+	return graph.Constraint{
+		Type:      graph.ConstraintType(c.Type),
+		Item:      graph.ItemType(c.Item),
+		Label:     c.Label,
+		Property:  c.Property,
+		ValueType: graph.ValueType(c.ValueType),
+	}
+}
+
+// convertGraphConstraint converts a graph constraint into a service constraint.
+func convertGraphConstraint(c graph.Constraint) *pb.Constraint {
+	return &pb.Constraint{
+		Type:      pb.ConstraintType(c.Type),
+		Item:      pb.ItemType(c.Item),
+		Label:     c.Label,
+		Property:  c.Property,
+		ValueType: pb.ValueType(c.ValueType),
+	}
+}
+
+// convertEdgeSchema converts a service edge schema into a graph edge schema.
+func convertEdgeSchema(schema *pb.EdgeSchema) graph.EdgeSchema {
+	return graph.EdgeSchema{
+		Label:   schema.Label,
+		Sources: schema.Sources,
+		Targets: schema.Targets,
+		MaxOut:  int(schema.MaxOut),
+		MaxIn:   int(schema.MaxIn),
+	}
+}
+
+// convertGraphEdgeSchema converts a graph edge schema into a service edge schema.
+func convertGraphEdgeSchema(schema graph.EdgeSchema) *pb.EdgeSchema {
+	return &pb.EdgeSchema{
+		Label:   schema.Label,
+		Sources: schema.Sources,
+		Targets: schema.Targets,
+		MaxOut:  int32(schema.MaxOut),
+		MaxIn:   int32(schema.MaxIn),
+	}
+}
+
 type server struct {
-	graph *graph.Graph
+	graph  *graph.Graph
+	txLock sync.Mutex
+	txs    map[string]*openTx
 }
 
 func (s *server) Stats(ctx context.Context, req *pb.StatsReq, resp *pb.StatsResp) error {
@@ -35,6 +108,8 @@ func (s *server) Stats(ctx context.Context, req *pb.StatsReq, resp *pb.StatsResp
 	resp.StartTime = stats.StartTime.String()
 	resp.NumGoroutines = int32(stats.NumGoroutings)
 	resp.TotalMemoryAlloc = int32(stats.MemStats.TotalAlloc)
+	resp.StoreBytes = int64(stats.StoreBytes)
+	resp.BytesPerElement = stats.BytesPerElement
 	return nil
 }
 
@@ -54,52 +129,89 @@ func (s *server) Save(w io.Writer) error {
 	return err
 }
 
-func dump(g *graph.Graph, resp *pb.DumpResp) error {
+// dump fills the response from the snapshot, so the dumped edges always
+// have their nodes in the dump as well.
+func dump(snap *graph.Snapshot, resp *pb.DumpResp) error {
 	// TODO: add in the subgraph and levels
-	nodesIter := g.Nodes()
-	edgesIter := g.Edges()
+	nodesIter := snap.Nodes()
+	defer nodesIter.Close()
 
-	resp.Nodes = make([]*pb.NodeResp, nodesIter.Size())
-	resp.Edges = make([]*pb.EdgeResp, edgesIter.Size())
+	edgesIter := snap.Edges()
+	defer edgesIter.Close()
+
+	resp.Nodes = make([]*pb.NodeResp, 0, snap.NodeCount())
+	resp.Edges = make([]*pb.EdgeResp, 0, snap.EdgeCount())
 
-	ncount := 0
 	for nodesIter.Next() {
-		node := nodesIter.Value().(graph.Node)
+		node := nodesIter.Node()
 		nresp := &pb.NodeResp{
 			Uid:        node.UID,
-			Label:      node.Label,
+			Labels:     node.Labels,
 			Properties: node.Properties,
 			InEdges:    node.InEdges(),
 			OutEdges:   node.OutEdges(),
+			Version:    node.Version,
 		}
-		resp.Nodes[ncount] = nresp
-		ncount++
+		resp.Nodes = append(resp.Nodes, nresp)
+	}
+
+	for _, def := range snap.Indexes() {
+		resp.Indexes = append(resp.Indexes, &pb.IndexDef{Label: def.Label, Property: def.Property, Type: pb.IndexType(def.Type)})
+	}
+
+	for _, c := range snap.Constraints() {
+		resp.Constraints = append(resp.Constraints, convertGraphConstraint(c))
+	}
+
+	for _, schema := range snap.EdgeSchemas() {
+		resp.EdgeSchemas = append(resp.EdgeSchemas, convertGraphEdgeSchema(schema))
+	}
+
+	for _, def := range snap.SearchIndexes() {
+		resp.SearchIndexes = append(
+			resp.SearchIndexes,
+			&pb.SearchIndexDef{
+				Name:       def.Name,
+				Type:       pb.ItemType(def.Type),
+				Labels:     def.Labels,
+				Properties: def.Properties,
+			},
+		)
 	}
 
-	ecount := 0
 	for edgesIter.Next() {
-		edge := edgesIter.Value().(graph.Edge)
+		edge := edgesIter.Edge()
 		eresp := &pb.EdgeResp{
 			Uid:        edge.UID,
 			SourceUid:  edge.SourceUID,
 			Label:      edge.Label,
 			TargetUid:  edge.TargetUID,
 			Properties: edge.Properties,
+			Version:    edge.Version,
 		}
-		resp.Edges[ecount] = eresp
-		ecount++
+		resp.Edges = append(resp.Edges, eresp)
 	}
 
-	return nil
+	// Nodes and edges which could not be read are missing.
+	return snap.Err()
 }
 
 func (s *server) Query(ctx context.Context, req *pb.QueryReq, resp *pb.DumpResp) error {
-	g, err := s.graph.Query(req.Query)
+	query := s.graph.Query
+	if req.AsOf != nil {
+		snap, err := s.snapshot(req.AsOf)
+		if err != nil {
+			return serviceError(fmt.Errorf("[Query] %w", err))
+		}
+		query = snap.Query
+	}
+
+	g, err := query(req.Query)
 	if err != nil {
-		return fmt.Errorf("[Query] Error trying to execute a query: %v", err)
+		return serviceError(fmt.Errorf("[Query] Error trying to execute a query: %w", err))
 	}
 
-	if err := dump(g, resp); err != nil {
+	if err := dump(g.Snapshot(), resp); err != nil {
 		return fmt.Errorf("[Query] Error trying to dump query response: %v", err)
 	}
 
@@ -107,7 +219,25 @@ func (s *server) Query(ctx context.Context, req *pb.QueryReq, resp *pb.DumpResp)
 }
 
 func (s *server) Dump(ctx context.Context, req *pb.DumpReq, resp *pb.DumpResp) error {
-	if err := dump(s.graph, resp); err != nil {
+	read := func(snap *graph.Snapshot) error {
+		resp.Version = snap.Version()
+		return dump(snap, resp)
+	}
+
+	var err error
+	if req.AsOf == nil {
+		// The current version is held still while dumped, in case the
+		// snapshots of the graph see later changes.
+		err = s.graph.ReadSnapshot(read)
+	} else {
+		snap, serr := s.snapshot(req.AsOf)
+		if serr != nil {
+			return serviceError(fmt.Errorf("[Dump] %w", serr))
+		}
+		err = read(snap)
+	}
+
+	if err != nil {
 		return fmt.Errorf("[Dump] Error trying to dump the graph: %v", err)
 	}
 
@@ -116,3 +246,74 @@ func (s *server) Dump(ctx context.Context, req *pb.DumpReq, resp *pb.DumpResp) e
 
 // See server_node.go for node methods
 // See server_edge.go for edge methods
+
+func (s *server) Search(ctx context.Context, req *pb.SearchReq, stream pb.Graph_SearchStream) error {
+	results, err := s.graph.Search(req.Index, req.Query, int(req.Limit))
+	if err != nil {
+		return fmt.Errorf("[Search] Error searching: %v", err)
+	}
+
+	for _, result := range results {
+		resp := pb.SearchResp{Score: result.Score}
+
+		switch result.Type {
+		case graph.NODE:
+			resp.Node = &pb.NodeResp{
+				Uid:        result.Node.UID,
+				Labels:     result.Node.Labels,
+				Properties: result.Node.Properties,
+				InEdges:    result.Node.InEdges(),
+				OutEdges:   result.Node.OutEdges(),
+				Version:    result.Node.Version,
+			}
+		case graph.EDGE:
+			resp.Edge = &pb.EdgeResp{
+				Uid:        result.Edge.UID,
+				SourceUid:  result.Edge.SourceUID,
+				Label:      result.Edge.Label,
+				TargetUid:  result.Edge.TargetUID,
+				Properties: result.Edge.Properties,
+				Version:    result.Edge.Version,
+			}
+		}
+
+		if err := stream.Send(&resp); err != nil {
+			return fmt.Errorf("[Search] Error streaming search results: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *server) Schema(ctx context.Context, req *pb.SchemaReq, resp *pb.SchemaResp) error {
+	change := graph.SchemaChange{RemoveEdgeSchemas: req.RemoveEdgeSchemas}
+	for _, c := range req.Drop {
+		change.DropConstraints = append(change.DropConstraints, convertConstraint(c))
+	}
+
+	for _, c := range req.Create {
+		change.CreateConstraints = append(change.CreateConstraints, convertConstraint(c))
+	}
+
+	for _, schema := range req.SetEdgeSchemas {
+		change.SetEdgeSchemas = append(change.SetEdgeSchemas, convertEdgeSchema(schema))
+	}
+
+	if err := s.graph.ChangeSchema(change); err != nil {
+		return serviceError(fmt.Errorf("[Schema] Error changing schema: %w", err))
+	}
+
+	for _, c := range s.graph.Constraints() {
+		resp.Constraints = append(resp.Constraints, convertGraphConstraint(c))
+	}
+
+	for _, schema := range s.graph.EdgeSchemas() {
+		resp.EdgeSchemas = append(resp.EdgeSchemas, convertGraphEdgeSchema(schema))
+	}
+
+	for _, violation := range s.graph.ValidateEdges() {
+		resp.Violations = append(resp.Violations, violation.Error())
+	}
+
+	return nil
+}
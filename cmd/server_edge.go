@@ -17,9 +17,14 @@ func (s *server) AddEdge(ctx context.Context, req *pb.EdgeReq, resp *pb.EdgeResp
 		count++
 	}
 
-	edge, err := s.graph.AddEdge(req.Uid, req.SourceUid, req.Label, req.TargetUid, kvs...)
+	m, err := s.mutator(req.TxId)
 	if err != nil {
-		return fmt.Errorf("[AddEdge] Error adding edge: %v", err)
+		return fmt.Errorf("[AddEdge] %v", err)
+	}
+
+	edge, err := m.AddEdge(req.Uid, req.SourceUid, req.Label, req.TargetUid, kvs...)
+	if err != nil {
+		return serviceError(fmt.Errorf("[AddEdge] Error adding edge: %w", err))
 	}
 
 	resp.Uid = edge.UID
@@ -27,6 +32,7 @@ func (s *server) AddEdge(ctx context.Context, req *pb.EdgeReq, resp *pb.EdgeResp
 	resp.Label = edge.Label
 	resp.TargetUid = edge.TargetUID
 	resp.Properties = edge.Properties
+	resp.Version = edge.Version
 
 	return nil
 }
@@ -34,7 +40,13 @@ func (s *server) AddEdge(ctx context.Context, req *pb.EdgeReq, resp *pb.EdgeResp
 func (s *server) RemoveEdge(ctx context.Context, req *pb.UIDReq, resp *pb.RemoveResp) error {
 	resp.Uid = req.Uid
 
-	if err := s.graph.RemoveEdge(req.Uid); err != nil {
+	m, err := s.mutator(req.TxId)
+	if err != nil {
+		resp.Error = err.Error()
+		return fmt.Errorf("[RemoveEdge] %v", err)
+	}
+
+	if err := m.RemoveEdge(req.Uid); err != nil {
 		resp.Error = err.Error()
 		return fmt.Errorf("[RemoveEdge] Error removing edge: %v", err)
 	}
@@ -44,54 +56,196 @@ func (s *server) RemoveEdge(ctx context.Context, req *pb.UIDReq, resp *pb.Remove
 }
 
 func (s *server) Edge(ctx context.Context, req *pb.EdgeReq, resp *pb.EdgeResp) error {
+	if req.AsOf != nil && req.TxId != "" {
+		return fmt.Errorf("[Edge] A transaction can not be read as of a earlier version")
+	}
+
+	var edge graph.Edge
+
+	switch {
 	// if we have a uid in the edge request, then just use that.
-	if req.Uid != "" {
-		edge, err := s.graph.Edge(req.Uid)
+	case req.Uid != "" && req.AsOf == nil:
+		m, err := s.mutator(req.TxId)
+		if err != nil {
+			return fmt.Errorf("[Edge] %v", err)
+		}
+
+		edge, err = m.Edge(req.Uid)
 		if err != nil {
 			return fmt.Errorf("[Edge] Error fetching edge: %v", err)
 		}
 
-		resp.Uid = edge.UID
-		resp.SourceUid = edge.SourceUID
-		resp.Label = edge.Label
-		resp.TargetUid = edge.TargetUID
-		resp.Properties = edge.Properties
-		return nil
-	}
+	case req.Uid != "":
+		snap, err := s.snapshot(req.AsOf)
+		if err != nil {
+			return serviceError(fmt.Errorf("[Edge] %w", err))
+		}
+
+		edge, err = snap.Edge(req.Uid)
+		if err != nil {
+			return fmt.Errorf("[Edge] Error fetching edge: %v", err)
+		}
 
 	// if we don't have a Uid do a filter for labels and properties.
-	iter := s.graph.EdgesBy("", []string{req.Label}, "", req.Properties)
-	if iter.Size() != 1 {
-		return fmt.Errorf("[Edge] Error fetching edge, expected 1 but found %d", iter.Size())
+	default:
+		snap, err := s.snapshot(req.AsOf)
+		if err != nil {
+			return serviceError(fmt.Errorf("[Edge] %w", err))
+		}
+
+		iter := snap.EdgesBy("", []string{req.Label}, "", req.Properties)
+		defer iter.Close()
+
+		size := iter.Size()
+		iter.Next()
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("[Edge] Error fetching edge: %v", err)
+		}
+
+		if size != 1 {
+			return fmt.Errorf("[Edge] Error fetching edge, expected 1 but found %d", size)
+		}
+
+		edge = iter.Edge()
 	}
 
-	edge := iter.Value().(graph.Edge)
 	resp.Uid = edge.UID
 	resp.SourceUid = edge.SourceUID
 	resp.Label = edge.Label
 	resp.TargetUid = edge.TargetUID
 	resp.Properties = edge.Properties
+	resp.Version = edge.Version
 
 	return nil
 }
 
+func (s *server) UpdateEdge(ctx context.Context, req *pb.UpdateEdgeReq, resp *pb.EdgeResp) error {
+	m, err := s.mutator(req.TxId)
+	if err != nil {
+		return fmt.Errorf("[UpdateEdge] %v", err)
+	}
+
+	patch := graph.EdgePatch{
+		Label:            req.Label,
+		SetProperties:    req.SetProperties,
+		DeleteProperties: req.DeleteProperties,
+		Version:          req.Version,
+	}
+
+	edge, err := m.PatchEdge(req.Uid, patch)
+	if err != nil {
+		return serviceError(fmt.Errorf("[UpdateEdge] Error updating edge: %w", err))
+	}
+
+	resp.Uid = edge.UID
+	resp.SourceUid = edge.SourceUID
+	resp.Label = edge.Label
+	resp.TargetUid = edge.TargetUID
+	resp.Properties = edge.Properties
+	resp.Version = edge.Version
+
+	return nil
+}
+
+// edges returns the snapshot read and a iterator over the edges of the
+// request, starting after the page token.
+func (s *server) edges(req *pb.EdgesReq) (*graph.Snapshot, graph.EdgeIterator, error) {
+	snap, err := s.snapshot(req.AsOf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := withFilter(graph.EdgesByPredicate(req.SourceUid, req.Label, req.TargetUid, req.Properties), req.Filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.PageToken != "" {
+		if err := s.checkPaging(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return snap, snap.EdgesWhereAfter(p, after), nil
+}
+
+// convertEdge converts a graph edge into a service edge response.
+func convertEdge(edge graph.Edge) *pb.EdgeResp {
+	return &pb.EdgeResp{
+		Uid:        edge.UID,
+		SourceUid:  edge.SourceUID,
+		Label:      edge.Label,
+		TargetUid:  edge.TargetUID,
+		Properties: edge.Properties,
+		Version:    edge.Version,
+	}
+}
+
 func (s *server) Edges(ctx context.Context, req *pb.EdgesReq, stream pb.Graph_EdgesStream) error {
-	iter := s.graph.EdgesBy(req.SourceUid, req.Label, req.TargetUid, req.Properties)
+	// A stream has no next page token to continue from, so it is not
+	// paged. A page token from EdgesPage streams the rest of the edges.
+	if req.PageSize != 0 {
+		return fmt.Errorf("[Edges] Page size is not supported when streaming, use EdgesPage")
+	}
+
+	_, iter, err := s.edges(req)
+	if err != nil {
+		return serviceError(fmt.Errorf("[Edges] %w", err))
+	}
+
+	// The edges are fetched as they are streamed, stopping early if the
+	// client goes away.
+	defer iter.Close()
+
 	for iter.Next() {
-		edge := iter.Value().(graph.Edge)
-
-		resp := pb.EdgeResp{
-			Uid:        edge.UID,
-			SourceUid:  edge.SourceUID,
-			Label:      edge.Label,
-			TargetUid:  edge.TargetUID,
-			Properties: edge.Properties,
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("[Edges] Stopped streaming edges: %v", err)
 		}
 
-		if err := stream.Send(&resp); err != nil {
+		if err := stream.Send(convertEdge(iter.Edge())); err != nil {
 			return fmt.Errorf("[Edges] Error fetching and streaming edges: %v", err)
 		}
 	}
 
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("[Edges] Error fetching edges: %v", err)
+	}
+
+	return nil
+}
+
+func (s *server) EdgesPage(ctx context.Context, req *pb.EdgesReq, resp *pb.EdgesPageResp) error {
+	if err := s.checkPaging(); err != nil {
+		return serviceError(fmt.Errorf("[EdgesPage] %w", err))
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return fmt.Errorf("[EdgesPage] %v", err)
+	}
+
+	snap, iter, err := s.edges(req)
+	if err != nil {
+		return serviceError(fmt.Errorf("[EdgesPage] %w", err))
+	}
+	defer iter.Close()
+
+	resp.Version = snap.Version()
+	resp.Edges = []*pb.EdgeResp{}
+
+	for len(resp.Edges) < size && iter.Next() {
+		resp.Edges = append(resp.Edges, convertEdge(iter.Edge()))
+	}
+
+	// There is a next page only if there are more edges.
+	if len(resp.Edges) == size && iter.Next() {
+		resp.NextPageToken = encodePageToken(resp.Edges[size-1].Uid)
+	}
+
 	return nil
 }
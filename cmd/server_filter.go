@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/jenmud/draft/graph"
+	pb "github.com/jenmud/draft/service"
+)
+
+// convertFilter converts a service filter into a graph predicate.
+func convertFilter(f *pb.Filter) (graph.Predicate, error) {
+	children := func() ([]graph.Predicate, error) {
+		predicates := make([]graph.Predicate, len(f.Filters))
+		for i, child := range f.Filters {
+			p, err := convertFilter(child)
+			if err != nil {
+				return nil, err
+			}
+			predicates[i] = p
+		}
+		return predicates, nil
+	}
+
+	switch f.Type {
+	case pb.FilterType_AND, pb.FilterType_OR:
+		predicates, err := children()
+		if err != nil {
+			return nil, err
+		}
+
+		if f.Type == pb.FilterType_OR {
+			return graph.Or(predicates...), nil
+		}
+		return graph.And(predicates...), nil
+	case pb.FilterType_NOT:
+		if len(f.Filters) != 1 {
+			return nil, fmt.Errorf("NOT filter needs exactly one filter, found %d", len(f.Filters))
+		}
+
+		p, err := convertFilter(f.Filters[0])
+		if err != nil {
+			return nil, err
+		}
+		return graph.Not(p), nil
+	case pb.FilterType_HAS_LABEL:
+		return graph.HasLabel(f.Label), nil
+	case pb.FilterType_HAS_SOURCE:
+		return graph.HasSource(f.Uid), nil
+	case pb.FilterType_HAS_TARGET:
+		return graph.HasTarget(f.Uid), nil
+	case pb.FilterType_PROP_EQUALS:
+		return graph.PropEquals(f.Key, f.Value), nil
+	case pb.FilterType_PROP_RANGE:
+		return graph.PropRange(f.Key, convertRange(f.Range)), nil
+	case pb.FilterType_DEGREE:
+		return graph.Degree(graph.Direction(f.Direction), int(f.MinDegree), int(f.MaxDegree)), nil
+	}
+
+	return nil, fmt.Errorf("Unknown filter type %d", f.Type)
+}
+
+// convertRange converts a service value range into a graph range. Empty
+// bounds are left unbounded.
+func convertRange(r *pb.ValueRange) graph.Range {
+	if r == nil {
+		return graph.Range{}
+	}
+
+	vr := graph.Range{MinExclusive: r.MinExclusive, MaxExclusive: r.MaxExclusive}
+
+	if len(r.Min) > 0 {
+		vr.Min = r.Min
+	}
+
+	if len(r.Max) > 0 {
+		vr.Max = r.Max
+	}
+
+	if len(r.Prefix) > 0 {
+		vr.Prefix = r.Prefix
+	}
+
+	return vr
+}
+
+// withFilter returns the predicate combined with the filter, if set.
+func withFilter(p graph.Predicate, f *pb.Filter) (graph.Predicate, error) {
+	if f == nil {
+		return p, nil
+	}
+
+	fp, err := convertFilter(f)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid filter: %v", err)
+	}
+
+	return graph.And(p, fp), nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenmud/draft/graph"
+	pb "github.com/jenmud/draft/service"
+)
+
+// snapshot returns the snapshot of the graph as of the version or time,
+// or of the current version if neither is set.
+func (s *server) snapshot(asOf *pb.AsOf) (*graph.Snapshot, error) {
+	switch {
+	case asOf == nil || asOf.Version == 0 && asOf.Time == "":
+		return s.graph.Snapshot(), nil
+	case asOf.Version != 0 && asOf.Time != "":
+		return nil, fmt.Errorf("Only one of the as of version or time can be used")
+	case asOf.Version != 0:
+		return s.graph.SnapshotAtVersion(asOf.Version)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, asOf.Time)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid as of time %q: %v", asOf.Time, err)
+	}
+
+	return s.graph.SnapshotAt(t)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/jenmud/draft/graph"
+	pb "github.com/jenmud/draft/service"
+)
+
+func (s *server) Ingest(ctx context.Context, stream pb.Graph_IngestStream) error {
+	ingester := s.graph.NewIngester(graph.DefaultIngestChunkSize)
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			// The records still queued are dropped rather than applying a
+			// truncated stream, but the chunks already applied are kept.
+			summary := ingester.Abort()
+			return fmt.Errorf(
+				"[Ingest] Error receiving records after creating %d nodes and %d edges: %v",
+				summary.NodesCreated, summary.EdgesCreated, err,
+			)
+		}
+
+		if req.Node != nil {
+			ingester.AddNode(graph.NewNode(req.Node.Uid, req.Node.Labels, convertServicePropsToGraphKVs(req.Node.Properties)...))
+		}
+
+		if req.Edge != nil {
+			ingester.AddEdge(graph.NewEdge(req.Edge.Uid, req.Edge.SourceUid, req.Edge.Label, req.Edge.TargetUid, convertServicePropsToGraphKVs(req.Edge.Properties)...))
+		}
+	}
+
+	summary := ingester.Close()
+
+	// The graph stops taking changes once the write-ahead log fails.
+	if err := ingester.Err(); err != nil {
+		return fmt.Errorf(
+			"[Ingest] Error after creating %d nodes and %d edges: %v",
+			summary.NodesCreated, summary.EdgesCreated, err,
+		)
+	}
+
+	resp := pb.IngestResp{
+		NodesCreated: int32(summary.NodesCreated),
+		EdgesCreated: int32(summary.EdgesCreated),
+		Skipped:      int32(summary.Skipped),
+		Failed:       make([]*pb.IngestFailure, len(summary.Failed)),
+	}
+
+	for i, failure := range summary.Failed {
+		resp.Failed[i] = &pb.IngestFailure{Uid: failure.UID, Reason: failure.Reason}
+	}
+
+	if err := stream.SendMsg(&resp); err != nil {
+		return fmt.Errorf("[Ingest] Error sending summary: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jenmud/draft/graph"
+	pb "github.com/jenmud/draft/service"
+)
+
+// copyProperties returns a copy of the service properties.
+func copyProperties(props map[string][]byte) map[string][]byte {
+	copied := make(map[string][]byte, len(props))
+	for k, v := range props {
+		copied[k] = v
+	}
+	return copied
+}
+
+// mutate makes the change of the mutation in the transaction and returns
+// the uid of the changed node or edge.
+func mutate(tx *graph.Tx, m *pb.Mutation) (string, error) {
+	switch m.Type {
+	case pb.MutationType_ADD_NODE, pb.MutationType_UPDATE_NODE, pb.MutationType_REMOVE_NODE:
+		if m.Node == nil {
+			return "", fmt.Errorf("Missing node for %s mutation", m.Type)
+		}
+	default:
+		if m.Edge == nil {
+			return "", fmt.Errorf("Missing edge for %s mutation", m.Type)
+		}
+	}
+
+	switch m.Type {
+	case pb.MutationType_ADD_NODE:
+		node, err := tx.AddNode(m.Node.Uid, m.Node.Labels, convertServicePropsToGraphKVs(m.Node.Properties)...)
+		return node.UID, err
+
+	case pb.MutationType_UPDATE_NODE:
+		node, err := tx.Node(m.Node.Uid)
+		if err != nil {
+			return m.Node.Uid, err
+		}
+
+		node.Labels = m.Node.Labels
+		node.Properties = copyProperties(m.Node.Properties)
+		node.Version = m.Node.Version
+		_, err = tx.UpdateNode(node)
+		return node.UID, err
+
+	case pb.MutationType_REMOVE_NODE:
+		return m.Node.Uid, tx.RemoveNode(m.Node.Uid)
+
+	case pb.MutationType_ADD_EDGE:
+		edge, err := tx.AddEdge(m.Edge.Uid, m.Edge.SourceUid, m.Edge.Label, m.Edge.TargetUid, convertServicePropsToGraphKVs(m.Edge.Properties)...)
+		return edge.UID, err
+
+	case pb.MutationType_UPDATE_EDGE:
+		edge, err := tx.Edge(m.Edge.Uid)
+		if err != nil {
+			return m.Edge.Uid, err
+		}
+
+		edge.Label = m.Edge.Label
+		edge.Properties = copyProperties(m.Edge.Properties)
+		edge.Version = m.Edge.Version
+		_, err = tx.UpdateEdge(edge)
+		return edge.UID, err
+
+	case pb.MutationType_REMOVE_EDGE:
+		return m.Edge.Uid, tx.RemoveEdge(m.Edge.Uid)
+	}
+
+	return "", fmt.Errorf("Unknown mutation type %s", m.Type)
+}
+
+func (s *server) Mutate(ctx context.Context, req *pb.MutateReq, resp *pb.MutateResp) error {
+	tx := s.graph.Begin()
+	resp.Results = make([]*pb.MutationResult, len(req.Mutations))
+
+	failed := false
+	for i, m := range req.Mutations {
+		result := &pb.MutationResult{}
+		resp.Results[i] = result
+
+		if failed {
+			result.Error = "Not applied, a earlier mutation failed"
+			continue
+		}
+
+		uid, err := mutate(tx, m)
+		result.Uid = uid
+		if err != nil {
+			result.Error = err.Error()
+			failed = true
+		}
+	}
+
+	if failed {
+		tx.Rollback()
+		return nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		// A commit failing for other reasons than a mutation, such as
+		// the write-ahead log or the storage, is a error of the service.
+		var opErr graph.OpError
+		if !errors.As(err, &opErr) {
+			resp.Results = nil
+			return serviceError(err)
+		}
+
+		resp.Results[opErr.Op].Error = opErr.Err.Error()
+		for _, result := range resp.Results {
+			if result.Error == "" {
+				result.Error = "Not applied, a mutation failed"
+			}
+		}
+
+		return nil
+	}
+
+	for _, result := range resp.Results {
+		result.Success = true
+	}
+	resp.Success = true
+
+	return nil
+}
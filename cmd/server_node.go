@@ -17,24 +17,41 @@ func (s *server) AddNode(ctx context.Context, req *pb.NodeReq, resp *pb.NodeResp
 		count++
 	}
 
-	node, err := s.graph.AddNode(req.Uid, req.Label, kvs...)
+	m, err := s.mutator(req.TxId)
 	if err != nil {
-		return fmt.Errorf("[AddNode] Error adding node: %v", err)
+		return fmt.Errorf("[AddNode] %v", err)
+	}
+
+	node, err := m.AddNode(req.Uid, req.Labels, kvs...)
+	if err != nil {
+		return serviceError(fmt.Errorf("[AddNode] Error adding node: %w", err))
 	}
 
 	resp.Uid = node.UID
-	resp.Label = node.Label
+	resp.Labels = node.Labels
 	resp.Properties = node.Properties
 	resp.InEdges = node.InEdges()
 	resp.OutEdges = node.OutEdges()
+	resp.Version = node.Version
 
 	return nil
 }
 
-func (s *server) RemoveNode(ctx context.Context, req *pb.UIDReq, resp *pb.RemoveResp) error {
+func (s *server) RemoveNode(ctx context.Context, req *pb.RemoveNodeReq, resp *pb.RemoveResp) error {
 	resp.Uid = req.Uid
 
-	if err := s.graph.RemoveNode(req.Uid); err != nil {
+	m, err := s.mutator(req.TxId)
+	if err != nil {
+		resp.Error = err.Error()
+		return fmt.Errorf("[RemoveNode] %v", err)
+	}
+
+	opts := []graph.RemoveOption{}
+	if req.Detach {
+		opts = append(opts, graph.Detach())
+	}
+
+	if err := m.RemoveNode(req.Uid, opts...); err != nil {
 		resp.Error = err.Error()
 		resp.Success = false
 		return fmt.Errorf("[RemoveNode] Error removing node: %v", err)
@@ -44,55 +61,220 @@ func (s *server) RemoveNode(ctx context.Context, req *pb.UIDReq, resp *pb.Remove
 	return nil
 }
 
+func (s *server) RemoveNodes(ctx context.Context, req *pb.RemoveNodesReq, resp *pb.RemoveNodesResp) error {
+	opts := []graph.RemoveOption{}
+	if req.Detach {
+		opts = append(opts, graph.Detach())
+	}
+
+	p, err := withFilter(graph.NodesByPredicate(req.Labels, graph.LabelMatch(req.LabelMatch), req.Properties), req.Filter)
+	if err != nil {
+		return fmt.Errorf("[RemoveNodes] %v", err)
+	}
+
+	nodes, edges, err := s.graph.RemoveNodesWhere(p, opts...)
+	if err != nil {
+		return fmt.Errorf("[RemoveNodes] Error removing nodes: %v", err)
+	}
+
+	resp.NodeUids = nodes
+	resp.EdgeUids = edges
+
+	return nil
+}
+
 func (s *server) Node(ctx context.Context, req *pb.NodeReq, resp *pb.NodeResp) error {
+	if req.AsOf != nil && req.TxId != "" {
+		return fmt.Errorf("[Node] A transaction can not be read as of a earlier version")
+	}
+
+	var node graph.Node
+
+	switch {
 	// if we have a uid in the node request, then just use that.
-	if req.Uid != "" {
-		node, err := s.graph.Node(req.Uid)
+	case req.Uid != "" && req.AsOf == nil:
+		m, err := s.mutator(req.TxId)
+		if err != nil {
+			return fmt.Errorf("[Node] %v", err)
+		}
+
+		node, err = m.Node(req.Uid)
 		if err != nil {
 			return fmt.Errorf("[Node] Error fetching node: %v", err)
 		}
 
-		resp.Uid = node.UID
-		resp.Label = node.Label
-		resp.Properties = node.Properties
-		resp.InEdges = node.InEdges()
-		resp.OutEdges = node.OutEdges()
-		return nil
-	}
+	case req.Uid != "":
+		snap, err := s.snapshot(req.AsOf)
+		if err != nil {
+			return serviceError(fmt.Errorf("[Node] %w", err))
+		}
+
+		node, err = snap.Node(req.Uid)
+		if err != nil {
+			return fmt.Errorf("[Node] Error fetching node: %v", err)
+		}
 
 	// if we don't have a Uid do a filter for labels and properties.
-	iter := s.graph.NodesBy([]string{req.Label}, req.Properties)
-	if iter.Size() != 1 {
-		return fmt.Errorf("[Node] Error fetching node, expected 1 but found %d", iter.Size())
+	default:
+		snap, err := s.snapshot(req.AsOf)
+		if err != nil {
+			return serviceError(fmt.Errorf("[Node] %w", err))
+		}
+
+		iter := snap.NodesBy(req.Labels, graph.ALL, req.Properties)
+		defer iter.Close()
+
+		size := iter.Size()
+		iter.Next()
+		if err := iter.Err(); err != nil {
+			return fmt.Errorf("[Node] Error fetching node: %v", err)
+		}
+
+		if size != 1 {
+			return fmt.Errorf("[Node] Error fetching node, expected 1 but found %d", size)
+		}
+
+		node = iter.Node()
+	}
+
+	resp.Uid = node.UID
+	resp.Labels = node.Labels
+	resp.Properties = node.Properties
+	resp.InEdges = node.InEdges()
+	resp.OutEdges = node.OutEdges()
+	resp.Version = node.Version
+
+	return nil
+}
+
+func (s *server) UpdateNode(ctx context.Context, req *pb.UpdateNodeReq, resp *pb.NodeResp) error {
+	m, err := s.mutator(req.TxId)
+	if err != nil {
+		return fmt.Errorf("[UpdateNode] %v", err)
+	}
+
+	patch := graph.NodePatch{
+		AddLabels:        req.AddLabels,
+		RemoveLabels:     req.RemoveLabels,
+		SetProperties:    req.SetProperties,
+		DeleteProperties: req.DeleteProperties,
+		Version:          req.Version,
+	}
+
+	node, err := m.PatchNode(req.Uid, patch)
+	if err != nil {
+		return serviceError(fmt.Errorf("[UpdateNode] Error updating node: %w", err))
 	}
 
-	node := iter.Value().(graph.Node)
 	resp.Uid = node.UID
-	resp.Label = node.Label
+	resp.Labels = node.Labels
 	resp.Properties = node.Properties
 	resp.InEdges = node.InEdges()
 	resp.OutEdges = node.OutEdges()
+	resp.Version = node.Version
 
 	return nil
 }
 
+// nodes returns the snapshot read and a iterator over the nodes of the
+// request, starting after the page token.
+func (s *server) nodes(req *pb.NodesReq) (*graph.Snapshot, graph.NodeIterator, error) {
+	snap, err := s.snapshot(req.AsOf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	p, err := withFilter(graph.NodesByPredicate(req.Label, graph.LabelMatch(req.LabelMatch), req.Properties), req.Filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.PageToken != "" {
+		if err := s.checkPaging(); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	after, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return snap, snap.NodesWhereAfter(p, after), nil
+}
+
+// convertNode converts a graph node into a service node response.
+func convertNode(node graph.Node) *pb.NodeResp {
+	return &pb.NodeResp{
+		Uid:        node.UID,
+		Labels:     node.Labels,
+		Properties: node.Properties,
+		InEdges:    node.InEdges(),
+		OutEdges:   node.OutEdges(),
+		Version:    node.Version,
+	}
+}
+
 func (s *server) Nodes(ctx context.Context, req *pb.NodesReq, stream pb.Graph_NodesStream) error {
-	iter := s.graph.NodesBy(req.Label, req.Properties)
+	// A stream has no next page token to continue from, so it is not
+	// paged. A page token from NodesPage streams the rest of the nodes.
+	if req.PageSize != 0 {
+		return fmt.Errorf("[Nodes] Page size is not supported when streaming, use NodesPage")
+	}
+
+	_, iter, err := s.nodes(req)
+	if err != nil {
+		return serviceError(fmt.Errorf("[Nodes] %w", err))
+	}
+
+	// The nodes are fetched as they are streamed, stopping early if the
+	// client goes away.
+	defer iter.Close()
+
 	for iter.Next() {
-		node := iter.Value().(graph.Node)
-
-		resp := pb.NodeResp{
-			Uid:        node.UID,
-			Label:      node.Label,
-			Properties: node.Properties,
-			InEdges:    node.InEdges(),
-			OutEdges:   node.OutEdges(),
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("[Nodes] Stopped streaming nodes: %v", err)
 		}
 
-		if err := stream.Send(&resp); err != nil {
+		if err := stream.Send(convertNode(iter.Node())); err != nil {
 			return fmt.Errorf("[Nodes] Error fetching and streaming nodes: %v", err)
 		}
 	}
 
+	if err := iter.Err(); err != nil {
+		return fmt.Errorf("[Nodes] Error fetching nodes: %v", err)
+	}
+
+	return nil
+}
+
+func (s *server) NodesPage(ctx context.Context, req *pb.NodesReq, resp *pb.NodesPageResp) error {
+	if err := s.checkPaging(); err != nil {
+		return serviceError(fmt.Errorf("[NodesPage] %w", err))
+	}
+
+	size, err := pageSize(req.PageSize)
+	if err != nil {
+		return fmt.Errorf("[NodesPage] %v", err)
+	}
+
+	snap, iter, err := s.nodes(req)
+	if err != nil {
+		return serviceError(fmt.Errorf("[NodesPage] %w", err))
+	}
+	defer iter.Close()
+
+	resp.Version = snap.Version()
+	resp.Nodes = []*pb.NodeResp{}
+
+	for len(resp.Nodes) < size && iter.Next() {
+		resp.Nodes = append(resp.Nodes, convertNode(iter.Node()))
+	}
+
+	// There is a next page only if there are more nodes.
+	if len(resp.Nodes) == size && iter.Next() {
+		resp.NextPageToken = encodePageToken(resp.Nodes[size-1].Uid)
+	}
+
 	return nil
 }
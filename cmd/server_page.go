@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/jenmud/draft/graph"
+)
+
+const (
+	// defaultPageSize is the page size of the paged requests without a page size.
+	defaultPageSize = 100
+	// maxPageSize is the largest page size of the paged requests.
+	maxPageSize = 1000
+)
+
+// encodePageToken returns the opaque page token of the page continuing
+// after the uid.
+func encodePageToken(uid string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(uid))
+}
+
+// decodePageToken returns the uid the page of the page token continues
+// after. The empty token is the first page.
+func decodePageToken(token string) (string, error) {
+	uid, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("Invalid page token %q", token)
+	}
+	return string(uid), nil
+}
+
+// checkPaging returns a error if the graph can not be read in pages. With
+// a storage which is not versioned, the pages would not all be read from
+// the same version of the graph.
+func (s *server) checkPaging() error {
+	if !s.graph.Versioned() {
+		return fmt.Errorf("Paging is not supported: %w", graph.ErrNotVersioned)
+	}
+	return nil
+}
+
+// pageSize returns the size of the page of a paged request.
+func pageSize(size int32) (int, error) {
+	switch {
+	case size < 0:
+		return 0, fmt.Errorf("Page size can not be negative")
+	case size == 0:
+		return defaultPageSize, nil
+	case size > maxPageSize:
+		return maxPageSize, nil
+	}
+	return int(size), nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jenmud/draft/graph"
+	pb "github.com/jenmud/draft/service"
+)
+
+// mutator is the node and edge API shared by the graph and transactions.
+type mutator interface {
+	AddNode(uid string, labels []string, kv ...graph.KV) (graph.Node, error)
+	RemoveNode(uid string, opts ...graph.RemoveOption) error
+	Node(uid string) (graph.Node, error)
+	PatchNode(uid string, patch graph.NodePatch) (graph.Node, error)
+	AddEdge(uid, sourceUID, label, targetUID string, kv ...graph.KV) (graph.Edge, error)
+	RemoveEdge(uid string) error
+	Edge(uid string) (graph.Edge, error)
+	PatchEdge(uid string, patch graph.EdgePatch) (graph.Edge, error)
+}
+
+// openTx is a open transaction and the time it was last used.
+type openTx struct {
+	tx   *graph.Tx
+	used time.Time
+}
+
+// tx returns the open transaction with the id.
+func (s *server) tx(id string) (*graph.Tx, error) {
+	s.txLock.Lock()
+	defer s.txLock.Unlock()
+
+	open, ok := s.txs[id]
+	if !ok {
+		return nil, fmt.Errorf("No such transaction %s", id)
+	}
+
+	open.used = time.Now()
+	return open.tx, nil
+}
+
+// mutator returns the transaction with the id, or the graph if the id is empty.
+func (s *server) mutator(txID string) (mutator, error) {
+	if txID == "" {
+		return s.graph, nil
+	}
+	return s.tx(txID)
+}
+
+// endTx removes the transaction with the id from the open transactions.
+func (s *server) endTx(id string) (*graph.Tx, error) {
+	s.txLock.Lock()
+	defer s.txLock.Unlock()
+
+	open, ok := s.txs[id]
+	if !ok {
+		return nil, fmt.Errorf("No such transaction %s", id)
+	}
+
+	delete(s.txs, id)
+	return open.tx, nil
+}
+
+// expireTxs rolls back the transactions which have not been used for the
+// timeout, such as those of clients which went away, until stop is closed.
+// A timeout of zero or less never expires the transactions.
+func (s *server) expireTxs(timeout time.Duration, stop <-chan struct{}) {
+	if timeout <= 0 {
+		return
+	}
+
+	interval := timeout / 2
+	if interval <= 0 {
+		interval = timeout
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.txLock.Lock()
+			for id, open := range s.txs {
+				if now.Sub(open.used) < timeout {
+					continue
+				}
+
+				delete(s.txs, id)
+				open.tx.Rollback()
+				log.Printf("Rolled back transaction %s idle for %s", id, now.Sub(open.used))
+			}
+			s.txLock.Unlock()
+		}
+	}
+}
+
+func (s *server) BeginTx(ctx context.Context, req *pb.TxReq, resp *pb.TxResp) error {
+	tx := s.graph.Begin()
+
+	s.txLock.Lock()
+	s.txs[tx.ID()] = &openTx{tx: tx, used: time.Now()}
+	s.txLock.Unlock()
+
+	resp.TxId = tx.ID()
+	return nil
+}
+
+func (s *server) Commit(ctx context.Context, req *pb.TxReq, resp *pb.TxResp) error {
+	resp.TxId = req.TxId
+
+	// The transaction is ended even if committing it fails, as it can not
+	// be committed again.
+	tx, err := s.endTx(req.TxId)
+	if err != nil {
+		return fmt.Errorf("[Commit] %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return serviceError(fmt.Errorf("[Commit] Error committing transaction: %w", err))
+	}
+
+	return nil
+}
+
+func (s *server) Rollback(ctx context.Context, req *pb.TxReq, resp *pb.TxResp) error {
+	resp.TxId = req.TxId
+
+	tx, err := s.endTx(req.TxId)
+	if err != nil {
+		return fmt.Errorf("[Rollback] %v", err)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		return fmt.Errorf("[Rollback] Error rolling back transaction: %v", err)
+	}
+
+	return nil
+}
@@ -4,7 +4,9 @@ go 1.14
 
 require (
 	github.com/golang/protobuf v1.4.1
+	github.com/google/btree v1.0.1
 	github.com/micro/go-micro/v2 v2.6.0
 	github.com/stretchr/testify v1.5.1
+	go.etcd.io/bbolt v1.3.6
 	google.golang.org/protobuf v1.22.0
 )
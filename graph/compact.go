@@ -0,0 +1,241 @@
+package graph
+
+import (
+	"sync"
+	"unsafe"
+
+	"github.com/google/btree"
+)
+
+// symbol is a interned label or property key.
+type symbol uint32
+
+// symbolTable interns the labels and property keys, so each is stored once
+// however many nodes and edges use it. Symbols are never removed, so the
+// names of a symbol can be read from any earlier copy of the names.
+type symbolTable struct {
+	lock  sync.RWMutex
+	ids   map[string]symbol
+	names []string
+}
+
+// newSymbolTable returns a new empty symbol table.
+func newSymbolTable() *symbolTable {
+	return &symbolTable{ids: make(map[string]symbol)}
+}
+
+// intern returns the symbol of the name, adding it if it is new.
+func (t *symbolTable) intern(name string) symbol {
+	t.lock.RLock()
+	id, ok := t.ids[name]
+	t.lock.RUnlock()
+	if ok {
+		return id
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if id, ok := t.ids[name]; ok {
+		return id
+	}
+
+	id = symbol(len(t.names))
+	t.ids[name] = id
+	t.names = append(t.names, name)
+	return id
+}
+
+// lookup returns the symbol of the name, or false if the name has never
+// been interned.
+func (t *symbolTable) lookup(name string) (symbol, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	id, ok := t.ids[name]
+	return id, ok
+}
+
+// list returns the names of all the symbols, indexed by symbol.
+func (t *symbolTable) list() []string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return t.names
+}
+
+// size returns a estimate of the memory used by the symbol table in bytes.
+func (t *symbolTable) size() int {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	size := 0
+	for _, name := range t.names {
+		size += len(name) + int(unsafe.Sizeof(name)) + mapEntrySize(unsafe.Sizeof(name), unsafe.Sizeof(symbol(0)))
+	}
+	return size
+}
+
+// property is a property value with a interned key.
+type property struct {
+	key   symbol
+	value []byte
+}
+
+// nodeRecord is the compact form of a node, with the ids of the edges
+// coming in and going out of it. The labels and properties of a record are
+// never changed, a changed node gets a new record, and edges are only ever
+// appended past the end of the adjacency slices or copied into new ones,
+// so records can be shared with snapshots.
+type nodeRecord struct {
+	uid     string
+	labels  []symbol
+	props   []property
+	in      []elemID
+	out     []elemID
+	version uint64
+}
+
+// adjacency returns the ids of the edges of the node in the direction.
+func (rec *nodeRecord) adjacency(dir Direction) *[]elemID {
+	if dir == OUT {
+		return &rec.out
+	}
+	return &rec.in
+}
+
+// edgeRecord is the compact form of a edge. The same as for nodes, the
+// properties of a record are never changed.
+type edgeRecord struct {
+	uid     string
+	source  string
+	target  string
+	label   symbol
+	props   []property
+	version uint64
+}
+
+// compactProps returns the properties with interned keys.
+func (t *symbolTable) compactProps(props map[string][]byte) []property {
+	if len(props) == 0 {
+		return nil
+	}
+
+	compact := make([]property, 0, len(props))
+	for key, value := range props {
+		compact = append(compact, property{key: t.intern(key), value: value})
+	}
+	return compact
+}
+
+// compactNode returns the compact form of the node.
+func (t *symbolTable) compactNode(node Node) nodeRecord {
+	rec := nodeRecord{
+		uid:     node.UID,
+		props:   t.compactProps(node.Properties),
+		version: node.Version,
+	}
+
+	if len(node.Labels) > 0 {
+		rec.labels = make([]symbol, len(node.Labels))
+		for i, label := range node.Labels {
+			rec.labels[i] = t.intern(label)
+		}
+	}
+
+	return rec
+}
+
+// compactEdge returns the compact form of the edge.
+func (t *symbolTable) compactEdge(edge Edge) edgeRecord {
+	return edgeRecord{
+		uid:     edge.UID,
+		source:  edge.SourceUID,
+		target:  edge.TargetUID,
+		label:   t.intern(edge.Label),
+		props:   t.compactProps(edge.Properties),
+		version: edge.Version,
+	}
+}
+
+// expandProps returns the properties as a map keyed by name.
+func expandProps(names []string, props []property) map[string][]byte {
+	expanded := make(map[string][]byte, len(props))
+	for _, prop := range props {
+		expanded[names[prop.key]] = prop.value
+	}
+	return expanded
+}
+
+// node returns the node of the record, without its edges.
+func (t *symbolTable) node(rec nodeRecord) Node {
+	names := t.list()
+
+	node := Node{
+		UID:        rec.uid,
+		Labels:     make([]string, len(rec.labels)),
+		Properties: expandProps(names, rec.props),
+		Version:    rec.version,
+	}
+
+	for i, label := range rec.labels {
+		node.Labels[i] = names[label]
+	}
+
+	return node
+}
+
+// edge returns the edge of the record.
+func (t *symbolTable) edge(rec edgeRecord) Edge {
+	names := t.list()
+
+	return Edge{
+		UID:        rec.uid,
+		SourceUID:  rec.source,
+		Label:      names[rec.label],
+		TargetUID:  rec.target,
+		Properties: expandProps(names, rec.props),
+		Version:    rec.version,
+	}
+}
+
+// elemID is the internal id of a node or edge, made of the index of its
+// shard and its position in the shard. The ids of removed nodes and edges
+// are reused.
+type elemID uint32
+
+// newElemID returns the id of the position in the shard.
+func newElemID(shard, pos int) elemID {
+	return elemID(pos*shardCount + shard)
+}
+
+// shard returns the index of the shard of the id.
+func (id elemID) shard() int {
+	return int(id) % shardCount
+}
+
+// pos returns the position of the id in its shard.
+func (id elemID) pos() int {
+	return int(id) / shardCount
+}
+
+// mapEntrySize returns a estimate of the size of a map entry in bytes,
+// allowing for the average load of the map buckets.
+func mapEntrySize(key, value uintptr) int {
+	return int(key+value+1) * 8 / 6
+}
+
+// recordSize returns a estimate of the memory used by the uid and the
+// properties of a record in bytes.
+func recordSize(uid string, props []property) int {
+	size := len(uid)
+	for _, prop := range props {
+		size += int(unsafe.Sizeof(prop)) + len(prop.value)
+	}
+	return size
+}
+
+// treeSize returns a estimate of the memory used by the btree in bytes,
+// with each item boxed in a interface of the item size, allowing for the
+// btree nodes being partly full.
+func treeSize(tree *btree.BTree, itemSize uintptr) int {
+	return tree.Len() * int(itemSize+unsafe.Sizeof(btree.Item(nil))) * 3 / 2
+}
@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSymbolTable_intern(t *testing.T) {
+	table := newSymbolTable()
+
+	person := table.intern("person")
+	name := table.intern("name")
+	assert.NotEqual(t, person, name)
+	assert.Equal(t, person, table.intern("person"))
+
+	id, ok := table.lookup("name")
+	assert.True(t, ok)
+	assert.Equal(t, name, id)
+
+	_, ok = table.lookup("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"person", "name"}, table.list())
+}
+
+func TestSymbolTable_node(t *testing.T) {
+	table := newSymbolTable()
+	node := NewNode("node-1", []string{"person", "admin"}, KV{Key: "name", Value: []byte("foo")})
+	node.Version = 3
+
+	actual := table.node(table.compactNode(node))
+	assert.Equal(t, node.UID, actual.UID)
+	assert.Equal(t, node.Labels, actual.Labels)
+	assert.Equal(t, node.Properties, actual.Properties)
+	assert.Equal(t, node.Version, actual.Version)
+}
+
+func TestMemoryShard_reuse(t *testing.T) {
+	s := newMemoryBackend(newSymbolTable()).shards[3]
+	assert.Nil(t, s.PutNode(NewNode("node-1", nil)))
+	assert.Nil(t, s.PutNode(NewNode("node-2", nil)))
+	s.DeleteNode("node-1")
+	assert.Nil(t, s.PutNode(NewNode("node-3", nil)))
+
+	assert.Equal(t, 2, s.nodeCount)
+	id, ok := lookup(s.trees.nodeIDs, "node-3")
+	assert.True(t, ok)
+	assert.Equal(t, newElemID(3, 0), id)
+	assert.Equal(t, "node-3", s.trees.nodeRecord(id).uid)
+
+	assert.False(t, s.HasNode("node-1"))
+	assert.Equal(t, 2, s.NodeCount())
+}
+
+func TestMemoryShard_adjacency(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	g.AddEdge("edge-2", "node-1", "knows", "node-2")
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+
+	before := g.Snapshot()
+	g.RemoveEdge("edge-2")
+	g.AddEdge("edge-3", "node-2", "knows", "node-1")
+
+	store := g.shard("node-1").store
+	assert.Equal(t, []string{"edge-1"}, readAll(store.Adjacency("node-1", OUT, "")))
+	assert.Equal(t, []string{"edge-3"}, readAll(store.Adjacency("node-1", IN, "")))
+	assert.Equal(t, 2, store.Degree("node-1"))
+
+	// The edge ids are read from the views of the snapshot.
+	view := before.shard("node-1")
+	assert.Equal(t, []string{"edge-1", "edge-2"}, readAll(view.Adjacency("node-1", OUT, "")))
+	assert.Equal(t, []string{"edge-2"}, readAll(view.Adjacency("node-1", OUT, "edge-1")))
+	assert.Equal(t, []string{}, readAll(view.Adjacency("node-1", IN, "")))
+
+	view = g.Snapshot().shard("node-1")
+	assert.Equal(t, []string{"edge-1"}, readAll(view.Adjacency("node-1", OUT, "")))
+	assert.Equal(t, []string{"edge-3"}, readAll(view.Adjacency("node-1", IN, "")))
+}
+
+func TestGraph_Stats_bytes_per_element(t *testing.T) {
+	g := New()
+	assert.Equal(t, float64(0), g.Stats().BytesPerElement)
+
+	for i := 0; i < 100; i++ {
+		g.AddNode(fmt.Sprintf("node-%d", i), []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	}
+	for i := 1; i < 100; i++ {
+		g.AddEdge(fmt.Sprintf("edge-%d", i), "node-0", "knows", fmt.Sprintf("node-%d", i))
+	}
+
+	stats := g.Stats()
+	assert.True(t, stats.StoreBytes > 0)
+	assert.Equal(t, float64(stats.StoreBytes)/199, stats.BytesPerElement)
+}
+
+func TestGraph_Stats_indexes(t *testing.T) {
+	g := New()
+	for i := 0; i < 100; i++ {
+		g.AddNode(fmt.Sprintf("node-%d", i), []string{"person"}, KV{Key: "name", Value: []byte(fmt.Sprintf("name-%d", i))})
+	}
+
+	before := g.Stats().StoreBytes
+
+	// The trees are counted along with the pages of records.
+	for _, shard := range g.shards {
+		size, _ := shard.store.Size()
+		trees := shard.store.(*memoryShard).trees.size()
+		assert.True(t, trees > 0)
+		assert.True(t, size > trees)
+	}
+
+	assert.Nil(t, g.CreateIndex("person", "name"))
+	indexed := g.Stats().StoreBytes
+	assert.True(t, indexed > before)
+
+	assert.Nil(t, g.CreateRangeIndex("person", "name"))
+	assert.True(t, g.Stats().StoreBytes > indexed)
+}
@@ -0,0 +1,176 @@
+package graph
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ConstraintType is the kind of schema constraint.
+type ConstraintType int
+
+const (
+	// UNIQUE requires the property value to be unique per label.
+	UNIQUE ConstraintType = iota
+	// EXISTS requires the property to be set.
+	EXISTS
+	// TYPED requires the property value, if set, to be of a value type.
+	TYPED
+)
+
+// String returns the constraint type name.
+func (t ConstraintType) String() string {
+	switch t {
+	case UNIQUE:
+		return "UNIQUE"
+	case EXISTS:
+		return "EXISTS"
+	case TYPED:
+		return "TYPED"
+	}
+	return fmt.Sprintf("ConstraintType(%d)", int(t))
+}
+
+// ValueType is the type of a property value checked by a TYPED constraint.
+type ValueType int
+
+const (
+	// STRING accepts any value.
+	STRING ValueType = iota
+	// INTEGER accepts base 10 integers.
+	INTEGER
+	// FLOAT accepts floating point and integer numbers.
+	FLOAT
+	// BOOLEAN accepts true or false.
+	BOOLEAN
+)
+
+// String returns the value type name.
+func (t ValueType) String() string {
+	switch t {
+	case STRING:
+		return "STRING"
+	case INTEGER:
+		return "INTEGER"
+	case FLOAT:
+		return "FLOAT"
+	case BOOLEAN:
+		return "BOOLEAN"
+	}
+	return fmt.Sprintf("ValueType(%d)", int(t))
+}
+
+// valid returns true if the value is of the value type.
+func (t ValueType) valid(value []byte) bool {
+	var err error
+
+	switch t {
+	case INTEGER:
+		_, err = strconv.ParseInt(string(value), 10, 64)
+	case FLOAT:
+		_, err = strconv.ParseFloat(string(value), 64)
+	case BOOLEAN:
+		_, err = strconv.ParseBool(string(value))
+	}
+
+	return err == nil
+}
+
+// Constraint is a schema constraint on a property of nodes or edges with
+// a label. ValueType is only used by TYPED constraints.
+type Constraint struct {
+	Type      ConstraintType `json:"type"`
+	Item      ItemType       `json:"item"`
+	Label     string         `json:"label"`
+	Property  string         `json:"property"`
+	ValueType ValueType      `json:"value_type,omitempty"`
+}
+
+// String returns the constraint in a readable form.
+func (c Constraint) String() string {
+	target := fmt.Sprintf(":%s(%s)", c.Label, c.Property)
+	if c.Item == EDGE {
+		target = fmt.Sprintf("-[%s]-", target)
+	}
+
+	if c.Type == TYPED {
+		return fmt.Sprintf("%s %s %s", c.Type, c.ValueType, target)
+	}
+
+	return fmt.Sprintf("%s %s", c.Type, target)
+}
+
+// ConstraintError is returned when adding or updating a node or edge
+// would violate a schema constraint.
+type ConstraintError struct {
+	Constraint Constraint
+	UID        string
+	Reason     string
+}
+
+// Error returns the error message.
+func (e ConstraintError) Error() string {
+	return fmt.Sprintf("Constraint %s violated by %s: %s", e.Constraint, e.UID, e.Reason)
+}
+
+// uniqueIndex maps the values of a UNIQUE constraint to the owning uid.
+// The value of each uid is kept so it can be removed even if the
+// properties have since been changed. The lock is held from checking the
+// value of a node or edge until it is indexed, as the changes of other
+// shards share the index.
+type uniqueIndex struct {
+	lock    sync.Mutex
+	byValue map[string]string
+	byUID   map[string]string
+}
+
+// newUniqueIndex returns a new empty unique index.
+func newUniqueIndex() *uniqueIndex {
+	return &uniqueIndex{
+		byValue: make(map[string]string),
+		byUID:   make(map[string]string),
+	}
+}
+
+// add sets the value owned by the uid.
+func (idx *uniqueIndex) add(value []byte, uid string) {
+	idx.remove(uid)
+	idx.byValue[string(value)] = uid
+	idx.byUID[uid] = string(value)
+}
+
+// remove removes the value owned by the uid.
+func (idx *uniqueIndex) remove(uid string) {
+	if value, ok := idx.byUID[uid]; ok {
+		delete(idx.byValue, value)
+		delete(idx.byUID, uid)
+	}
+}
+
+// check returns a error if the properties of the element with the uid
+// violate the constraint. Unique is the index of the values for UNIQUE
+// constraints.
+func (c Constraint) check(uid string, props map[string][]byte, unique *uniqueIndex) error {
+	value, ok := props[c.Property]
+
+	switch c.Type {
+	case EXISTS:
+		if !ok {
+			return ConstraintError{Constraint: c, UID: uid, Reason: fmt.Sprintf("missing property %s", c.Property)}
+		}
+	case TYPED:
+		if ok && !c.ValueType.valid(value) {
+			return ConstraintError{Constraint: c, UID: uid, Reason: fmt.Sprintf("%q is not a %s", value, c.ValueType)}
+		}
+	case UNIQUE:
+		if !ok {
+			return nil
+		}
+
+		if other, ok := unique.byValue[string(value)]; ok && other != uid {
+			return ConstraintError{Constraint: c, UID: uid, Reason: fmt.Sprintf("%q is already used by %s", value, other)}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueType_valid(t *testing.T) {
+	assert.True(t, STRING.valid([]byte("anything")))
+	assert.True(t, INTEGER.valid([]byte("-42")))
+	assert.False(t, INTEGER.valid([]byte("4.2")))
+	assert.True(t, FLOAT.valid([]byte("4.2")))
+	assert.False(t, FLOAT.valid([]byte("four")))
+	assert.True(t, BOOLEAN.valid([]byte("true")))
+	assert.False(t, BOOLEAN.valid([]byte("yes")))
+}
+
+func TestConstraint_String(t *testing.T) {
+	assert.Equal(t, "UNIQUE :person(email)", Constraint{Type: UNIQUE, Label: "person", Property: "email"}.String())
+	assert.Equal(t, "TYPED INTEGER -[:knows(since)]-", Constraint{Type: TYPED, Item: EDGE, Label: "knows", Property: "since", ValueType: INTEGER}.String())
+}
+
+func TestUniqueIndex(t *testing.T) {
+	idx := newUniqueIndex()
+	idx.add([]byte("foo"), "node-1")
+	idx.add([]byte("bar"), "node-1")
+
+	assert.Equal(t, map[string]string{"bar": "node-1"}, idx.byValue)
+
+	idx.remove("node-1")
+	assert.Equal(t, map[string]string{}, idx.byValue)
+	assert.Equal(t, map[string]string{}, idx.byUID)
+}
@@ -12,10 +12,13 @@ func NewEdge(uid, sourceUID, label, targetUID string, kv ...KV) Edge {
 }
 
 // Edge is a edge in the graph.
+// Version is increased every time the edge is changed. When updating a
+// edge, a non zero version must be the current version of the edge.
 type Edge struct {
 	UID        string            `json:"uid"`
 	SourceUID  string            `json:"source_uid"`
 	Label      string            `json:"label"`
 	TargetUID  string            `json:"target_uid"`
 	Properties map[string][]byte `json:"properties"`
+	Version    uint64            `json:"version,omitempty"`
 }
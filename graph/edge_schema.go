@@ -0,0 +1,74 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EdgeSchema restricts the edges with a label. Sources and Targets are the
+// labels allowed on the source and target nodes, a node needs at least one
+// of them. MaxOut limits the number of edges with the label leaving a
+// source node and MaxIn the number entering a target node. Empty label
+// lists and zero limits are unrestricted.
+type EdgeSchema struct {
+	Label   string   `json:"label"`
+	Sources []string `json:"sources,omitempty"`
+	Targets []string `json:"targets,omitempty"`
+	MaxOut  int      `json:"max_out,omitempty"`
+	MaxIn   int      `json:"max_in,omitempty"`
+}
+
+// String returns the schema in a readable form.
+func (s EdgeSchema) String() string {
+	sources := strings.Join(s.Sources, "|")
+	targets := strings.Join(s.Targets, "|")
+	return fmt.Sprintf("(:%s)-[:%s]->(:%s)", sources, s.Label, targets)
+}
+
+// validate returns a error if the schema has no label or negative limits.
+func (s EdgeSchema) validate() error {
+	if s.Label == "" {
+		return fmt.Errorf("Edge schema label is required")
+	}
+
+	if s.MaxOut < 0 || s.MaxIn < 0 {
+		return fmt.Errorf("Edge schema %s limits can not be negative", s)
+	}
+
+	return nil
+}
+
+// EdgeSchemaError is returned when a edge does not fit the schema of its label.
+type EdgeSchemaError struct {
+	Schema EdgeSchema
+	UID    string
+	Reason string
+}
+
+// Error returns the error message.
+func (e EdgeSchemaError) Error() string {
+	return fmt.Sprintf("Edge schema %s violated by %s: %s", e.Schema, e.UID, e.Reason)
+}
+
+// check returns a error if the edge between the source and target nodes
+// does not fit the schema. OutCount and inCount are the number of other
+// edges with the label leaving the source and entering the target.
+func (s EdgeSchema) check(edge Edge, source, target Node, outCount, inCount int) error {
+	if len(s.Sources) > 0 && !source.HasLabels(s.Sources, ANY) {
+		return EdgeSchemaError{Schema: s, UID: edge.UID, Reason: fmt.Sprintf("source %s does not have a allowed label", source.UID)}
+	}
+
+	if len(s.Targets) > 0 && !target.HasLabels(s.Targets, ANY) {
+		return EdgeSchemaError{Schema: s, UID: edge.UID, Reason: fmt.Sprintf("target %s does not have a allowed label", target.UID)}
+	}
+
+	if s.MaxOut > 0 && outCount >= s.MaxOut {
+		return EdgeSchemaError{Schema: s, UID: edge.UID, Reason: fmt.Sprintf("source %s already has %d %s edges", source.UID, outCount, s.Label)}
+	}
+
+	if s.MaxIn > 0 && inCount >= s.MaxIn {
+		return EdgeSchemaError{Schema: s, UID: edge.UID, Reason: fmt.Sprintf("target %s already has %d %s edges", target.UID, inCount, s.Label)}
+	}
+
+	return nil
+}
@@ -19,3 +19,25 @@ const (
 	// EDGE is a edge item type.
 	EDGE
 )
+
+// LabelMatch indicates how a set of labels is matched against a node.
+type LabelMatch int
+
+const (
+	// ANY matches nodes having at least one of the labels.
+	ANY LabelMatch = iota
+	// ALL matches nodes having every one of the labels.
+	ALL
+)
+
+// Direction indicates which edges of a node are used.
+type Direction int
+
+const (
+	// BOTH is the inbound and outbound edges of a node.
+	BOTH Direction = iota
+	// IN is the inbound edges of a node.
+	IN
+	// OUT is the outbound edges of a node.
+	OUT
+)
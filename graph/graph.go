@@ -15,13 +15,55 @@ const (
 	Unlimited = 0
 )
 
+// Option is used for configuring a graph.
+type Option func(*Graph)
+
+// WithUIDGenerator sets the generator used for creating UIDs for nodes
+// and edges which are added without one.
+func WithUIDGenerator(gen UIDGenerator) Option {
+	return func(g *Graph) {
+		g.generateUID = gen
+	}
+}
+
 // New returns a new empty graph.
-func New() *Graph {
-	return &Graph{
-		startTime: time.Now().UTC(),
-		nodes:     make(map[string]Node),
-		edges:     make(map[string]Edge),
+func New(opts ...Option) *Graph {
+	g := &Graph{
+		startTime:   time.Now().UTC(),
+		nodeProps:   make(map[IndexDef]struct{}),
+		nodeRanges:  make(map[IndexDef]struct{}),
+		searches:    make(map[string]*searchIndex),
+		constraints: make(map[Constraint]*uniqueIndex),
+		edgeSchemas: make(map[string]EdgeSchema),
+		symbols:     newSymbolTable(),
+		now:         func() time.Time { return time.Now().UTC() },
+		generateUID: NewULIDGenerator(),
+	}
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	if g.storage == nil {
+		g.storage = newMemoryBackend(g.symbols)
+	}
+
+	for i := range g.shards {
+		g.shards[i] = &shard{store: g.storage.Shard(i), ranges: make(map[IndexDef]*rangeIndex)}
+	}
+
+	// The property indexes are in every shard.
+	for _, def := range g.shards[0].store.Indexes() {
+		g.nodeProps[def] = struct{}{}
+	}
+
+	if backend, ok := g.storage.(schemaBackend); ok {
+		g.restoreSchema(backend)
 	}
+
+	g.history = []*Snapshot{g.takeSnapshot(0, g.now())}
+
+	return g
 }
 
 // NewFromJSON takes a JSON formatted output and returns a new Graph.
@@ -36,11 +78,42 @@ func NewFromJSON(r io.Reader) (*Graph, error) {
 }
 
 // Graph is a graph store.
+//
+// The nodes and edges are spread over shards by UID hash, each with its
+// own lock and storage, so changes to nodes and edges in different shards
+// are made concurrently. The graph lock is held for reading while changing the
+// shards, and for writing while changing the indexes and schema spanning
+// all the shards. The full-text and unique constraint indexes have locks
+// of their own, held briefly by the changes of any shard. Adding a version
+// to the history is still serialised, but the changed shards are committed
+// before.
 type Graph struct {
-	lock      sync.RWMutex
-	startTime time.Time
-	nodes     map[string]Node
-	edges     map[string]Edge
+	// walSeq orders the changes waiting to be logged, it is first for
+	// the alignment needed by 64 bit atomic operations.
+	walSeq      uint64
+	lock        sync.RWMutex
+	startTime   time.Time
+	shards      [shardCount]*shard
+	storage     StorageBackend
+	symbols     *symbolTable
+	nodeProps   map[IndexDef]struct{}
+	nodeRanges  map[IndexDef]struct{}
+	searches    map[string]*searchIndex
+	constraints map[Constraint]*uniqueIndex
+	edgeSchemas map[string]EdgeSchema
+	wal         *WAL
+	// locked is set while the write lock is held by a change, which can
+	// read and change all the shards.
+	locked bool
+	// changed is set when the indexes or schema have changed since the
+	// last version was committed.
+	changed     bool
+	historyLock sync.Mutex
+	history     []*Snapshot
+	retention   RetentionPolicy
+	now         func() time.Time
+	uidLock     sync.Mutex
+	generateUID UIDGenerator
 }
 
 // Stats returns some stats on the current graph instance.
@@ -58,6 +131,25 @@ func (g *Graph) Stats() Stat {
 
 	runtime.ReadMemStats(&s.MemStats)
 
+	elements := 0
+	g.lock.RLock()
+	for _, shard := range g.shards {
+		shard.lock.Lock()
+		size, count := shard.store.Size()
+		for _, idx := range shard.ranges {
+			size += idx.size()
+		}
+		shard.lock.Unlock()
+		s.StoreBytes += size
+		elements += count
+	}
+	g.lock.RUnlock()
+
+	s.StoreBytes += g.symbols.size()
+	if elements > 0 {
+		s.BytesPerElement = float64(s.StoreBytes) / float64(elements)
+	}
+
 	return s
 }
 
@@ -67,8 +159,9 @@ func (g *Graph) Stats() Stat {
 func (g *Graph) SubGraph(uid string, levels int) (*Graph, error) {
 	//TODO: add in the levels
 	subg := New()
+	snap := g.Snapshot()
 
-	node, err := g.Node(uid)
+	node, err := snap.Node(uid)
 	if err != nil {
 		return subg, fmt.Errorf("[SubGraph] %s", err)
 	}
@@ -76,21 +169,21 @@ func (g *Graph) SubGraph(uid string, levels int) (*Graph, error) {
 	var addClosure func(edge Edge) error
 
 	addClosure = func(edge Edge) error {
-		source, err := g.Node(edge.SourceUID)
+		source, err := snap.Node(edge.SourceUID)
 		if err != nil {
 			return fmt.Errorf("[SubGraph] %s", err)
 		}
 
-		target, err := g.Node(edge.TargetUID)
+		target, err := snap.Node(edge.TargetUID)
 		if err != nil {
 			return fmt.Errorf("[SubGraph] %s", err)
 		}
 
 		// Add the node and continue if they are already in the graph.
-		subg.AddNode(source.UID, source.Label, convertPropertiesToKV(source.Properties)...)
-		subg.AddNode(target.UID, target.Label, convertPropertiesToKV(target.Properties)...)
+		subg.copyNode(source)
+		subg.copyNode(target)
 
-		if _, err := subg.AddEdge(edge.UID, source.UID, edge.Label, target.UID, convertPropertiesToKV(edge.Properties)...); err != nil {
+		if _, err := subg.copyEdge(edge); err != nil {
 			return fmt.Errorf("[SubGraph] %s", err)
 		}
 
@@ -98,7 +191,7 @@ func (g *Graph) SubGraph(uid string, levels int) (*Graph, error) {
 	}
 
 	for edgeUID := range node.inEdges {
-		edge, err := g.Edge(edgeUID)
+		edge, err := snap.Edge(edgeUID)
 		if err != nil {
 			return subg, fmt.Errorf("[SubGraph] %s", err)
 		}
@@ -109,7 +202,7 @@ func (g *Graph) SubGraph(uid string, levels int) (*Graph, error) {
 	}
 
 	for edgeUID := range node.outEdges {
-		edge, err := g.Edge(edgeUID)
+		edge, err := snap.Edge(edgeUID)
 		if err != nil {
 			return subg, fmt.Errorf("[SubGraph] %s", err)
 		}
@@ -125,43 +218,25 @@ func (g *Graph) SubGraph(uid string, levels int) (*Graph, error) {
 // See graph_node.go for all the node related methods
 // See graph_edges.go for all the edge related methods
 
-// MarshalJSON marchals the graph into a JSON format.
-func (g *Graph) MarshalJSON() ([]byte, error) {
-	type G struct {
-		Nodes []Node `json:"nodes"`
-		Edges []Edge `json:"edges"`
-	}
-
-	nodes := g.Nodes()
-	edges := g.Edges()
-
-	graph := G{
-		Nodes: make([]Node, nodes.Size()),
-		Edges: make([]Edge, edges.Size()),
-	}
-
-	ncount := 0
-	for nodes.Next() {
-		node := nodes.Value().(Node)
-		graph.Nodes[ncount] = node
-		ncount++
-	}
-
-	ecount := 0
-	for edges.Next() {
-		edge := edges.Value().(Edge)
-		graph.Edges[ecount] = edge
-		ecount++
-	}
-
-	return json.Marshal(graph)
+// MarshalJSON marchals the graph into a JSON format. A snapshot of the
+// graph is used so the nodes and edges are from the same point in time.
+func (g *Graph) MarshalJSON() (data []byte, err error) {
+	err = g.ReadSnapshot(func(s *Snapshot) error {
+		data, err = s.MarshalJSON()
+		return err
+	})
+	return data, err
 }
 
 // UnmarshalJSON unmarshals JSON data into the graph.
 func (g *Graph) UnmarshalJSON(b []byte) error {
 	type G struct {
-		Nodes []Node `json:"nodes"`
-		Edges []Edge `json:"edges"`
+		Nodes         []Node           `json:"nodes"`
+		Edges         []Edge           `json:"edges"`
+		Indexes       []IndexDef       `json:"indexes"`
+		SearchIndexes []SearchIndexDef `json:"search_indexes"`
+		Constraints   []Constraint     `json:"constraints"`
+		EdgeSchemas   []EdgeSchema     `json:"edge_schemas"`
 	}
 
 	graph := G{}
@@ -169,14 +244,45 @@ func (g *Graph) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	for _, def := range graph.Indexes {
+		create := g.CreateIndex
+		if def.Type == RANGE {
+			create = g.CreateRangeIndex
+		}
+
+		if err := create(def.Label, def.Property); err != nil {
+			return err
+		}
+	}
+
+	for _, def := range graph.SearchIndexes {
+		if err := g.CreateSearchIndex(def); err != nil {
+			return err
+		}
+	}
+
+	for _, constraint := range graph.Constraints {
+		if err := g.CreateConstraint(constraint); err != nil {
+			return err
+		}
+	}
+
 	for _, node := range graph.Nodes {
-		if _, err := g.AddNode(node.UID, node.Label, convertPropertiesToKV(node.Properties)...); err != nil {
+		if _, err := g.copyNode(node); err != nil {
 			return err
 		}
 	}
 
 	for _, edge := range graph.Edges {
-		if _, err := g.AddEdge(edge.UID, edge.SourceUID, edge.Label, edge.TargetUID, convertPropertiesToKV(edge.Properties)...); err != nil {
+		if _, err := g.copyEdge(edge); err != nil {
+			return err
+		}
+	}
+
+	// Edge schemas are set last as the existing edges are not checked
+	// when a schema is set, so the dump may contain edges not fitting it.
+	for _, schema := range graph.EdgeSchemas {
+		if err := g.SetEdgeSchema(schema); err != nil {
 			return err
 		}
 	}
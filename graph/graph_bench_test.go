@@ -0,0 +1,130 @@
+package graph
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+// The parallel benchmarks only show the changes to different shards being
+// made at the same time with more than one CPU, run them with -cpu 1,4.
+
+// exclusiveBackend is a storage backend which can not be changed by shard,
+// so every change takes the write lock of the graph.
+type exclusiveBackend struct {
+	StorageBackend
+}
+
+// Concurrent returns false.
+func (exclusiveBackend) Concurrent() bool {
+	return false
+}
+
+// newExclusive returns a graph taking the write lock for every change, the
+// same as before the storage was sharded.
+func newExclusive() *Graph {
+	return New(WithStorage(exclusiveBackend{newMemoryBackend(newSymbolTable())}))
+}
+
+// newSchema returns a graph with a range index, full-text search index,
+// unique constraint and edge schema.
+func newSchema(b *testing.B) *Graph {
+	g := New()
+	if err := g.CreateRangeIndex("person", "name"); err != nil {
+		b.Fatal(err)
+	}
+
+	if err := g.CreateSearchIndex(SearchIndexDef{Name: "names", Type: NODE, Properties: []string{"name"}}); err != nil {
+		b.Fatal(err)
+	}
+
+	if err := g.CreateConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "name"}); err != nil {
+		b.Fatal(err)
+	}
+
+	if err := g.SetEdgeSchema(EdgeSchema{Label: "likes", Sources: []string{"person"}, Targets: []string{"person"}}); err != nil {
+		b.Fatal(err)
+	}
+
+	return g
+}
+
+// benchmarkAddNodes adds nodes to the graph from parallel goroutines.
+func benchmarkAddNodes(b *testing.B, g *Graph) {
+	var count uint64
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			uid := fmt.Sprintf("node-%d", atomic.AddUint64(&count, 1))
+			if _, err := g.AddNode(uid, []string{"person"}, KV{Key: "name", Value: []byte(uid)}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkAddNode(b *testing.B) {
+	g := New()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		uid := fmt.Sprintf("node-%d", i)
+		if _, err := g.AddNode(uid, []string{"person"}, KV{Key: "name", Value: []byte(uid)}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAddNode_parallel(b *testing.B) {
+	benchmarkAddNodes(b, New())
+}
+
+func BenchmarkAddNode_parallel_exclusive(b *testing.B) {
+	benchmarkAddNodes(b, newExclusive())
+}
+
+// The indexes and constraints spanning all the shards are locked on their
+// own, so the changes are still made by shard.
+func BenchmarkAddNode_parallel_schema(b *testing.B) {
+	benchmarkAddNodes(b, newSchema(b))
+}
+
+// benchmarkAddEdges adds edges between random pairs of a set of nodes
+// from parallel goroutines, so most edges lock the shards of three UIDs.
+func benchmarkAddEdges(b *testing.B, g *Graph) {
+	const nodeCount = 1024
+	for i := 0; i < nodeCount; i++ {
+		if _, err := g.AddNode(fmt.Sprintf("node-%d", i), []string{"person"}); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	var count uint64
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			n := atomic.AddUint64(&count, 1)
+			source := fmt.Sprintf("node-%d", n%nodeCount)
+			target := fmt.Sprintf("node-%d", (n*7919)%nodeCount)
+			if _, err := g.AddEdge(fmt.Sprintf("edge-%d", n), source, "likes", target); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkAddEdge_parallel(b *testing.B) {
+	benchmarkAddEdges(b, New())
+}
+
+func BenchmarkAddEdge_parallel_exclusive(b *testing.B) {
+	benchmarkAddEdges(b, newExclusive())
+}
+
+func BenchmarkAddEdge_parallel_schema(b *testing.B) {
+	benchmarkAddEdges(b, newSchema(b))
+}
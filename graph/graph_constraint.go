@@ -0,0 +1,282 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CreateConstraint adds a schema constraint to the graph. The existing
+// nodes or edges are checked and the constraint is not added if any of
+// them violate it.
+func (g *Graph) CreateConstraint(c Constraint) (err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+
+	if _, ok := g.constraints[c]; ok {
+		return fmt.Errorf("[CreateConstraint] Constraint %s already exists", c)
+	}
+
+	return g.createConstraint(c)
+}
+
+// createConstraint adds the constraint, which does not exist yet, if none
+// of the existing nodes or edges violate it. The caller is expected to be
+// holding the write lock.
+func (g *Graph) createConstraint(c Constraint) error {
+	var unique *uniqueIndex
+	if c.Type == UNIQUE {
+		unique = newUniqueIndex()
+	}
+
+	add := func(uid string, props map[string][]byte) error {
+		if err := c.check(uid, props, unique); err != nil {
+			return fmt.Errorf("[CreateConstraint] %w", err)
+		}
+
+		if value, ok := props[c.Property]; ok && unique != nil {
+			unique.add(value, uid)
+		}
+
+		return nil
+	}
+
+	switch c.Item {
+	case NODE:
+		iter := g.view().NodesWhere(HasLabel(c.Label))
+		for iter.Next() {
+			if err := add(iter.Node().UID, iter.Node().Properties); err != nil {
+				return err
+			}
+		}
+	case EDGE:
+		iter := g.view().EdgesWhere(HasLabel(c.Label))
+		for iter.Next() {
+			if err := add(iter.Edge().UID, iter.Edge().Properties); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("[CreateConstraint] Unknown item type %d", c.Item)
+	}
+
+	g.constraints[c] = unique
+	g.changed = true
+	g.recordSchema(walEntry{Op: walCreateConstraint, Constraint: &c}, func() { g.dropConstraint(c) })
+	return nil
+}
+
+// DropConstraint removes the schema constraint.
+func (g *Graph) DropConstraint(c Constraint) (err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+
+	if _, ok := g.constraints[c]; !ok {
+		return fmt.Errorf("[DropConstraint] No such constraint %s", c)
+	}
+
+	g.dropConstraint(c)
+	return nil
+}
+
+// SchemaChange is a set of changes to the constraints and edge schemas
+// applied together by ChangeSchema.
+type SchemaChange struct {
+	DropConstraints   []Constraint
+	RemoveEdgeSchemas []string
+	CreateConstraints []Constraint
+	SetEdgeSchemas    []EdgeSchema
+}
+
+// ChangeSchema drops the constraints and removes the edge schemas, then
+// creates the constraints and sets the edge schemas of the change. Either
+// all of the change is applied or none of it is, so a constraint which is
+// violated by the existing nodes or edges leaves the schema as it was.
+func (g *Graph) ChangeSchema(change SchemaChange) (err error) {
+	for _, schema := range change.SetEdgeSchemas {
+		if err := schema.validate(); err != nil {
+			return fmt.Errorf("[ChangeSchema] %w", err)
+		}
+	}
+
+	w := g.exclusive()
+	defer w.release(&err)
+
+	for _, c := range change.DropConstraints {
+		if _, ok := g.constraints[c]; !ok {
+			return fmt.Errorf("[ChangeSchema] No such constraint %s", c)
+		}
+		g.dropConstraint(c)
+	}
+
+	for _, label := range change.RemoveEdgeSchemas {
+		if _, ok := g.edgeSchemas[label]; !ok {
+			return fmt.Errorf("[ChangeSchema] No edge schema for label %s", label)
+		}
+		g.removeEdgeSchema(label)
+	}
+
+	for _, c := range change.CreateConstraints {
+		if _, ok := g.constraints[c]; ok {
+			return fmt.Errorf("[ChangeSchema] Constraint %s already exists", c)
+		}
+
+		if err := g.createConstraint(c); err != nil {
+			return fmt.Errorf("[ChangeSchema] %w", err)
+		}
+	}
+
+	for _, schema := range change.SetEdgeSchemas {
+		g.setEdgeSchema(schema)
+	}
+
+	return nil
+}
+
+// dropConstraint removes the constraint, which is expected to exist.
+// The caller is expected to be holding the write lock.
+func (g *Graph) dropConstraint(c Constraint) {
+	unique := g.constraints[c]
+	delete(g.constraints, c)
+	g.changed = true
+	g.recordSchema(walEntry{Op: walDropConstraint, Constraint: &c}, func() { g.constraints[c] = unique })
+}
+
+// Constraints returns all the schema constraints sorted by item type,
+// label, property and constraint type.
+func (g *Graph) Constraints() []Constraint {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.listConstraints()
+}
+
+// listConstraints returns all the constraints.
+// The caller is expected to be holding the read lock.
+func (g *Graph) listConstraints() []Constraint {
+	constraints := make([]Constraint, 0, len(g.constraints))
+	for c := range g.constraints {
+		constraints = append(constraints, c)
+	}
+
+	sort.Slice(constraints, func(i, j int) bool {
+		a, b := constraints[i], constraints[j]
+		switch {
+		case a.Item != b.Item:
+			return a.Item < b.Item
+		case a.Label != b.Label:
+			return a.Label < b.Label
+		case a.Property != b.Property:
+			return a.Property < b.Property
+		case a.Type != b.Type:
+			return a.Type < b.Type
+		}
+		return a.ValueType < b.ValueType
+	})
+
+	return constraints
+}
+
+// checkConstraints returns a ConstraintError if the node or edge with the
+// uid, labels and properties violates any of the constraints. The caller
+// is expected to be holding the unique constraint locks of the labels.
+func (g *Graph) checkConstraints(item ItemType, uid string, labels []string, props map[string][]byte) error {
+	for c, unique := range g.constraints {
+		if c.Item != item {
+			continue
+		}
+
+		for _, label := range labels {
+			if label != c.Label {
+				continue
+			}
+
+			if err := c.check(uid, props, unique); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// lockUnique locks the unique constraint indexes of the item type on any
+// of the labels and returns a function unlocking them. The indexes are
+// locked in order of item type, label and property, so changes in
+// different shards can not deadlock. The caller is expected to be holding
+// the lock of the node or edge shard.
+func (g *Graph) lockUnique(item ItemType, labels ...[]string) func() {
+	type locked struct {
+		c      Constraint
+		unique *uniqueIndex
+	}
+
+	indexes := []locked{}
+	for c, unique := range g.constraints {
+		if unique == nil || c.Item != item {
+			continue
+		}
+
+		for _, set := range labels {
+			if containsString(set, c.Label) {
+				indexes = append(indexes, locked{c: c, unique: unique})
+				break
+			}
+		}
+	}
+
+	if len(indexes) == 0 {
+		return func() {}
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		a, b := indexes[i].c, indexes[j].c
+		if a.Item != b.Item {
+			return a.Item < b.Item
+		}
+		if a.Label != b.Label {
+			return a.Label < b.Label
+		}
+		return a.Property < b.Property
+	})
+
+	for _, idx := range indexes {
+		idx.unique.lock.Lock()
+	}
+
+	return func() {
+		for i := len(indexes) - 1; i >= 0; i-- {
+			indexes[i].unique.lock.Unlock()
+		}
+	}
+}
+
+// indexUnique adds the node or edge values to the unique constraint indexes.
+// The caller is expected to be holding the unique constraint locks of the
+// labels.
+func (g *Graph) indexUnique(item ItemType, uid string, labels []string, props map[string][]byte) {
+	for c, unique := range g.constraints {
+		if unique == nil || c.Item != item {
+			continue
+		}
+
+		for _, label := range labels {
+			if label != c.Label {
+				continue
+			}
+
+			if value, ok := props[c.Property]; ok {
+				unique.add(value, uid)
+			}
+		}
+	}
+}
+
+// unindexUnique removes the node or edge with the labels from the unique
+// constraint indexes. The caller is expected to be holding the unique
+// constraint locks of the labels.
+func (g *Graph) unindexUnique(item ItemType, uid string, labels []string) {
+	for c, unique := range g.constraints {
+		if unique != nil && c.Item == item && containsString(labels, c.Label) {
+			unique.remove(uid)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateConstraint(t *testing.T) {
+	g := New()
+	unique := Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "email"}
+	exists := Constraint{Type: EXISTS, Item: NODE, Label: "person", Property: "name"}
+
+	assert.Nil(t, g.CreateConstraint(unique))
+	assert.Nil(t, g.CreateConstraint(exists))
+	assert.NotNil(t, g.CreateConstraint(unique))
+	assert.Equal(t, []Constraint{unique, exists}, g.Constraints())
+
+	assert.Nil(t, g.DropConstraint(unique))
+	assert.NotNil(t, g.DropConstraint(unique))
+	assert.Equal(t, []Constraint{exists}, g.Constraints())
+}
+
+func TestCreateConstraint_existing_violations(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+
+	err := g.CreateConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "email"})
+	assert.True(t, errors.As(err, &ConstraintError{}))
+
+	err = g.CreateConstraint(Constraint{Type: EXISTS, Item: NODE, Label: "person", Property: "name"})
+	assert.True(t, errors.As(err, &ConstraintError{}))
+
+	assert.Equal(t, []Constraint{}, g.Constraints())
+}
+
+func TestChangeSchema(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+
+	exists := Constraint{Type: EXISTS, Item: NODE, Label: "person", Property: "email"}
+	unique := Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "email"}
+	schema := EdgeSchema{Label: "knows", Sources: []string{"person"}}
+	assert.Nil(t, g.CreateConstraint(exists))
+	assert.Nil(t, g.SetEdgeSchema(schema))
+	version := g.Version()
+
+	// The unique constraint is violated, so the drop and removal are undone.
+	err := g.ChangeSchema(SchemaChange{
+		DropConstraints:   []Constraint{exists},
+		RemoveEdgeSchemas: []string{"knows"},
+		CreateConstraints: []Constraint{unique},
+	})
+	assert.True(t, errors.As(err, &ConstraintError{}))
+	assert.Equal(t, version, g.Version())
+	assert.Equal(t, []Constraint{exists}, g.Constraints())
+	assert.Equal(t, []EdgeSchema{schema}, g.EdgeSchemas())
+
+	err = g.ChangeSchema(SchemaChange{SetEdgeSchemas: []EdgeSchema{{Label: "likes", MaxIn: -1}}})
+	assert.NotNil(t, err)
+
+	g.UpdateNode(NewNode("node-2", []string{"person"}, KV{Key: "email", Value: []byte("bar@example.com")}))
+	err = g.ChangeSchema(SchemaChange{
+		DropConstraints:   []Constraint{exists},
+		RemoveEdgeSchemas: []string{"knows"},
+		CreateConstraints: []Constraint{unique},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []Constraint{unique}, g.Constraints())
+	assert.Equal(t, []EdgeSchema{}, g.EdgeSchemas())
+}
+
+func TestAddNode_constraints(t *testing.T) {
+	g := New()
+	g.CreateConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "email"})
+	g.CreateConstraint(Constraint{Type: EXISTS, Item: NODE, Label: "person", Property: "name"})
+	g.CreateConstraint(Constraint{Type: TYPED, Item: NODE, Label: "person", Property: "age", ValueType: INTEGER})
+
+	_, err := g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("foo")}, KV{Key: "email", Value: []byte("foo@example.com")})
+	assert.Nil(t, err)
+
+	var constraintErr ConstraintError
+
+	_, err = g.AddNode("node-2", []string{"person"}, KV{Key: "name", Value: []byte("bar")}, KV{Key: "email", Value: []byte("foo@example.com")})
+	assert.True(t, errors.As(err, &constraintErr))
+	assert.Equal(t, UNIQUE, constraintErr.Constraint.Type)
+	assert.False(t, g.HasNode("node-2"))
+
+	_, err = g.AddNode("node-2", []string{"person"}, KV{Key: "email", Value: []byte("bar@example.com")})
+	assert.True(t, errors.As(err, &constraintErr))
+	assert.Equal(t, EXISTS, constraintErr.Constraint.Type)
+
+	_, err = g.AddNode("node-2", []string{"person"}, KV{Key: "name", Value: []byte("bar")}, KV{Key: "age", Value: []byte("old")})
+	assert.True(t, errors.As(err, &constraintErr))
+	assert.Equal(t, TYPED, constraintErr.Constraint.Type)
+
+	// other labels are not constrained
+	_, err = g.AddNode("node-3", []string{"pet"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	assert.Nil(t, err)
+}
+
+func TestUpdateNode_constraints(t *testing.T) {
+	g := New()
+	g.CreateConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "email"})
+
+	n1, _ := g.AddNode("node-1", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	n2, _ := g.AddNode("node-2", []string{"person"}, KV{Key: "email", Value: []byte("bar@example.com")})
+
+	// updating a node with its own value is allowed
+	n1, err := g.UpdateNode(n1)
+	assert.Nil(t, err)
+
+	n2.Properties = map[string][]byte{"email": []byte("foo@example.com")}
+	_, err = g.UpdateNode(n2)
+	assert.True(t, errors.As(err, &ConstraintError{}))
+
+	// changing the value releases the old value
+	n1.Properties = map[string][]byte{"email": []byte("baz@example.com")}
+	_, err = g.UpdateNode(n1)
+	assert.Nil(t, err)
+
+	_, err = g.UpdateNode(n2)
+	assert.Nil(t, err)
+
+	g.RemoveNode(n2.UID)
+	_, err = g.AddNode("node-3", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	assert.Nil(t, err)
+}
+
+func TestAddEdge_constraints(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	g.CreateConstraint(Constraint{Type: EXISTS, Item: EDGE, Label: "knows", Property: "since"})
+	g.CreateConstraint(Constraint{Type: TYPED, Item: EDGE, Label: "knows", Property: "since", ValueType: INTEGER})
+
+	_, err := g.AddEdge("edge-1", "node-1", "knows", "node-2")
+	assert.True(t, errors.As(err, &ConstraintError{}))
+
+	source, _ := g.Node("node-1")
+	assert.Equal(t, 0, len(source.OutEdges()))
+
+	edge, err := g.AddEdge("edge-1", "node-1", "knows", "node-2", KV{Key: "since", Value: []byte("2010")})
+	assert.Nil(t, err)
+
+	edge.Properties = map[string][]byte{"since": []byte("last year")}
+	_, err = g.UpdateEdge(edge)
+	assert.True(t, errors.As(err, &ConstraintError{}))
+}
+
+func TestQuery_constraints(t *testing.T) {
+	g := New()
+
+	_, err := g.Query(`CREATE CONSTRAINT ON (n:person) ASSERT n.email IS UNIQUE`)
+	assert.Nil(t, err)
+	_, err = g.Query(`CREATE CONSTRAINT ON ()-[r:knows]->() ASSERT exists(r.since)`)
+	assert.Nil(t, err)
+	_, err = g.Query(`CREATE CONSTRAINT ON (n:person) ASSERT n.age IS :: INTEGER`)
+	assert.Nil(t, err)
+
+	assert.Equal(
+		t,
+		[]Constraint{
+			{Type: TYPED, Item: NODE, Label: "person", Property: "age", ValueType: INTEGER},
+			{Type: UNIQUE, Item: NODE, Label: "person", Property: "email"},
+			{Type: EXISTS, Item: EDGE, Label: "knows", Property: "since"},
+		},
+		g.Constraints(),
+	)
+
+	_, err = g.Query(`DROP CONSTRAINT ON (n:person) ASSERT n.email IS UNIQUE`)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(g.Constraints()))
+}
+
+func TestMarshalJSON_constraints(t *testing.T) {
+	g := New()
+	g.CreateConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "email"})
+	g.AddNode("node-1", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+
+	dump, err := json.Marshal(g)
+	assert.Nil(t, err)
+
+	actual := New()
+	assert.Nil(t, json.Unmarshal(dump, actual))
+	assert.Equal(t, g.Constraints(), actual.Constraints())
+
+	_, err = actual.AddNode("node-2", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	assert.NotNil(t, err)
+}
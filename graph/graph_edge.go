@@ -1,248 +1,244 @@
 package graph
 
 import (
-	"bytes"
 	"fmt"
-
-	"github.com/jenmud/draft/graph/iterator"
 )
 
 // HasEdge returns true if the graph has a edge with the provided uid.
 func (g *Graph) HasEdge(uid string) bool {
-	g.lock.RLock()
-	defer g.lock.RUnlock()
-
-	_, ok := g.edges[uid]
-	return ok
+	return g.Snapshot().HasEdge(uid)
 }
 
-// UpdateEdge updates the graph edge with the new edge.
-func (g *Graph) UpdateEdge(edge Edge) (Edge, error) {
-	if !g.HasEdge(edge.UID) {
-		return edge, fmt.Errorf("[UpdateEdge] Edge does not exists, can not update edge %s", edge)
-	}
-
-	g.lock.Lock()
-	defer g.lock.Unlock()
-
-	g.edges[edge.UID] = edge
-	return edge, nil
+// UpdateEdge updates the graph edge with the new edge. The source and
+// target of a edge can not be changed. If the edge version is set, the
+// update fails with a VersionError unless it is the current version of the
+// graph edge.
+func (g *Graph) UpdateEdge(edge Edge) (updated Edge, err error) {
+	w := g.writeEdge(edge.UID)
+	defer w.release(&err)
+	return g.updateEdge(edge)
 }
 
-// AddEdge adds a new edge to the graph.
-func (g *Graph) AddEdge(uid, sourceUID, label, targetUID string, kv ...KV) (Edge, error) {
-	if !g.HasNode(sourceUID) {
-		return Edge{}, fmt.Errorf("[AddEdge] No such not with UID %s", sourceUID)
+// updateEdge updates the graph edge with the new edge. As the source and
+// target do not change, the adjacency is left as is. The caller is expected
+// to be holding the locks of the edge, source and target shards, as the
+// edge schema is checked against the edges of the source and target.
+func (g *Graph) updateEdge(edge Edge) (Edge, error) {
+	current, ok := g.edge(edge.UID)
+	if !ok {
+		return edge, fmt.Errorf("[UpdateEdge] Edge does not exists, can not update edge %s", edge.UID)
 	}
 
-	if !g.HasNode(targetUID) {
-		return Edge{}, fmt.Errorf("[AddEdge] No such not with UID %s", targetUID)
+	if err := checkVersion(edge.UID, edge.Version, current.Version); err != nil {
+		return edge, fmt.Errorf("[UpdateEdge] %w", err)
 	}
 
-	source, err := g.Node(sourceUID)
-	if err != nil {
-		return Edge{}, fmt.Errorf("[AddEdge] %s", err)
+	if edge.SourceUID != current.SourceUID || edge.TargetUID != current.TargetUID {
+		return edge, fmt.Errorf("[UpdateEdge] Can not change the source or target of edge %s", edge.UID)
 	}
 
-	target, err := g.Node(targetUID)
-	if err != nil {
-		return Edge{}, fmt.Errorf("[AddEdge] %s", err)
-	}
+	defer g.lockUnique(EDGE, []string{current.Label}, []string{edge.Label})()
 
-	g.lock.Lock()
-	defer g.lock.Unlock()
+	if err := g.checkConstraints(EDGE, edge.UID, []string{edge.Label}, edge.Properties); err != nil {
+		return edge, fmt.Errorf("[UpdateEdge] %w", err)
+	}
 
-	if _, ok := g.edges[uid]; ok {
-		return Edge{}, fmt.Errorf("[AddEdge] Edge UID %s already exists", uid)
+	if err := g.checkEdgeSchema(edge); err != nil {
+		return edge, fmt.Errorf("[UpdateEdge] %w", err)
 	}
 
-	edge := NewEdge(uid, sourceUID, label, targetUID, kv...)
-	g.edges[edge.UID] = edge
+	edge.Version = current.Version + 1
+	if err := g.shard(edge.UID).store.PutEdge(edge); err != nil {
+		return edge, fmt.Errorf("[UpdateEdge] %w", err)
+	}
 
-	// (source)->(target)
-	source.outEdges[edge.UID] = struct{}{}
-	target.inEdges[edge.UID] = struct{}{}
+	g.unindexEdge(current)
+	g.indexEdge(edge)
+	g.record(edge.UID, walEntry{Op: walPutEdge, Edge: &edge}, func() { g.restoreEdge(current) })
 
 	return edge, nil
 }
 
-// RemoveEdge removes the edge from the graph.
-func (g *Graph) RemoveEdge(uid string) error {
-	edge, err := g.Edge(uid)
-	if err != nil {
-		return fmt.Errorf("[RemoveEdge] %s", err)
-	}
+// PatchEdge applies the patch to the edge with the uid.
+func (g *Graph) PatchEdge(uid string, patch EdgePatch) (edge Edge, err error) {
+	w := g.writeEdge(uid)
+	defer w.release(&err)
 
-	// (source)->(target)
-	source, err := g.Node(edge.SourceUID)
-	if err != nil {
-		// this is only here for safty, but we shoud not
-		// get into a situation where this error is returned.
-		return fmt.Errorf("[RemoveEdge] %s", err)
+	current, ok := g.edge(uid)
+	if !ok {
+		return Edge{}, fmt.Errorf("[PatchEdge] [GetEdge] No such edge with UID %s found", uid)
 	}
-	delete(source.outEdges, uid)
 
-	target, err := g.Node(edge.TargetUID)
+	edge, err = g.updateEdge(patch.apply(current))
 	if err != nil {
-		// this is only here for safty, but we shoud not
-		// get into a situation where this error is returned.
-		return fmt.Errorf("[RemoveEdge] %s", err)
+		return edge, fmt.Errorf("[PatchEdge] %w", err)
 	}
-	delete(target.inEdges, uid)
-
-	g.lock.Lock()
-	defer g.lock.Unlock()
 
-	delete(g.edges, uid)
-	return nil
+	return edge, nil
 }
 
-// Edge returns the edge with the provided uid.
-func (g *Graph) Edge(uid string) (Edge, error) {
-	g.lock.RLock()
-	defer g.lock.RUnlock()
-
-	edge, ok := g.edges[uid]
-	if !ok {
-		return Edge{}, fmt.Errorf("[GetEdge] No such edge with UID %s found", uid)
+// restoreEdge replaces or adds the edge as is, including the adjacency of
+// the source and target nodes, without any checks. It is used for undoing
+// changes. The caller is expected to be holding the locks of the edge,
+// source and target shards.
+func (g *Graph) restoreEdge(edge Edge) error {
+	current, ok := g.edge(edge.UID)
+	labels := []string{edge.Label}
+	if ok {
+		labels = append(labels, current.Label)
 	}
+	defer g.lockUnique(EDGE, labels)()
 
-	return edge, nil
-}
-
-// Edges returns a edge iterator.
-func (g *Graph) Edges() Iterator {
-	g.lock.RLock()
-	defer g.lock.RUnlock()
+	if err := g.shard(edge.UID).store.PutEdge(edge); err != nil {
+		return err
+	}
 
-	edges := make([]interface{}, len(g.edges))
-	count := 0
-	for _, edge := range g.edges {
-		edges[count] = edge
-		count++
+	if ok {
+		g.unindexEdge(current)
 	}
 
-	return iterator.New(edges)
+	g.indexEdge(edge)
+	g.linkEdge(edge)
+	g.record(edge.UID, walEntry{Op: walPutEdge, Edge: &edge}, func() {
+		if ok {
+			g.restoreEdge(current)
+		} else {
+			g.removeEdge(edge.UID)
+		}
+	})
+	return nil
 }
 
-// edgeMapper converts interfaces into Edges
-func edgeMapper(in <-chan interface{}, out chan<- Edge) {
-	for item := range in {
-		out <- item.(Edge)
-	}
-	close(out)
+// linkEdge adds the stored edge to the adjacency of the source and target
+// nodes, unless it is already there. The caller is expected to be holding
+// the locks of the edge, source and target shards.
+func (g *Graph) linkEdge(edge Edge) {
+	// (source)->(target)
+	g.shard(edge.SourceUID).store.Link(edge.SourceUID, OUT, edge.UID)
+	g.shard(edge.TargetUID).store.Link(edge.TargetUID, IN, edge.UID)
 }
 
-// edgeLabelReducer filters for edges that have the given labels.
-func edgeLabelReducer(labels []string, in <-chan Edge, out chan<- Edge) {
-	for edge := range in {
-		if len(labels) == 0 {
-			out <- edge
-			continue
-		}
+// unlinkEdge removes the stored edge from the adjacency of the source and
+// target nodes. The caller is expected to be holding the locks of the edge,
+// source and target shards.
+func (g *Graph) unlinkEdge(edge Edge) {
+	// (source)->(target)
+	g.shard(edge.SourceUID).store.Unlink(edge.SourceUID, OUT, edge.UID)
+	g.shard(edge.TargetUID).store.Unlink(edge.TargetUID, IN, edge.UID)
+}
 
-		for _, label := range labels {
-			if edge.Label == label {
-				out <- edge
-				continue
-			}
-		}
+// AddEdge adds a new edge to the graph.
+// If uid is empty, a new UID is generated for the edge.
+func (g *Graph) AddEdge(uid, sourceUID, label, targetUID string, kv ...KV) (edge Edge, err error) {
+	uid = g.ensureUID(uid)
+	w := g.write(uid, sourceUID, targetUID)
+	defer w.release(&err)
+	return g.addEdge(uid, sourceUID, label, targetUID, kv...)
+}
+
+// addEdge adds a new edge to the graph and the adjacency of the source
+// and target nodes. The uid is expected to be set and the caller is
+// expected to be holding the locks of the edge, source and target shards.
+func (g *Graph) addEdge(uid, sourceUID, label, targetUID string, kv ...KV) (Edge, error) {
+	if uid == "" {
+		return Edge{}, fmt.Errorf("[AddEdge] Edge UID is empty")
 	}
 
-	close(out)
-}
+	if !g.hasNode(sourceUID) {
+		return Edge{}, fmt.Errorf("[AddEdge] No such not with UID %s", sourceUID)
+	}
 
-// edgeSourceTargetReducer filters for edges that have the given source or target uids.
-// If source or target is empty, then all edges will be returned.
-func edgeSourceTargetReducer(source, target string, in <-chan Edge, out chan<- Edge) {
-	for edge := range in {
-		// if there is no source or target add the edge
-		if source == "" && target == "" {
-			out <- edge
-			continue
-		}
+	if !g.hasNode(targetUID) {
+		return Edge{}, fmt.Errorf("[AddEdge] No such not with UID %s", targetUID)
+	}
 
-		// if there is a source and not target, then only filter on source
-		if target == "" && source == edge.SourceUID {
-			out <- edge
-			continue
-		}
+	if g.hasEdge(uid) {
+		return Edge{}, fmt.Errorf("[AddEdge] Edge UID %s already exists", uid)
+	}
 
-		// if there is a target and not source, then only filter on target
-		if source == "" && target == edge.TargetUID {
-			out <- edge
-			continue
-		}
+	edge := NewEdge(uid, sourceUID, label, targetUID, kv...)
+	defer g.lockUnique(EDGE, []string{edge.Label})()
 
-		if source == edge.SourceUID && target == edge.TargetUID {
-			out <- edge
-			continue
-		}
+	if err := g.checkConstraints(EDGE, edge.UID, []string{edge.Label}, edge.Properties); err != nil {
+		return Edge{}, fmt.Errorf("[AddEdge] %w", err)
 	}
 
-	close(out)
-}
+	if err := g.checkEdgeSchema(edge); err != nil {
+		return Edge{}, fmt.Errorf("[AddEdge] %w", err)
+	}
 
-// edgePropReducer filters for edges that have the given properties.
-func edgePropReducer(props map[string][]byte, in <-chan Edge, out chan<- Edge) {
-	for edge := range in {
-		if len(props) == 0 {
-			out <- edge
-			continue
-		}
+	edge.Version = 1
+	if err := g.shard(edge.UID).store.PutEdge(edge); err != nil {
+		return Edge{}, fmt.Errorf("[AddEdge] %w", err)
+	}
 
-		var allMatched = false
-		for key, value := range props {
-			nvalue, ok := edge.Properties[key]
-			if !ok {
-				allMatched = false
-				break
-			}
+	g.indexEdge(edge)
+	g.linkEdge(edge)
+	g.record(edge.UID, walEntry{Op: walPutEdge, Edge: &edge}, func() { g.removeEdge(edge.UID) })
 
-			if !bytes.Equal(value, nvalue) {
-				allMatched = false
-				break
-			}
+	return edge, nil
+}
 
-			allMatched = true
-		}
+// RemoveEdge removes the edge from the graph.
+func (g *Graph) RemoveEdge(uid string) (err error) {
+	w := g.writeEdge(uid)
+	defer w.release(&err)
+	return g.removeEdge(uid)
+}
 
-		if allMatched {
-			out <- edge
-		}
+// removeEdge removes the edge from the graph and the adjacency of the
+// source and target nodes. The caller is expected to be holding the locks
+// of the edge, source and target shards.
+func (g *Graph) removeEdge(uid string) error {
+	edge, ok := g.edge(uid)
+	if !ok {
+		return fmt.Errorf("[RemoveEdge] [GetEdge] No such edge with UID %s found", uid)
 	}
 
-	close(out)
+	g.unlinkEdge(edge)
+	unlock := g.lockUnique(EDGE, []string{edge.Label})
+	g.unindexEdge(edge)
+	unlock()
+
+	g.shard(uid).store.DeleteEdge(uid)
+	g.record(uid, walEntry{Op: walRemoveEdge, UID: uid}, func() { g.restoreEdge(edge) })
+	return nil
 }
 
-// EdgesBy returns a edge iterator with filtered edges.
-// If labels is an empty list, then any label will be used.
-// If props is an empty map, no properties will be used for filtering.
-func (g *Graph) EdgesBy(source string, labels []string, target string, props map[string][]byte) Iterator {
-	g.lock.RLock()
-	defer g.lock.RUnlock()
+// Edge returns the edge with the provided uid, read from a snapshot of
+// the graph.
+func (g *Graph) Edge(uid string) (Edge, error) {
+	return g.Snapshot().Edge(uid)
+}
 
-	in := make(chan Edge, len(g.edges))
-	labelFiltered := make(chan Edge, len(g.edges))
-	sourceTargetFiltered := make(chan Edge, len(g.edges))
-	final := make(chan Edge)
+// Edges returns a lazy edge iterator over a snapshot of the graph, with
+// the edges ordered by UID.
+func (g *Graph) Edges() EdgeIterator {
+	return g.Snapshot().Edges()
+}
 
-	go edgeMapper(g.Edges().Channel(), in)
-	go edgeLabelReducer(labels, in, labelFiltered)
-	go edgeSourceTargetReducer(source, target, labelFiltered, sourceTargetFiltered)
-	go edgePropReducer(props, sourceTargetFiltered, final)
+// EdgesWhere returns a lazy edge iterator over a snapshot of the graph
+// with the edges matching the predicate ordered by UID.
+func (g *Graph) EdgesWhere(p Predicate) EdgeIterator {
+	return g.Snapshot().EdgesWhere(p)
+}
 
-	edges := []interface{}{}
-	for edge := range final {
-		edges = append(edges, edge)
-	}
+// EdgesWhereAfter returns a lazy edge iterator over a snapshot of the
+// graph the same as EdgesWhere, but starting after the edge with the uid.
+func (g *Graph) EdgesWhereAfter(p Predicate, after string) EdgeIterator {
+	return g.Snapshot().EdgesWhereAfter(p, after)
+}
 
-	return iterator.New(edges)
+// EdgesBy returns a lazy edge iterator over a snapshot of the graph with
+// filtered edges ordered by UID.
+// If source or target is empty, they are not used for filtering.
+// If labels is an empty list, then any label will be used.
+// If props is an empty map, no properties will be used for filtering.
+func (g *Graph) EdgesBy(source string, labels []string, target string, props map[string][]byte) EdgeIterator {
+	return g.Snapshot().EdgesBy(source, labels, target, props)
 }
 
 // EdgeCount returns the total number of edges in the graph.
 func (g *Graph) EdgeCount() int {
-	g.lock.RLock()
-	defer g.lock.RUnlock()
-	return len(g.edges)
+	return g.Snapshot().EdgeCount()
 }
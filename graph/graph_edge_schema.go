@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SetEdgeSchema sets the schema for the edges with the schema label,
+// replacing any existing schema for the label. New edges are checked
+// against the schema, use ValidateEdges to check the existing edges.
+func (g *Graph) SetEdgeSchema(schema EdgeSchema) (err error) {
+	if err := schema.validate(); err != nil {
+		return fmt.Errorf("[SetEdgeSchema] %w", err)
+	}
+
+	w := g.exclusive()
+	defer w.release(&err)
+
+	g.setEdgeSchema(schema)
+	return nil
+}
+
+// setEdgeSchema sets the schema for the edges with the schema label.
+// The caller is expected to be holding the write lock.
+func (g *Graph) setEdgeSchema(schema EdgeSchema) {
+	previous, ok := g.edgeSchemas[schema.Label]
+	g.edgeSchemas[schema.Label] = schema
+	g.changed = true
+
+	g.recordSchema(walEntry{Op: walSetEdgeSchema, EdgeSchema: &schema}, func() {
+		if ok {
+			g.setEdgeSchema(previous)
+		} else {
+			g.removeEdgeSchema(schema.Label)
+		}
+	})
+}
+
+// RemoveEdgeSchema removes the schema for the edges with the label.
+func (g *Graph) RemoveEdgeSchema(label string) (err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+
+	if _, ok := g.edgeSchemas[label]; !ok {
+		return fmt.Errorf("[RemoveEdgeSchema] No edge schema for label %s", label)
+	}
+
+	g.removeEdgeSchema(label)
+	return nil
+}
+
+// removeEdgeSchema removes the schema for the edges with the label, which
+// is expected to exist. The caller is expected to be holding the write lock.
+func (g *Graph) removeEdgeSchema(label string) {
+	previous := g.edgeSchemas[label]
+	delete(g.edgeSchemas, label)
+	g.changed = true
+	g.recordSchema(walEntry{Op: walRemoveEdgeSchema, Name: label}, func() { g.setEdgeSchema(previous) })
+}
+
+// EdgeSchemas returns all the edge schemas sorted by label.
+func (g *Graph) EdgeSchemas() []EdgeSchema {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.listEdgeSchemas()
+}
+
+// listEdgeSchemas returns all the edge schemas.
+// The caller is expected to be holding the read lock.
+func (g *Graph) listEdgeSchemas() []EdgeSchema {
+	schemas := make([]EdgeSchema, 0, len(g.edgeSchemas))
+	for _, schema := range g.edgeSchemas {
+		schemas = append(schemas, schema)
+	}
+
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Label < schemas[j].Label
+	})
+
+	return schemas
+}
+
+// ValidateEdges checks all the existing edges against the edge schemas and
+// returns the edges which do not fit, sorted by edge UID. When a node has
+// too many edges with a label, all of those edges are returned.
+func (g *Graph) ValidateEdges() []EdgeSchemaError {
+	// The write lock is held so all the shards can be read, nothing is
+	// changed.
+	w := g.exclusive()
+	defer w.unlock()
+
+	violations := []EdgeSchemaError{}
+
+	for label := range g.edgeSchemas {
+		iter := g.view().EdgesWhere(HasLabel(label))
+		for iter.Next() {
+			if err := g.checkEdgeSchema(iter.Edge()); err != nil {
+				violations = append(violations, err.(EdgeSchemaError))
+			}
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		return violations[i].UID < violations[j].UID
+	})
+
+	return violations
+}
+
+// countEdges returns the number of edges of the node in the direction with
+// the label, excluding the edge with the uid. The caller is expected to be
+// holding the lock of the node shard.
+//
+// The edges are in the shards of their UIDs, which may be changed by other
+// writers, so unless the write lock is held they are read from the current
+// snapshot. The edges of the node are only changed while holding the lock
+// of its shard, and are committed before it is released, so the snapshot
+// has them as they are.
+func (g *Graph) countEdges(node string, dir Direction, label, uid string) int {
+	lookup := g.edge
+	if !g.locked {
+		snap := g.Snapshot()
+		lookup = func(uid string) (Edge, bool) {
+			return snap.shard(uid).Edge(uid)
+		}
+	}
+
+	count := 0
+	edges := g.shard(node).store.Adjacency(node, dir, "")
+	for edgeUID, ok := edges(); ok; edgeUID, ok = edges() {
+		if edgeUID == uid {
+			continue
+		}
+
+		if edge, found := lookup(edgeUID); found && edge.Label == label {
+			count++
+		}
+	}
+	return count
+}
+
+// checkEdgeSchema returns a EdgeSchemaError if the edge does not fit the
+// schema of its label. The caller is expected to be holding the locks of
+// the edge, source and target shards.
+func (g *Graph) checkEdgeSchema(edge Edge) error {
+	schema, ok := g.edgeSchemas[edge.Label]
+	if !ok {
+		return nil
+	}
+
+	source, _ := g.node(edge.SourceUID)
+	target, _ := g.node(edge.TargetUID)
+
+	return schema.check(
+		edge,
+		source,
+		target,
+		g.countEdges(edge.SourceUID, OUT, edge.Label, edge.UID),
+		g.countEdges(edge.TargetUID, IN, edge.Label, edge.UID),
+	)
+}
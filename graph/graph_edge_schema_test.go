@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetEdgeSchema(t *testing.T) {
+	g := New()
+	schema := EdgeSchema{Label: "works_at", Sources: []string{"person"}, Targets: []string{"company"}, MaxOut: 1}
+
+	assert.Nil(t, g.SetEdgeSchema(schema))
+	assert.NotNil(t, g.SetEdgeSchema(EdgeSchema{}))
+	assert.NotNil(t, g.SetEdgeSchema(EdgeSchema{Label: "knows", MaxIn: -1}))
+	assert.Equal(t, []EdgeSchema{schema}, g.EdgeSchemas())
+
+	assert.Nil(t, g.RemoveEdgeSchema("works_at"))
+	assert.NotNil(t, g.RemoveEdgeSchema("works_at"))
+	assert.Equal(t, []EdgeSchema{}, g.EdgeSchemas())
+}
+
+func TestAddEdge_edge_schema(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+	g.AddNode("node-bar", []string{"person"})
+	g.AddNode("node-acme", []string{"company"})
+	g.AddNode("node-corp", []string{"company"})
+	g.SetEdgeSchema(EdgeSchema{Label: "works_at", Sources: []string{"person"}, Targets: []string{"company"}, MaxOut: 1})
+
+	var schemaErr EdgeSchemaError
+
+	_, err := g.AddEdge("edge-1", "node-acme", "works_at", "node-foo")
+	assert.True(t, errors.As(err, &schemaErr))
+	assert.Equal(t, "works_at", schemaErr.Schema.Label)
+
+	_, err = g.AddEdge("edge-1", "node-foo", "works_at", "node-bar")
+	assert.True(t, errors.As(err, &schemaErr))
+
+	_, err = g.AddEdge("edge-1", "node-foo", "works_at", "node-acme")
+	assert.Nil(t, err)
+
+	// at most once per person
+	_, err = g.AddEdge("edge-2", "node-foo", "works_at", "node-corp")
+	assert.True(t, errors.As(err, &schemaErr))
+	assert.False(t, g.HasEdge("edge-2"))
+
+	_, err = g.AddEdge("edge-2", "node-bar", "works_at", "node-acme")
+	assert.Nil(t, err)
+
+	// other labels are not restricted
+	_, err = g.AddEdge("edge-3", "node-acme", "knows", "node-corp")
+	assert.Nil(t, err)
+
+	// updating a edge does not count itself
+	edge, _ := g.Edge("edge-1")
+	_, err = g.UpdateEdge(edge)
+	assert.Nil(t, err)
+}
+
+func TestValidateEdges(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+	g.AddNode("node-acme", []string{"company"})
+	g.AddNode("node-corp", []string{"company"})
+	g.AddEdge("edge-1", "node-foo", "works_at", "node-acme")
+	g.AddEdge("edge-2", "node-foo", "works_at", "node-corp")
+	g.AddEdge("edge-3", "node-acme", "works_at", "node-corp")
+	g.AddEdge("edge-4", "node-acme", "knows", "node-corp")
+
+	assert.Equal(t, []EdgeSchemaError{}, g.ValidateEdges())
+
+	g.SetEdgeSchema(EdgeSchema{Label: "works_at", Sources: []string{"person"}, MaxOut: 1})
+
+	uids := []string{}
+	for _, violation := range g.ValidateEdges() {
+		uids = append(uids, violation.UID)
+	}
+
+	assert.Equal(t, []string{"edge-1", "edge-2", "edge-3"}, uids)
+}
+
+func TestMarshalJSON_edge_schemas(t *testing.T) {
+	g := New()
+	g.SetEdgeSchema(EdgeSchema{Label: "works_at", Sources: []string{"person"}, Targets: []string{"company"}, MaxOut: 1})
+
+	dump, err := json.Marshal(g)
+	assert.Nil(t, err)
+
+	actual := New()
+	assert.Nil(t, json.Unmarshal(dump, actual))
+	assert.Equal(t, g.EdgeSchemas(), actual.EdgeSchemas())
+}
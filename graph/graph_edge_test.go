@@ -1,6 +1,10 @@
 package graph
 
 import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,10 +13,11 @@ import (
 func TestAddEdge(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	expected := NewEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
+	expected.Version = 1
 	actual, err := g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 
 	assert.Nil(t, err)
@@ -31,7 +36,7 @@ func TestAddEdge(t *testing.T) {
 func TestAddEdge_missing_source(t *testing.T) {
 	g := New()
 
-	n2, _ := g.AddNode("node-2", "person")
+	n2, _ := g.AddNode("node-2", []string{"person"})
 	actual, err := g.AddEdge("edge-1234", "nissing", "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 
 	assert.NotNil(t, err)
@@ -41,7 +46,7 @@ func TestAddEdge_missing_source(t *testing.T) {
 func TestAddEdge_missing_target(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
 	actual, err := g.AddEdge("edge-1234", n1.UID, "knows", "missing", KV{Key: "since", Value: []byte("school")})
 
 	assert.NotNil(t, err)
@@ -51,8 +56,8 @@ func TestAddEdge_missing_target(t *testing.T) {
 func TestAddEdge_duplicate_uid(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 	g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	actual, err := g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 
@@ -63,8 +68,8 @@ func TestAddEdge_duplicate_uid(t *testing.T) {
 func TestHasEdge(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 	g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 
 	assert.Equal(t, true, g.HasEdge("edge-1234"))
@@ -73,8 +78,8 @@ func TestHasEdge(t *testing.T) {
 func TestHasEdge_missing(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 	g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 
 	assert.Equal(t, false, g.HasEdge("missing"))
@@ -83,9 +88,9 @@ func TestHasEdge_missing(t *testing.T) {
 func TestRemoveEdge(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
-	n3, _ := g.AddNode("node-3", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
+	n3, _ := g.AddNode("node-3", []string{"person"})
 
 	edge1, _ := g.AddEdge("edge-1", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	edge2, _ := g.AddEdge("edge-2", n1.UID, "knows", n3.UID)
@@ -120,8 +125,8 @@ func TestRemoveEdge_missing_edge(t *testing.T) {
 func TestEdge(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 	expected, _ := g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	actual, err := g.Edge("edge-1234")
 
@@ -141,8 +146,8 @@ func TestEdge_no_such_edge(t *testing.T) {
 func TestEdges(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	e1, _ := g.AddEdge("edge-1234", n1.UID, "knows", n2.UID)
 	e2, _ := g.AddEdge("edge-2345", n1.UID, "knows", n2.UID)
@@ -163,8 +168,8 @@ func TestEdges(t *testing.T) {
 func TestUpdateEdge(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	old, _ := g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	old.Properties["since"] = []byte("2020")
@@ -172,6 +177,7 @@ func TestUpdateEdge(t *testing.T) {
 	updated, err := g.UpdateEdge(old)
 
 	assert.Nil(t, err)
+	old.Version++
 	assert.Equal(t, updated, old)
 
 	source, _ := g.Node(n1.UID)
@@ -184,11 +190,66 @@ func TestUpdateEdge(t *testing.T) {
 	assert.Equal(t, true, ok)
 }
 
+func TestUpdateEdge_version(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+
+	edge, _ := g.AddEdge("edge-1", "node-1", "knows", "node-2")
+	assert.Equal(t, uint64(1), edge.Version)
+
+	updated, err := g.UpdateEdge(edge)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), updated.Version)
+
+	_, err = g.UpdateEdge(edge)
+	assert.True(t, errors.As(err, &VersionError{}))
+}
+
+func TestUpdateEdge_source_target(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+
+	edge, _ := g.AddEdge("edge-1", "node-1", "knows", "node-2")
+	edge.TargetUID = "node-1"
+
+	_, err := g.UpdateEdge(edge)
+	assert.NotNil(t, err)
+}
+
+func TestPatchEdge(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2", KV{Key: "since", Value: []byte("school")})
+
+	patched, err := g.PatchEdge("edge-1", EdgePatch{
+		Label:            "likes",
+		SetProperties:    map[string][]byte{"weight": []byte("10")},
+		DeleteProperties: []string{"since"},
+	})
+	assert.Nil(t, err)
+
+	edge, _ := g.Edge("edge-1")
+	assert.Equal(t, patched, edge)
+	assert.Equal(t, Edge{UID: "edge-1", SourceUID: "node-1", Label: "likes", TargetUID: "node-2", Properties: map[string][]byte{"weight": []byte("10")}, Version: 2}, edge)
+	assert.Equal(t, 0, g.EdgesBy("", []string{"knows"}, "", nil).Size())
+
+	// A empty label keeps the label.
+	edge, err = g.PatchEdge("edge-1", EdgePatch{Version: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, "likes", edge.Label)
+
+	_, err = g.PatchEdge("edge-1", EdgePatch{Version: 2})
+	assert.True(t, errors.As(err, &VersionError{}))
+}
+
 func TestUpdateEdge_missing_edge(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	old, _ := g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	g.RemoveEdge(old.UID)
@@ -203,8 +264,8 @@ func TestUpdateEdge_missing_edge(t *testing.T) {
 func TestEdgeCount(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	g.AddEdge("edge-1", n1.UID, "knows", n2.UID)
 	g.AddEdge("edge-2", n1.UID, "knows", n2.UID)
@@ -216,8 +277,8 @@ func TestEdgeCount(t *testing.T) {
 func TestEdgesBy__label(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	e1, _ := g.AddEdge("edge-1234", n1.UID, "knows", n2.UID)
 	e2, _ := g.AddEdge("edge-2345", n1.UID, "likes", n2.UID)
@@ -249,8 +310,8 @@ func TestEdgesBy__label(t *testing.T) {
 func TestEdgesBy__Properties(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	e1, _ := g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	g.AddEdge("edge-2345", n1.UID, "likes", n2.UID, KV{Key: "like-a", Value: []byte("friend")})
@@ -271,8 +332,8 @@ func TestEdgesBy__Properties(t *testing.T) {
 func TestEdgesBy__source(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	e1, _ := g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	e2, _ := g.AddEdge("edge-2345", n1.UID, "likes", n2.UID, KV{Key: "like-a", Value: []byte("friend")})
@@ -293,8 +354,8 @@ func TestEdgesBy__source(t *testing.T) {
 func TestEdgesBy__target(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	g.AddEdge("edge-2345", n1.UID, "likes", n2.UID, KV{Key: "like-a", Value: []byte("friend")})
@@ -315,8 +376,8 @@ func TestEdgesBy__target(t *testing.T) {
 func TestEdgesBy__source_target(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	g.AddEdge("edge-2345", n1.UID, "likes", n2.UID, KV{Key: "like-a", Value: []byte("friend")})
@@ -337,8 +398,8 @@ func TestEdgesBy__source_target(t *testing.T) {
 func TestEdgesBy__source_label(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	e2, _ := g.AddEdge("edge-2345", n1.UID, "likes", n2.UID, KV{Key: "like-a", Value: []byte("friend")})
@@ -359,8 +420,8 @@ func TestEdgesBy__source_label(t *testing.T) {
 func TestEdgesBy__target_label(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	e2, _ := g.AddEdge("edge-2345", n1.UID, "likes", n2.UID, KV{Key: "like-a", Value: []byte("friend")})
@@ -381,8 +442,8 @@ func TestEdgesBy__target_label(t *testing.T) {
 func TestEdgesBy__source_property(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 
 	e1, _ := g.AddEdge("edge-1234", n1.UID, "knows", n2.UID, KV{Key: "since", Value: []byte("school")})
 	g.AddEdge("edge-2345", n1.UID, "likes", n2.UID, KV{Key: "like-a", Value: []byte("friend")})
@@ -399,3 +460,124 @@ func TestEdgesBy__source_property(t *testing.T) {
 
 	assert.ElementsMatch(t, expected, actual)
 }
+
+// checkAdjacency checks every edge is in the adjacency of its source and
+// target nodes, and every edge in the adjacency of a node is attached to
+// it, both in the graph and in the current snapshot.
+func checkAdjacency(t *testing.T, g *Graph) {
+	t.Helper()
+
+	for _, shard := range g.shards {
+		for _, uid := range readAll(shard.store.Edges("")) {
+			edge, _ := shard.store.Edge(uid)
+			assert.True(t, g.hasNode(edge.SourceUID), edge.SourceUID)
+			assert.True(t, g.hasNode(edge.TargetUID), edge.TargetUID)
+			assert.Contains(t, readAll(g.shard(edge.SourceUID).store.Adjacency(edge.SourceUID, OUT, "")), uid)
+			assert.Contains(t, readAll(g.shard(edge.TargetUID).store.Adjacency(edge.TargetUID, IN, "")), uid)
+		}
+
+		for _, uid := range readAll(shard.store.Nodes("")) {
+			out := readAll(shard.store.Adjacency(uid, OUT, ""))
+			for _, edgeUID := range out {
+				edge, ok := g.edge(edgeUID)
+				assert.True(t, ok, edgeUID)
+				assert.Equal(t, uid, edge.SourceUID)
+			}
+
+			in := readAll(shard.store.Adjacency(uid, IN, ""))
+			for _, edgeUID := range in {
+				edge, ok := g.edge(edgeUID)
+				assert.True(t, ok, edgeUID)
+				assert.Equal(t, uid, edge.TargetUID)
+			}
+			assert.Equal(t, len(in)+len(out), shard.store.Degree(uid), uid)
+		}
+	}
+
+	snap := g.Snapshot()
+	edges := snap.Edges()
+	for edges.Next() {
+		edge := edges.Edge()
+		source, err := snap.Node(edge.SourceUID)
+		assert.Nil(t, err)
+		target, err := snap.Node(edge.TargetUID)
+		assert.Nil(t, err)
+		assert.Contains(t, source.outEdges, edge.UID)
+		assert.Contains(t, target.inEdges, edge.UID)
+	}
+
+	nodes := snap.Nodes()
+	for nodes.Next() {
+		node := nodes.Node()
+		for _, edgeUID := range node.Edges() {
+			assert.True(t, snap.HasEdge(edgeUID), edgeUID)
+		}
+	}
+}
+
+func TestAddEdge_RemoveEdge_concurrent(t *testing.T) {
+	const (
+		workers   = 8
+		nodeCount = 16
+		ops       = 200
+	)
+
+	g := New()
+	for i := 0; i < nodeCount; i++ {
+		g.AddNode(fmt.Sprintf("node-%d", i), []string{"person"})
+	}
+
+	kept := make([][]string, workers)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(int64(w)))
+			node := func() string {
+				return fmt.Sprintf("node-%d", r.Intn(nodeCount))
+			}
+
+			added := []string{}
+			for i := 0; i < ops; i++ {
+				switch r.Intn(4) {
+				case 0, 1:
+					edge, err := g.AddEdge(fmt.Sprintf("edge-%d-%d", w, i), node(), "knows", node())
+					assert.Nil(t, err)
+					added = append(added, edge.UID)
+				case 2:
+					if len(added) == 0 {
+						continue
+					}
+					n := r.Intn(len(added))
+					assert.Nil(t, g.RemoveEdge(added[n]))
+					added = append(added[:n], added[n+1:]...)
+				case 3:
+					// The nodes returned are not changed by the other workers.
+					patched, err := g.PatchNode(node(), NodePatch{SetProperties: map[string][]byte{"worker": []byte{byte(w)}}})
+					assert.Nil(t, err)
+					patched.Edges()
+
+					read, err := g.Node(node())
+					assert.Nil(t, err)
+					read.Edges()
+				}
+			}
+
+			kept[w] = added
+		}(w)
+	}
+	wg.Wait()
+
+	checkAdjacency(t, g)
+
+	count := 0
+	for _, added := range kept {
+		for _, uid := range added {
+			assert.True(t, g.HasEdge(uid), uid)
+		}
+		count += len(added)
+	}
+	assert.Equal(t, count, g.EdgeCount())
+}
@@ -0,0 +1,244 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CreateIndex creates a property hash index on nodes with the label.
+// Existing nodes are indexed straight away.
+func (g *Graph) CreateIndex(label, property string) (err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+
+	def := IndexDef{Label: label, Property: property}
+	if _, ok := g.nodeProps[def]; ok {
+		return fmt.Errorf("[CreateIndex] Index on %s already exists", def)
+	}
+
+	g.createIndex(def)
+	return nil
+}
+
+// DropIndex removes the property index on nodes with the label.
+func (g *Graph) DropIndex(label, property string) (err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+
+	def := IndexDef{Label: label, Property: property}
+	if _, ok := g.nodeProps[def]; !ok {
+		return fmt.Errorf("[DropIndex] No such index on %s", def)
+	}
+
+	g.dropIndex(def)
+	return nil
+}
+
+// CreateRangeIndex creates a ordered property index on nodes with the
+// label, used for range and prefix lookups and ordering by the property.
+// Existing nodes are indexed straight away.
+func (g *Graph) CreateRangeIndex(label, property string) (err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+
+	def := IndexDef{Label: label, Property: property, Type: RANGE}
+	if _, ok := g.nodeRanges[def]; ok {
+		return fmt.Errorf("[CreateRangeIndex] Index on %s already exists", def)
+	}
+
+	g.createIndex(def)
+	return nil
+}
+
+// DropRangeIndex removes the ordered property index on nodes with the label.
+func (g *Graph) DropRangeIndex(label, property string) (err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+
+	def := IndexDef{Label: label, Property: property, Type: RANGE}
+	if _, ok := g.nodeRanges[def]; !ok {
+		return fmt.Errorf("[DropRangeIndex] No such index on %s", def)
+	}
+
+	g.dropIndex(def)
+	return nil
+}
+
+// createIndex creates the hash or range index, which does not exist yet,
+// and indexes the existing nodes. The caller is expected to be holding the
+// write lock.
+func (g *Graph) createIndex(def IndexDef) {
+	if def.Type == RANGE {
+		// Each shard has a range index of its own nodes.
+		for _, shard := range g.shards {
+			shard.ranges[def] = newRangeIndex()
+		}
+
+		iter := g.view().NodesWhere(HasLabel(def.Label))
+		for iter.Next() {
+			node := iter.Node()
+			if value, ok := node.Properties[def.Property]; ok {
+				g.shard(node.UID).ranges[def].add(value, node.UID)
+			}
+		}
+
+		g.nodeRanges[def] = struct{}{}
+	} else {
+		for _, shard := range g.shards {
+			shard.store.CreateIndex(def)
+		}
+
+		g.nodeProps[def] = struct{}{}
+	}
+
+	g.changed = true
+	g.recordSchema(walEntry{Op: walCreateIndex, Index: &def}, func() { g.dropIndex(def) })
+}
+
+// dropIndex removes the hash or range index, which is expected to exist.
+// The caller is expected to be holding the write lock.
+func (g *Graph) dropIndex(def IndexDef) {
+	if def.Type == RANGE {
+		delete(g.nodeRanges, def)
+		for _, shard := range g.shards {
+			delete(shard.ranges, def)
+		}
+	} else {
+		delete(g.nodeProps, def)
+		for _, shard := range g.shards {
+			shard.store.DropIndex(def)
+		}
+	}
+
+	g.changed = true
+	g.recordSchema(walEntry{Op: walDropIndex, Index: &def}, func() { g.createIndex(def) })
+}
+
+// Indexes returns all the property index definitions sorted by label,
+// property and index type.
+func (g *Graph) Indexes() []IndexDef {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.listIndexes()
+}
+
+// listIndexes returns all the property index definitions.
+// The caller is expected to be holding the read lock.
+func (g *Graph) listIndexes() []IndexDef {
+	defs := make([]IndexDef, 0, len(g.nodeProps)+len(g.nodeRanges))
+	for def := range g.nodeProps {
+		defs = append(defs, def)
+	}
+
+	for def := range g.nodeRanges {
+		defs = append(defs, def)
+	}
+
+	sort.Slice(defs, func(i, j int) bool {
+		if defs[i].Label != defs[j].Label {
+			return defs[i].Label < defs[j].Label
+		}
+		if defs[i].Property != defs[j].Property {
+			return defs[i].Property < defs[j].Property
+		}
+		return defs[i].Type < defs[j].Type
+	})
+
+	return defs
+}
+
+// indexNode adds the stored node to the range, search and unique
+// constraint indexes, the label and property indexes are kept by the
+// storage. The caller is expected to be holding the lock of the node shard
+// and the unique constraint locks of the node labels.
+func (g *Graph) indexNode(node Node) {
+	for def, idx := range g.shard(node.UID).ranges {
+		if !node.HasLabel(def.Label) {
+			continue
+		}
+
+		if value, ok := node.Properties[def.Property]; ok {
+			idx.add(value, node.UID)
+		}
+	}
+
+	for _, idx := range g.searches {
+		if idx.def.Type == NODE {
+			idx.add(node.UID, node.Labels, node.Properties)
+		}
+	}
+
+	g.indexUnique(NODE, node.UID, node.Labels, node.Properties)
+}
+
+// unindexNode removes the node from the range, search and unique
+// constraint indexes. The caller is expected to be holding the lock of the
+// node shard and the unique constraint locks of the node labels.
+func (g *Graph) unindexNode(node Node) {
+	for def, idx := range g.shard(node.UID).ranges {
+		if !node.HasLabel(def.Label) {
+			continue
+		}
+
+		if value, ok := node.Properties[def.Property]; ok {
+			idx.remove(value, node.UID)
+		}
+	}
+
+	for _, idx := range g.searches {
+		if idx.def.Type == NODE {
+			idx.remove(node.UID)
+		}
+	}
+
+	g.unindexUnique(NODE, node.UID, node.Labels)
+}
+
+// indexEdge adds the stored edge to the search and unique constraint
+// indexes. The caller is expected to be holding the lock of the edge shard
+// and the unique constraint locks of the edge label.
+func (g *Graph) indexEdge(edge Edge) {
+	for _, idx := range g.searches {
+		if idx.def.Type == EDGE {
+			idx.add(edge.UID, []string{edge.Label}, edge.Properties)
+		}
+	}
+
+	g.indexUnique(EDGE, edge.UID, []string{edge.Label}, edge.Properties)
+}
+
+// unindexEdge removes the edge from the search and unique constraint
+// indexes. The caller is expected to be holding the lock of the edge shard
+// and the unique constraint locks of the edge label.
+func (g *Graph) unindexEdge(edge Edge) {
+	for _, idx := range g.searches {
+		if idx.def.Type == EDGE {
+			idx.remove(edge.UID)
+		}
+	}
+
+	g.unindexUnique(EDGE, edge.UID, []string{edge.Label})
+}
+
+// RangeQuery is a lookup of nodes with a label ordered by a property value.
+type RangeQuery struct {
+	Label    string
+	Property string
+	// Range restricts the property values, the zero value matches any value.
+	Range Range
+	// Descending orders the nodes from the largest value.
+	Descending bool
+	// Limit is the maximum number of nodes returned, zero is unlimited.
+	Limit int
+	// Filter, if set, is matched against the nodes before the limit.
+	Filter Predicate
+}
+
+// NodesByRange returns the nodes with the label and a property value in
+// the range, ordered by the value and then by UID, from a snapshot of the
+// graph. If there is a range index on the label and property it is walked
+// in order, stopping once the limit is reached, otherwise the nodes with
+// the label are filtered and sorted.
+func (g *Graph) NodesByRange(q RangeQuery) NodeIterator {
+	return g.Snapshot().NodesByRange(q)
+}
@@ -0,0 +1,182 @@
+package graph
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateIndex(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "email", Value: []byte("bar@example.com")})
+	g.AddNode("node-3", []string{"pet"}, KV{Key: "email", Value: []byte("foo@example.com")})
+
+	err := g.CreateIndex("person", "email")
+	assert.Nil(t, err)
+	assert.Equal(t, []IndexDef{{Label: "person", Property: "email"}}, g.Indexes())
+
+	def := IndexDef{Label: "person", Property: "email"}
+	lookup := func(value string) []string {
+		uids := []string{}
+		next, ok := g.Snapshot().propertySource(def, []byte(value), "")
+		assert.True(t, ok)
+		for uid, ok := next(); ok; uid, ok = next() {
+			uids = append(uids, uid)
+		}
+		return uids
+	}
+
+	assert.Equal(t, []string{"node-1"}, lookup("foo@example.com"))
+	assert.Equal(t, []string{"node-2"}, lookup("bar@example.com"))
+}
+
+func TestCreateIndex_duplicate(t *testing.T) {
+	g := New()
+	assert.Nil(t, g.CreateIndex("person", "email"))
+	assert.NotNil(t, g.CreateIndex("person", "email"))
+}
+
+func TestDropIndex(t *testing.T) {
+	g := New()
+	g.CreateIndex("person", "email")
+
+	assert.Nil(t, g.DropIndex("person", "email"))
+	assert.Equal(t, []IndexDef{}, g.Indexes())
+	assert.NotNil(t, g.DropIndex("person", "email"))
+}
+
+func TestNodesBy__prop_index(t *testing.T) {
+	g := New()
+	g.CreateIndex("person", "email")
+
+	n1, _ := g.AddNode("node-1", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	n2, _ := g.AddNode("node-2", []string{"person", "employee"}, KV{Key: "email", Value: []byte("bar@example.com")})
+	g.AddNode("node-3", []string{"pet"}, KV{Key: "email", Value: []byte("foo@example.com")})
+
+	iter := g.NodesBy([]string{"person"}, ALL, map[string][]byte{"email": []byte("foo@example.com")})
+	assert.Equal(t, 1, iter.Size())
+	assert.Equal(t, n1, iter.Value().(Node))
+
+	iter = g.NodesBy([]string{"person", "employee"}, ALL, map[string][]byte{"email": []byte("foo@example.com")})
+	assert.Equal(t, 0, iter.Size())
+
+	n2.Properties = map[string][]byte{"email": []byte("baz@example.com")}
+	n2, _ = g.UpdateNode(n2)
+
+	iter = g.NodesBy([]string{"person"}, ALL, map[string][]byte{"email": []byte("bar@example.com")})
+	assert.Equal(t, 0, iter.Size())
+
+	iter = g.NodesBy([]string{"person"}, ALL, map[string][]byte{"email": []byte("baz@example.com")})
+	assert.Equal(t, 1, iter.Size())
+	assert.Equal(t, n2, iter.Value().(Node))
+
+	g.RemoveNode(n1.UID)
+	iter = g.NodesBy([]string{"person"}, ALL, map[string][]byte{"email": []byte("foo@example.com")})
+	assert.Equal(t, 0, iter.Size())
+}
+
+func TestNodesBy__prop_index_any_labels(t *testing.T) {
+	g := New()
+	g.CreateIndex("person", "email")
+
+	n1, _ := g.AddNode("node-1", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	n2, _ := g.AddNode("node-2", []string{"pet"}, KV{Key: "email", Value: []byte("foo@example.com")})
+
+	// pet has no index so all the labeled nodes are filtered instead.
+	iter := g.NodesBy([]string{"person", "pet"}, ANY, map[string][]byte{"email": []byte("foo@example.com")})
+
+	actual := []Node{}
+	for iter.Next() {
+		actual = append(actual, iter.Value().(Node))
+	}
+
+	assert.ElementsMatch(t, []Node{n1, n2}, actual)
+}
+
+func TestQuery_create_and_drop_index(t *testing.T) {
+	g := New()
+
+	_, err := g.Query(`CREATE INDEX ON :person(email)`)
+	assert.Nil(t, err)
+	assert.Equal(t, []IndexDef{{Label: "person", Property: "email"}}, g.Indexes())
+
+	_, err = g.Query(`CREATE INDEX ON :person(email)`)
+	assert.NotNil(t, err)
+
+	_, err = g.Query(`DROP INDEX ON :person(email)`)
+	assert.Nil(t, err)
+	assert.Equal(t, []IndexDef{}, g.Indexes())
+}
+
+func TestMarshalJSON_indexes(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	g.CreateIndex("person", "email")
+	g.CreateRangeIndex("person", "email")
+
+	dump, err := json.Marshal(g)
+	assert.Nil(t, err)
+
+	actual := New()
+	assert.Nil(t, json.Unmarshal(dump, actual))
+	assert.Equal(t, g.Indexes(), actual.Indexes())
+	assert.Equal(t, g.nodeProps, actual.nodeProps)
+	assert.Equal(t, 1, actual.shard("node-1").ranges[IndexDef{Label: "person", Property: "email", Type: RANGE}].tree.Len())
+}
+
+func TestCreateRangeIndex(t *testing.T) {
+	g := New()
+	g.CreateIndex("person", "email")
+
+	assert.Nil(t, g.CreateRangeIndex("person", "age"))
+	assert.NotNil(t, g.CreateRangeIndex("person", "age"))
+	assert.Equal(
+		t,
+		[]IndexDef{
+			{Label: "person", Property: "age", Type: RANGE},
+			{Label: "person", Property: "email"},
+		},
+		g.Indexes(),
+	)
+
+	assert.Nil(t, g.DropRangeIndex("person", "age"))
+	assert.NotNil(t, g.DropRangeIndex("person", "age"))
+	assert.Equal(t, []IndexDef{{Label: "person", Property: "email"}}, g.Indexes())
+}
+
+func TestNodesByRange(t *testing.T) {
+	for _, indexed := range []bool{true, false} {
+		g := New()
+		if indexed {
+			g.CreateRangeIndex("person", "age")
+		}
+
+		g.AddNode("node-1", []string{"person"}, KV{Key: "age", Value: []byte("30")})
+		g.AddNode("node-2", []string{"person"}, KV{Key: "age", Value: []byte("9")})
+		n3, _ := g.AddNode("node-3", []string{"person"}, KV{Key: "age", Value: []byte("100")})
+		g.AddNode("node-4", []string{"person"})
+		g.AddNode("node-5", []string{"pet"}, KV{Key: "age", Value: []byte("5")})
+
+		uids := func(q RangeQuery) []string {
+			found := []string{}
+			iter := g.NodesByRange(q)
+			for iter.Next() {
+				found = append(found, iter.Value().(Node).UID)
+			}
+			return found
+		}
+
+		assert.Equal(t, []string{"node-2", "node-1", "node-3"}, uids(RangeQuery{Label: "person", Property: "age"}), "indexed: %t", indexed)
+		assert.Equal(t, []string{"node-3", "node-1"}, uids(RangeQuery{Label: "person", Property: "age", Descending: true, Limit: 2}), "indexed: %t", indexed)
+		assert.Equal(t, []string{"node-1", "node-3"}, uids(RangeQuery{Label: "person", Property: "age", Range: Range{Min: []byte("10")}}), "indexed: %t", indexed)
+
+		n3.Properties = map[string][]byte{"age": []byte("1")}
+		g.UpdateNode(n3)
+		assert.Equal(t, []string{"node-3", "node-2"}, uids(RangeQuery{Label: "person", Property: "age", Range: Range{Max: []byte("10")}}), "indexed: %t", indexed)
+
+		filter := Not(PropEquals("age", []byte("1")))
+		assert.Equal(t, []string{"node-2"}, uids(RangeQuery{Label: "person", Property: "age", Limit: 1, Filter: filter}), "indexed: %t", indexed)
+	}
+}
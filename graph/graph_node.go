@@ -3,178 +3,355 @@ package graph
 import (
 	"bytes"
 	"fmt"
-
-	"github.com/jenmud/draft/graph/iterator"
+	"sort"
 )
 
 // HasNode returns true if the graph has a node with the provided uid.
 func (g *Graph) HasNode(uid string) bool {
-	g.lock.RLock()
-	defer g.lock.RUnlock()
+	return g.Snapshot().HasNode(uid)
+}
+
+// AddNode adds a new node with zero or more labels to the graph.
+// If uid is empty, a new UID is generated for the node. The returned node
+// is a copy, it does not change as edges are added or removed, use Node
+// to read the node again.
+func (g *Graph) AddNode(uid string, labels []string, kv ...KV) (node Node, err error) {
+	uid = g.ensureUID(uid)
+	w := g.write(uid)
+	defer w.release(&err)
 
-	_, ok := g.nodes[uid]
-	return ok
+	return g.addNode(uid, labels, kv...)
 }
 
-// AddNode adds a new node to the graph.
-func (g *Graph) AddNode(uid, label string, kv ...KV) (Node, error) {
-	g.lock.Lock()
-	defer g.lock.Unlock()
+// addNode adds a new node to the graph. The uid is expected to be set
+// and the caller is expected to be holding the lock of the node shard.
+func (g *Graph) addNode(uid string, labels []string, kv ...KV) (Node, error) {
+	if uid == "" {
+		return Node{}, fmt.Errorf("[AddNode] Node UID is empty")
+	}
 
-	if _, ok := g.nodes[uid]; ok {
+	if g.hasNode(uid) {
 		return Node{}, fmt.Errorf("[AddNode] Node UID %s already exists", uid)
 	}
 
-	node := NewNode(uid, label, kv...)
-	g.nodes[node.UID] = node
+	node := NewNode(uid, labels, kv...)
+	defer g.lockUnique(NODE, node.Labels)()
+
+	if err := g.checkConstraints(NODE, node.UID, node.Labels, node.Properties); err != nil {
+		return Node{}, fmt.Errorf("[AddNode] %w", err)
+	}
+
+	node.Version = 1
+	if err := g.shard(node.UID).store.PutNode(node); err != nil {
+		return Node{}, fmt.Errorf("[AddNode] %w", err)
+	}
+
+	g.indexNode(node)
+	g.record(node.UID, walEntry{Op: walPutNode, Node: &node}, func() { g.removeNode(node.UID) })
+
 	return node, nil
 }
 
-// UpdateNode updates the graph node with the new node.
-func (g *Graph) UpdateNode(node Node) (Node, error) {
-	if !g.HasNode(node.UID) {
-		return node, fmt.Errorf("[UpdateNode] Node does not exists, can not update node %s", node)
+// UpdateNode updates the graph node with the new node, keeping the edges
+// attached to the graph node. If the node version is set, the update fails
+// with a VersionError unless it is the current version of the graph node.
+func (g *Graph) UpdateNode(node Node) (updated Node, err error) {
+	w := g.write(node.UID)
+	defer w.release(&err)
+
+	updated, err = g.updateNode(node)
+	if err != nil {
+		return updated, err
+	}
+	return g.attach(updated), nil
+}
+
+// updateNode updates the graph node with the new node, the edges of the
+// node are left as is. The caller is expected to be holding the lock of
+// the node shard.
+func (g *Graph) updateNode(node Node) (Node, error) {
+	current, ok := g.node(node.UID)
+	if !ok {
+		return node, fmt.Errorf("[UpdateNode] Node does not exists, can not update node %s", node.UID)
 	}
 
-	g.lock.Lock()
-	defer g.lock.Unlock()
+	if err := checkVersion(node.UID, node.Version, current.Version); err != nil {
+		return node, fmt.Errorf("[UpdateNode] %w", err)
+	}
+
+	defer g.lockUnique(NODE, current.Labels, node.Labels)()
+
+	if err := g.checkConstraints(NODE, node.UID, node.Labels, node.Properties); err != nil {
+		return node, fmt.Errorf("[UpdateNode] %w", err)
+	}
+
+	node.Version = current.Version + 1
+	if err := g.putNode(node); err != nil {
+		return node, fmt.Errorf("[UpdateNode] %w", err)
+	}
 
-	g.nodes[node.UID] = node
 	return node, nil
 }
 
-// RemoveNode removes the node from the graph.
-func (g *Graph) RemoveNode(uid string) error {
-	node, err := g.Node(uid)
+// PatchNode applies the patch to the node with the uid, keeping the edges
+// attached to the node.
+func (g *Graph) PatchNode(uid string, patch NodePatch) (node Node, err error) {
+	w := g.write(uid)
+	defer w.release(&err)
+
+	current, ok := g.node(uid)
+	if !ok {
+		return Node{}, fmt.Errorf("[PatchNode] [GetNode] No such node with UID %s found", uid)
+	}
+
+	node, err = g.updateNode(patch.apply(current))
 	if err != nil {
-		return fmt.Errorf("[RemoveNode] %s", err)
+		return node, fmt.Errorf("[PatchNode] %w", err)
 	}
 
-	edgeCount := len(node.inEdges) + len(node.outEdges)
-	if edgeCount > 0 {
-		return fmt.Errorf("[RemoveNode] Can not remove node with edges attached (edge count: %d)", edgeCount)
+	return g.attach(node), nil
+}
+
+// restoreNode replaces or adds the node as is, without any checks, keeping
+// the edges of a replaced node. It is used for undoing changes. The caller
+// is expected to be holding the lock of the node shard.
+func (g *Graph) restoreNode(node Node) error {
+	current, _ := g.node(node.UID)
+	defer g.lockUnique(NODE, current.Labels, node.Labels)()
+	return g.putNode(node)
+}
+
+// putNode replaces or adds the node, keeping the edges of a replaced node.
+// The caller is expected to be holding the lock of the node shard and the
+// unique constraint locks of the labels of both nodes.
+func (g *Graph) putNode(node Node) error {
+	current, ok := g.node(node.UID)
+	if err := g.shard(node.UID).store.PutNode(node); err != nil {
+		return err
 	}
 
-	g.lock.Lock()
-	defer g.lock.Unlock()
+	if ok {
+		g.unindexNode(current)
+	}
 
-	delete(g.nodes, uid)
+	g.indexNode(node)
+	g.record(node.UID, walEntry{Op: walPutNode, Node: &node}, func() {
+		if ok {
+			g.restoreNode(current)
+		} else {
+			g.removeNode(node.UID)
+		}
+	})
 	return nil
 }
 
-// Node returns the node with the provided uid.
-func (g *Graph) Node(uid string) (Node, error) {
-	g.lock.RLock()
-	defer g.lock.RUnlock()
+// attach returns the node with its edges attached. The edges of a node
+// only change while holding the lock of its shard and are committed before
+// the lock is released, so the edges of the current snapshot are current.
+// The caller is expected to be holding the lock of the node shard.
+func (g *Graph) attach(node Node) Node {
+	return g.Snapshot().attach(node)
+}
 
-	node, ok := g.nodes[uid]
-	if !ok {
-		return Node{}, fmt.Errorf("[GetNode] No such node with UID %s found", uid)
+// RemoveOption configures how nodes are removed.
+type RemoveOption func(*removeOptions)
+
+// removeOptions are the options used when removing nodes.
+type removeOptions struct {
+	detach bool
+}
+
+// Detach removes the edges attached to a node along with the node,
+// instead of refusing to remove a node with edges.
+func Detach() RemoveOption {
+	return func(o *removeOptions) {
+		o.detach = true
 	}
+}
 
-	return node, nil
+// newRemoveOptions returns the remove options configured by opts.
+func newRemoveOptions(opts []RemoveOption) removeOptions {
+	options := removeOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
 }
 
-// Nodes returns a node iterator.
-func (g *Graph) Nodes() Iterator {
-	g.lock.RLock()
-	defer g.lock.RUnlock()
+// RemoveNode removes the node from the graph. Removing a node with edges
+// attached fails, unless the Detach option is used.
+func (g *Graph) RemoveNode(uid string, opts ...RemoveOption) (err error) {
+	if !newRemoveOptions(opts).detach {
+		w := g.write(uid)
+		defer w.release(&err)
+		return g.removeNode(uid)
+	}
+
+	// The edges of the node can be in any shard.
+	w := g.exclusive()
+	defer w.release(&err)
 
-	nodes := make([]interface{}, len(g.nodes))
-	count := 0
-	for _, node := range g.nodes {
-		nodes[count] = node
-		count++
+	if _, err := g.detachNode(uid); err != nil {
+		return err
 	}
 
-	return iterator.New(nodes)
+	return g.removeNode(uid)
 }
 
-// nodeMapper converts interfaces into Nodes
-func nodeMapper(in <-chan interface{}, out chan<- Node) {
-	for item := range in {
-		out <- item.(Node)
-	}
-	close(out)
+// RemoveNodes removes all the nodes matching the labels and properties as
+// filtered by NodesBy, and returns the uids of the removed nodes and edges.
+// Either all the nodes are removed or none are. If any of the nodes has
+// edges attached, no nodes are removed, unless the Detach option is used.
+func (g *Graph) RemoveNodes(labels []string, match LabelMatch, props map[string][]byte, opts ...RemoveOption) (nodes []string, edges []string, err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+	return g.removeNodes(NodesByPredicate(labels, match, props), opts)
 }
 
-// labelReducer filters for nodes that have the given labels.
-func labelReducer(labels []string, in <-chan Node, out chan<- Node) {
-	for node := range in {
-		if len(labels) == 0 {
-			out <- node
-			continue
+// RemoveNodesWhere removes all the nodes matching the predicate the same
+// way as RemoveNodes, and returns the uids of the removed nodes and edges.
+func (g *Graph) RemoveNodesWhere(p Predicate, opts ...RemoveOption) (nodes []string, edges []string, err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+	return g.removeNodes(p, opts)
+}
+
+// removeNodes removes all the nodes matching the predicate.
+// The caller is expected to be holding the write lock.
+func (g *Graph) removeNodes(p Predicate, opts []RemoveOption) ([]string, []string, error) {
+	detach := newRemoveOptions(opts).detach
+	nodes := []string{}
+
+	// The matching nodes are all found before changing the graph.
+	iter := g.view().NodesWhere(p)
+	for iter.Next() {
+		node := iter.Node()
+
+		edgeCount := len(node.inEdges) + len(node.outEdges)
+		if edgeCount > 0 && !detach {
+			return nil, nil, fmt.Errorf("[RemoveNodes] Can not remove node %s with edges attached (edge count: %d)", node.UID, edgeCount)
 		}
 
-		for _, label := range labels {
-			if node.Label == label {
-				out <- node
-				continue
-			}
+		nodes = append(nodes, node.UID)
+	}
+
+	edges := []string{}
+	for _, uid := range nodes {
+		detached, err := g.detachNode(uid)
+		if err != nil {
+			return nil, nil, fmt.Errorf("[RemoveNodes] %w", err)
+		}
+		edges = append(edges, detached...)
+
+		if err := g.removeNode(uid); err != nil {
+			return nil, nil, fmt.Errorf("[RemoveNodes] %w", err)
 		}
 	}
 
-	close(out)
+	sort.Strings(edges)
+
+	return nodes, edges, nil
 }
 
-// propReducer filters for nodes that have the given properties.
-func propReducer(props map[string][]byte, in <-chan Node, out chan<- Node) {
-	for node := range in {
-		if len(props) == 0 {
-			out <- node
-			continue
+// hasProperties returns true if the node has all the properties.
+func hasProperties(node Node, props map[string][]byte) bool {
+	return matchProperties(node.Properties, props)
+}
+
+// matchProperties returns true if have contains all the properties.
+func matchProperties(have, props map[string][]byte) bool {
+	for key, value := range props {
+		hvalue, ok := have[key]
+		if !ok || !bytes.Equal(value, hvalue) {
+			return false
 		}
+	}
+	return true
+}
 
-		var allMatched = false
-		for key, value := range props {
-			nvalue, ok := node.Properties[key]
-			if !ok {
-				allMatched = false
-				break
-			}
+// detachNode removes all the edges attached to the node and returns the
+// uids of the removed edges. The caller is expected to be holding the write lock.
+func (g *Graph) detachNode(uid string) ([]string, error) {
+	store := g.shard(uid).store
+	if !store.HasNode(uid) {
+		return nil, fmt.Errorf("[RemoveNode] [GetNode] No such node with UID %s found", uid)
+	}
 
-			if !bytes.Equal(value, nvalue) {
-				allMatched = false
-				break
-			}
+	// The edges are all found before removing any of them.
+	edges := append(readAll(store.Adjacency(uid, IN, "")), readAll(store.Adjacency(uid, OUT, ""))...)
 
-			allMatched = true
+	removed := []string{}
+	for _, edgeUID := range edges {
+		// A edge from the node to itself is both in and outbound.
+		if !g.hasEdge(edgeUID) {
+			continue
 		}
 
-		if allMatched {
-			out <- node
+		if err := g.removeEdge(edgeUID); err != nil {
+			return removed, err
 		}
+		removed = append(removed, edgeUID)
 	}
 
-	close(out)
+	return removed, nil
 }
 
-// NodesBy returns a node iterator with filtered nodes.
-// If labels is an empty list, then any label will be used.
-// If props is an empty map, no properties will be used for filtering.
-func (g *Graph) NodesBy(labels []string, props map[string][]byte) Iterator {
-	g.lock.RLock()
-	defer g.lock.RUnlock()
+// removeNode removes the node from the graph.
+// The caller is expected to be holding the lock of the node shard.
+func (g *Graph) removeNode(uid string) error {
+	store := g.shard(uid).store
+	node, ok := store.Node(uid)
+	if !ok {
+		return fmt.Errorf("[RemoveNode] [GetNode] No such node with UID %s found", uid)
+	}
+
+	if edgeCount := store.Degree(uid); edgeCount > 0 {
+		return fmt.Errorf("[RemoveNode] Can not remove node with edges attached (edge count: %d)", edgeCount)
+	}
 
-	in := make(chan Node, len(g.nodes))
-	labelFiltered := make(chan Node, len(g.nodes))
-	final := make(chan Node)
+	unlock := g.lockUnique(NODE, node.Labels)
+	g.unindexNode(node)
+	unlock()
 
-	go nodeMapper(g.Nodes().Channel(), in)
-	go labelReducer(labels, in, labelFiltered)
-	go propReducer(props, labelFiltered, final)
+	store.DeleteNode(uid)
+	g.record(uid, walEntry{Op: walRemoveNode, UID: uid}, func() { g.restoreNode(node) })
+	return nil
+}
 
-	nodes := []interface{}{}
-	for node := range final {
-		nodes = append(nodes, node)
-	}
+// Node returns the node with the provided uid, read from a snapshot of
+// the graph.
+func (g *Graph) Node(uid string) (Node, error) {
+	return g.Snapshot().Node(uid)
+}
+
+// Nodes returns a lazy node iterator over a snapshot of the graph, with
+// the nodes ordered by UID.
+func (g *Graph) Nodes() NodeIterator {
+	return g.Snapshot().Nodes()
+}
+
+// NodesWhere returns a lazy node iterator over a snapshot of the graph
+// with the nodes matching the predicate ordered by UID.
+func (g *Graph) NodesWhere(p Predicate) NodeIterator {
+	return g.Snapshot().NodesWhere(p)
+}
+
+// NodesWhereAfter returns a lazy node iterator over a snapshot of the
+// graph the same as NodesWhere, but starting after the node with the uid.
+func (g *Graph) NodesWhereAfter(p Predicate, after string) NodeIterator {
+	return g.Snapshot().NodesWhereAfter(p, after)
+}
 
-	return iterator.New(nodes)
+// NodesBy returns a lazy node iterator over a snapshot of the graph with
+// filtered nodes ordered by UID.
+// If labels is an empty list, then any label will be used.
+// Match decides if a node needs any or all of the labels.
+// If props is an empty map, no properties will be used for filtering.
+func (g *Graph) NodesBy(labels []string, match LabelMatch, props map[string][]byte) NodeIterator {
+	return g.Snapshot().NodesBy(labels, match, props)
 }
 
 // NodeCount returns the total number of nodes in the graph.
 func (g *Graph) NodeCount() int {
-	g.lock.RLock()
-	defer g.lock.RUnlock()
-	return len(g.nodes)
+	return g.Snapshot().NodeCount()
 }
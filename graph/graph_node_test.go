@@ -1,6 +1,7 @@
 package graph
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,23 +9,41 @@ import (
 
 func TestAddNode(t *testing.T) {
 	g := New()
-	expected := NewNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
-	actual, err := g.AddNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
+	expected := NewNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	expected.Version = 1
+	actual, err := g.AddNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
 	assert.Nil(t, err)
 	assert.Equal(t, expected, actual)
 }
 
+func TestAddNode_multiple_labels(t *testing.T) {
+	g := New()
+	actual, err := g.AddNode("abcd-1234", []string{"person", "employee", "person"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"person", "employee"}, actual.Labels)
+}
+
+func TestNode_HasLabels(t *testing.T) {
+	n := NewNode("node-1", []string{"person", "employee"})
+	assert.Equal(t, true, n.HasLabel("person"))
+	assert.Equal(t, false, n.HasLabel("manager"))
+	assert.Equal(t, true, n.HasLabels([]string{}, ALL))
+	assert.Equal(t, true, n.HasLabels([]string{"manager", "employee"}, ANY))
+	assert.Equal(t, false, n.HasLabels([]string{"manager", "employee"}, ALL))
+	assert.Equal(t, true, n.HasLabels([]string{"person", "employee"}, ALL))
+}
+
 func TestAddNode_Duplicate(t *testing.T) {
 	g := New()
-	g.AddNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
-	actual, err := g.AddNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	actual, err := g.AddNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
 	assert.NotNil(t, err)
 	assert.Equal(t, Node{}, actual)
 }
 
 func TestRemoveNode(t *testing.T) {
 	g := New()
-	g.AddNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
 	err := g.RemoveNode("abcd-1234")
 	assert.Nil(t, err)
 	assert.Equal(t, false, g.HasNode("abcd-1234"))
@@ -39,8 +58,8 @@ func TestRemoveNode_does_not_exist(t *testing.T) {
 func TestRemoveNode_with_edges(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 	g.AddEdge("edge-1", n1.UID, "knows", n2.UID)
 
 	err := g.RemoveNode("node-1")
@@ -50,11 +69,70 @@ func TestRemoveNode_with_edges(t *testing.T) {
 	assert.Equal(t, true, g.HasEdge("edge-1"))
 }
 
+func TestRemoveNode_detach(t *testing.T) {
+	g := New()
+
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
+	g.AddEdge("edge-1", n1.UID, "knows", n2.UID)
+	g.AddEdge("edge-2", n2.UID, "knows", n1.UID)
+	g.AddEdge("edge-3", n1.UID, "likes", n1.UID)
+
+	err := g.RemoveNode("node-1", Detach())
+	assert.Nil(t, err)
+
+	assert.Equal(t, false, g.HasNode("node-1"))
+	assert.Equal(t, 0, g.EdgeCount())
+
+	node, _ := g.Node("node-2")
+	assert.Equal(t, []string{}, node.Edges())
+
+	assert.NotNil(t, g.RemoveNode("node-1", Detach()))
+}
+
+func TestRemoveNodes(t *testing.T) {
+	g := New()
+
+	g.AddNode("node-1", []string{"person"}, KV{Key: "team", Value: []byte("red")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "team", Value: []byte("red")})
+	g.AddNode("node-3", []string{"person"}, KV{Key: "team", Value: []byte("blue")})
+	g.AddEdge("edge-1", "node-1", "knows", "node-3")
+
+	// Nothing is removed if any of the nodes has edges.
+	_, _, err := g.RemoveNodes([]string{"person"}, ANY, map[string][]byte{"team": []byte("red")})
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, g.NodeCount())
+
+	nodes, edges, err := g.RemoveNodes([]string{"person"}, ANY, map[string][]byte{"team": []byte("red")}, Detach())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"node-1", "node-2"}, nodes)
+	assert.Equal(t, []string{"edge-1"}, edges)
+
+	assert.Equal(t, 1, g.NodeCount())
+	assert.Equal(t, 0, g.EdgeCount())
+	assert.Equal(t, true, g.HasNode("node-3"))
+}
+
+func TestRemoveNodesWhere(t *testing.T) {
+	g := New()
+
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	g.AddNode("node-3", []string{"person"})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+
+	nodes, edges, err := g.RemoveNodesWhere(And(HasLabel("person"), Degree(BOTH, 0, 0)))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"node-3"}, nodes)
+	assert.Equal(t, []string{}, edges)
+	assert.Equal(t, 2, g.NodeCount())
+}
+
 func TestRemoveNode_after_edge_removal(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
 	g.AddEdge("edge-1", n1.UID, "knows", n2.UID)
 
 	err := g.RemoveNode("node-1")
@@ -71,19 +149,19 @@ func TestRemoveNode_after_edge_removal(t *testing.T) {
 
 func TestHasNode(t *testing.T) {
 	g := New()
-	g.AddNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
 	assert.Equal(t, true, g.HasNode("abcd-1234"))
 }
 
 func TestHasNode_not_found(t *testing.T) {
 	g := New()
-	g.AddNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
 	assert.Equal(t, false, g.HasNode("missing"))
 }
 
 func TestNode(t *testing.T) {
 	g := New()
-	expected, _ := g.AddNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
+	expected, _ := g.AddNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
 	actual, err := g.Node("abcd-1234")
 	assert.Nil(t, err)
 	assert.Equal(t, expected, actual)
@@ -91,179 +169,58 @@ func TestNode(t *testing.T) {
 
 func TestNode_not_found(t *testing.T) {
 	g := New()
-	g.AddNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
 	actual, err := g.Node("abcd-1234-missing")
 	assert.NotNil(t, err)
 	assert.Equal(t, Node{}, actual)
 }
 
-func TestlabelReducer(t *testing.T) {
-	nodes := make(chan Node, 3)
-
-	n1 := NewNode("node-1", "person")
-	n2 := NewNode("node-2", "person")
-	n3 := NewNode("node-3", "animal")
-
-	nodes <- n1
-	nodes <- n2
-	nodes <- n3
-	close(nodes)
-
-	out := make(chan Node, 3)
-
-	expected := []Node{n1, n2}
-	actual := []Node{}
-
-	labelReducer([]string{"person"}, nodes, out)
-
-	for node := range out {
-		actual = append(actual, node)
-	}
-
-	assert.ElementsMatch(t, expected, actual)
-}
-
-func TestlabelReducer__no_labels(t *testing.T) {
-	nodes := make(chan Node, 3)
-
-	n1 := NewNode("node-1", "person")
-	n2 := NewNode("node-2", "person")
-	n3 := NewNode("node-3", "animal")
-
-	nodes <- n1
-	nodes <- n2
-	nodes <- n3
-	close(nodes)
-
-	out := make(chan Node, 3)
-
-	expected := []Node{n1, n2, n3}
-	actual := []Node{}
-
-	labelReducer([]string{}, nodes, out)
-
-	for node := range out {
-		actual = append(actual, node)
-	}
-
-	assert.ElementsMatch(t, expected, actual)
-}
-
-func TestNodeMapper(t *testing.T) {
-	nodes := make(chan interface{}, 3)
-
-	n1 := NewNode("node-1", "person")
-	n2 := NewNode("node-2", "person")
-	n3 := NewNode("node-3", "animal")
-
-	nodes <- n1
-	nodes <- n2
-	nodes <- n3
-	close(nodes)
-
-	out := make(chan Node, 3)
-
-	expected := []Node{n1, n2, n3}
-	actual := []Node{}
-
-	nodeMapper(nodes, out)
-
-	for node := range out {
-		actual = append(actual, node)
-	}
-
-	assert.ElementsMatch(t, expected, actual)
-}
-
-func TestPropReducer(t *testing.T) {
-	nodes := make(chan Node, 3)
-
-	n1 := NewNode("node-1", "person", KV{Key: "name", Value: []byte("Foo")})
-	n2 := NewNode("node-1", "person", KV{Key: "name", Value: []byte("Bar")})
-	n3 := NewNode("node-1", "person", KV{Key: "age", Value: []byte("21")}, KV{Key: "name", Value: []byte("Foo")})
-
-	nodes <- n1
-	nodes <- n2
-	nodes <- n3
-	close(nodes)
-
-	out := make(chan Node, 3)
-
-	expected := []Node{n1, n3}
-	actual := []Node{}
-
-	propReducer(map[string][]byte{"name": []byte("Foo")}, nodes, out)
-
-	for node := range out {
-		actual = append(actual, node)
-	}
-
-	assert.ElementsMatch(t, expected, actual)
-}
-
-func TestPropReducer__multiple_props(t *testing.T) {
-	nodes := make(chan Node, 3)
-
-	n1 := NewNode("node-1", "person", KV{Key: "name", Value: []byte("Foo")})
-	n2 := NewNode("node-1", "person", KV{Key: "name", Value: []byte("Bar")})
-	n3 := NewNode("node-1", "person", KV{Key: "age", Value: []byte("21")}, KV{Key: "name", Value: []byte("Foo")})
-
-	nodes <- n1
-	nodes <- n2
-	nodes <- n3
-	close(nodes)
-
-	out := make(chan Node, 3)
+func TestNodesBy__label_filtered(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"pet"})
+	n3, _ := g.AddNode("node-3", []string{"bike"})
+	g.AddNode("node-4", []string{"person"})
 
-	expected := []Node{n3}
+	expected := []Node{n2, n3}
 	actual := []Node{}
 
-	propReducer(map[string][]byte{"name": []byte("Foo"), "age": []byte("21")}, nodes, out)
-
-	for node := range out {
-		actual = append(actual, node)
+	iter := g.NodesBy([]string{"pet", "bike"}, ANY, map[string][]byte{})
+	for iter.Next() {
+		actual = append(actual, iter.Value().(Node))
 	}
 
 	assert.ElementsMatch(t, expected, actual)
 }
 
-func TestPropReducer__empty_props(t *testing.T) {
-	nodes := make(chan Node, 3)
-
-	n1 := NewNode("node-1", "person", KV{Key: "name", Value: []byte("Foo")})
-	n2 := NewNode("node-1", "person", KV{Key: "name", Value: []byte("Bar")})
-	n3 := NewNode("node-1", "person", KV{Key: "age", Value: []byte("21")}, KV{Key: "name", Value: []byte("Foo")})
-
-	nodes <- n1
-	nodes <- n2
-	nodes <- n3
-	close(nodes)
-
-	out := make(chan Node, 3)
+func TestNodesBy__all_labels_filtered(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person", "employee"})
+	g.AddNode("node-3", []string{"employee"})
+	n4, _ := g.AddNode("node-4", []string{"employee", "manager", "person"})
 
-	expected := []Node{n1, n2, n3}
+	expected := []Node{n2, n4}
 	actual := []Node{}
 
-	propReducer(map[string][]byte{}, nodes, out)
-
-	for node := range out {
-		actual = append(actual, node)
+	iter := g.NodesBy([]string{"person", "employee"}, ALL, map[string][]byte{})
+	for iter.Next() {
+		actual = append(actual, iter.Value().(Node))
 	}
 
 	assert.ElementsMatch(t, expected, actual)
 }
 
-func TestNodesBy__label_filtered(t *testing.T) {
+func TestNodesBy__any_labels_filtered(t *testing.T) {
 	g := New()
-	g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "pet")
-	n3, _ := g.AddNode("node-3", "bike")
-	g.AddNode("node-4", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person", "employee"})
+	g.AddNode("node-3", []string{"pet"})
 
-	expected := []Node{n2, n3}
+	expected := []Node{n1, n2}
 	actual := []Node{}
 
-	iter := g.NodesBy([]string{"pet", "bike"}, map[string][]byte{})
+	iter := g.NodesBy([]string{"person", "employee"}, ANY, map[string][]byte{})
 	for iter.Next() {
 		actual = append(actual, iter.Value().(Node))
 	}
@@ -273,15 +230,15 @@ func TestNodesBy__label_filtered(t *testing.T) {
 
 func TestNodesBy__prop_filtered(t *testing.T) {
 	g := New()
-	g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "pet", KV{Key: "name", Value: []byte("socks")})
-	g.AddNode("node-3", "bike")
-	g.AddNode("node-4", "person")
+	g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"pet"}, KV{Key: "name", Value: []byte("socks")})
+	g.AddNode("node-3", []string{"bike"})
+	g.AddNode("node-4", []string{"person"})
 
 	expected := []Node{n2}
 	actual := []Node{}
 
-	iter := g.NodesBy([]string{"pet", "bike"}, map[string][]byte{"name": []byte("socks")})
+	iter := g.NodesBy([]string{"pet", "bike"}, ANY, map[string][]byte{"name": []byte("socks")})
 	for iter.Next() {
 		actual = append(actual, iter.Value().(Node))
 	}
@@ -291,15 +248,15 @@ func TestNodesBy__prop_filtered(t *testing.T) {
 
 func TestNodesBy__empty_labels_prop_filtered(t *testing.T) {
 	g := New()
-	g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "pet", KV{Key: "name", Value: []byte("socks")}, KV{Key: "enabled", Value: []byte("true")})
-	n3, _ := g.AddNode("node-3", "bike", KV{Key: "enabled", Value: []byte("true")})
-	g.AddNode("node-4", "person")
+	g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"pet"}, KV{Key: "name", Value: []byte("socks")}, KV{Key: "enabled", Value: []byte("true")})
+	n3, _ := g.AddNode("node-3", []string{"bike"}, KV{Key: "enabled", Value: []byte("true")})
+	g.AddNode("node-4", []string{"person"})
 
 	expected := []Node{n2, n3}
 	actual := []Node{}
 
-	iter := g.NodesBy([]string{}, map[string][]byte{"enabled": []byte("true")})
+	iter := g.NodesBy([]string{}, ANY, map[string][]byte{"enabled": []byte("true")})
 	for iter.Next() {
 		actual = append(actual, iter.Value().(Node))
 	}
@@ -309,15 +266,15 @@ func TestNodesBy__empty_labels_prop_filtered(t *testing.T) {
 
 func TestNodesBy__emtpy_lables_empty_props(t *testing.T) {
 	g := New()
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "pet", KV{Key: "name", Value: []byte("socks")})
-	n3, _ := g.AddNode("node-3", "bike")
-	n4, _ := g.AddNode("node-4", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"pet"}, KV{Key: "name", Value: []byte("socks")})
+	n3, _ := g.AddNode("node-3", []string{"bike"})
+	n4, _ := g.AddNode("node-4", []string{"person"})
 
 	expected := []Node{n1, n2, n3, n4}
 	actual := []Node{}
 
-	iter := g.NodesBy([]string{}, map[string][]byte{})
+	iter := g.NodesBy([]string{}, ANY, map[string][]byte{})
 	for iter.Next() {
 		actual = append(actual, iter.Value().(Node))
 	}
@@ -327,8 +284,8 @@ func TestNodesBy__emtpy_lables_empty_props(t *testing.T) {
 
 func TestNodes(t *testing.T) {
 	g := New()
-	expected1, _ := g.AddNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
-	expected2, _ := g.AddNode("abcd-4321", "person", KV{Key: "name", Value: []byte("bar")})
+	expected1, _ := g.AddNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	expected2, _ := g.AddNode("abcd-4321", []string{"person"}, KV{Key: "name", Value: []byte("bar")})
 
 	expected := []Node{expected1, expected2}
 	actual := []Node{}
@@ -344,7 +301,7 @@ func TestNodes(t *testing.T) {
 func TestUpdateNode(t *testing.T) {
 	g := New()
 
-	old, err := g.AddNode("abcd-1234", "person", KV{Key: "name", Value: []byte("foo")})
+	old, err := g.AddNode("abcd-1234", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
 	old.Properties["name"] = []byte("bar")
 
 	updated, err := g.UpdateNode(old)
@@ -354,10 +311,85 @@ func TestUpdateNode(t *testing.T) {
 	assert.Equal(t, updated, node)
 }
 
+func TestUpdateNode_version(t *testing.T) {
+	g := New()
+
+	node, _ := g.AddNode("node-1", []string{"person"})
+	assert.Equal(t, uint64(1), node.Version)
+
+	first := node
+	first.Properties = map[string][]byte{"name": []byte("foo")}
+	updated, err := g.UpdateNode(first)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), updated.Version)
+
+	// The second update was made from a stale read.
+	second := node
+	second.Properties = map[string][]byte{"name": []byte("bar")}
+	_, err = g.UpdateNode(second)
+
+	var versionErr VersionError
+	assert.True(t, errors.As(err, &versionErr))
+	assert.Equal(t, VersionError{UID: "node-1", Expected: 1, Actual: 2}, versionErr)
+
+	actual, _ := g.Node("node-1")
+	assert.Equal(t, []byte("foo"), actual.Properties["name"])
+
+	// A zero version is a unconditional update.
+	second.Version = 0
+	updated, err = g.UpdateNode(second)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), updated.Version)
+}
+
+func TestUpdateNode_keeps_edges(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+
+	_, err := g.UpdateNode(NewNode("node-1", []string{"employee"}))
+	assert.Nil(t, err)
+
+	node, _ := g.Node("node-1")
+	assert.Equal(t, []string{"employee"}, node.Labels)
+	assert.Equal(t, []string{"edge-1"}, node.OutEdges())
+}
+
+func TestPatchNode(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("foo")}, KV{Key: "age", Value: []byte("21")})
+	g.AddNode("node-2", []string{"person"})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+
+	patched, err := g.PatchNode("node-1", NodePatch{
+		AddLabels:        []string{"employee", "person"},
+		RemoveLabels:     []string{"person"},
+		SetProperties:    map[string][]byte{"name": []byte("bar")},
+		DeleteProperties: []string{"age"},
+		Version:          1,
+	})
+	assert.Nil(t, err)
+
+	node, _ := g.Node("node-1")
+	assert.Equal(t, patched, node)
+	assert.Equal(t, []string{"employee"}, node.Labels)
+	assert.Equal(t, map[string][]byte{"name": []byte("bar")}, node.Properties)
+	assert.Equal(t, uint64(2), node.Version)
+	assert.Equal(t, []string{"edge-1"}, node.OutEdges())
+	assert.Equal(t, 1, g.NodesBy([]string{"employee"}, ANY, nil).Size())
+
+	_, err = g.PatchNode("node-1", NodePatch{Version: 1})
+	assert.True(t, errors.As(err, &VersionError{}))
+
+	_, err = g.PatchNode("node-missing", NodePatch{})
+	assert.NotNil(t, err)
+}
+
 func TestUpdateNode_missing_node(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
 	g.RemoveNode(n1.UID)
 	n1.Properties["surname"] = []byte("Blah")
 
@@ -369,9 +401,9 @@ func TestUpdateNode_missing_node(t *testing.T) {
 func TestNodeCount(t *testing.T) {
 	g := New()
 
-	g.AddNode("node-1", "person")
-	g.AddNode("node-2", "person")
-	g.AddNode("node-3", "person")
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	g.AddNode("node-3", []string{"person"})
 
 	assert.Equal(t, 3, g.NodeCount())
 }
@@ -379,14 +411,17 @@ func TestNodeCount(t *testing.T) {
 func TestNode_InEdges(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
-	n3, _ := g.AddNode("node-3", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
+	n3, _ := g.AddNode("node-3", []string{"person"})
 
 	g.AddEdge("edge-knows", n1.UID, "knows", n2.UID)
 	g.AddEdge("edge-likes", n1.UID, "likes", n2.UID)
 	g.AddEdge("edge-dislikes", n3.UID, "dislikes", n1.UID)
 
+	// The node returned when adding it does not change with the graph.
+	n1, _ = g.Node(n1.UID)
+
 	expected := []string{"edge-dislikes"}
 	assert.ElementsMatch(t, expected, n1.InEdges())
 }
@@ -394,14 +429,16 @@ func TestNode_InEdges(t *testing.T) {
 func TestNode_OutEdges(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
-	n3, _ := g.AddNode("node-3", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
+	n3, _ := g.AddNode("node-3", []string{"person"})
 
 	g.AddEdge("edge-knows", n1.UID, "knows", n2.UID)
 	g.AddEdge("edge-likes", n1.UID, "likes", n2.UID)
 	g.AddEdge("edge-dislikes", n3.UID, "dislikes", n1.UID)
 
+	n1, _ = g.Node(n1.UID)
+
 	expected := []string{"edge-knows", "edge-likes"}
 	assert.ElementsMatch(t, expected, n1.OutEdges())
 }
@@ -409,24 +446,42 @@ func TestNode_OutEdges(t *testing.T) {
 func TestNode_Edges(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
-	n3, _ := g.AddNode("node-3", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
+	n3, _ := g.AddNode("node-3", []string{"person"})
 
 	g.AddEdge("edge-knows", n1.UID, "knows", n2.UID)
 	g.AddEdge("edge-likes", n1.UID, "likes", n2.UID)
 	g.AddEdge("edge-dislikes", n3.UID, "dislikes", n1.UID)
 
+	n1, _ = g.Node(n1.UID)
+
 	expected := []string{"edge-knows", "edge-likes", "edge-dislikes"}
 	assert.ElementsMatch(t, expected, n1.Edges())
 }
 
+func TestNode_Edges__copy(t *testing.T) {
+	g := New()
+
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+
+	g.AddEdge("edge-knows", "node-1", "knows", "node-2")
+	assert.Equal(t, []string{}, n1.Edges())
+
+	n1, _ = g.Node("node-1")
+	assert.Equal(t, []string{"edge-knows"}, n1.Edges())
+
+	g.RemoveEdge("edge-knows")
+	assert.Equal(t, []string{"edge-knows"}, n1.Edges())
+}
+
 func TestNode_Edges__remove_edge(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-1", "person")
-	n2, _ := g.AddNode("node-2", "person")
-	n3, _ := g.AddNode("node-3", "person")
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
+	n3, _ := g.AddNode("node-3", []string{"person"})
 
 	g.AddEdge("edge-knows", n1.UID, "knows", n2.UID)
 	g.AddEdge("edge-likes", n1.UID, "likes", n2.UID)
@@ -434,6 +489,8 @@ func TestNode_Edges__remove_edge(t *testing.T) {
 
 	g.RemoveEdge("edge-dislikes")
 
+	n1, _ = g.Node(n1.UID)
+
 	expected := []string{"edge-knows", "edge-likes"}
 	assert.ElementsMatch(t, expected, n1.Edges())
 }
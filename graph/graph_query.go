@@ -3,6 +3,7 @@ package graph
 import (
 	"fmt"
 	"log"
+	"sort"
 
 	"github.com/jenmud/draft/graph/parser/cypher"
 )
@@ -17,56 +18,118 @@ func (g *Graph) Query(query string) (*Graph, error) {
 		return nil, err
 	}
 
-	// search for nodes
-	for _, rc := range queryResult.(cypher.QueryPlan).ReadingClause {
+	plan := queryResult.(cypher.QueryPlan)
+
+	// schema commands
+	if plan.Index != nil {
+		switch {
+		case plan.Index.Drop && plan.Index.Range:
+			return subg, g.DropRangeIndex(plan.Index.Label, plan.Index.Property)
+		case plan.Index.Drop:
+			return subg, g.DropIndex(plan.Index.Label, plan.Index.Property)
+		case plan.Index.Range:
+			return subg, g.CreateRangeIndex(plan.Index.Label, plan.Index.Property)
+		}
+		return subg, g.CreateIndex(plan.Index.Label, plan.Index.Property)
+	}
+
+	if plan.Constraint != nil {
+		c := constraintFromCommand(*plan.Constraint)
+		if plan.Constraint.Drop {
+			return subg, g.DropConstraint(c)
+		}
+		return subg, g.CreateConstraint(c)
+	}
+
+	if plan.Call != nil {
+		return g.callProcedure(*plan.Call)
+	}
+
+	// search for nodes using a snapshot so the results are consistent
+	return g.Snapshot().query(plan)
+}
+
+// Query takes a query string and returns a subgraph containing the query
+// results as of the version of the graph in the snapshot. Schema commands
+// and procedures can not be used with a snapshot.
+func (s *Snapshot) Query(query string) (*Graph, error) {
+	queryResult, err := cypher.Parse("", []byte(query))
+	if err != nil {
+		return nil, err
+	}
+
+	plan := queryResult.(cypher.QueryPlan)
+	if plan.Index != nil || plan.Constraint != nil || plan.Call != nil {
+		return New(), fmt.Errorf("[Query] Schema commands and procedures can not be used on a snapshot")
+	}
+
+	return s.query(plan)
+}
+
+// query returns a subgraph with the nodes matching the reading clauses of
+// the query plan and their attached edges and nodes.
+func (s *Snapshot) query(plan cypher.QueryPlan) (*Graph, error) {
+	subg := New()
+
+	for _, rc := range plan.ReadingClause {
 		for _, match := range rc.Matches {
-			for _, node := range match.Nodes {
-				nodes := g.NodesBy(node.Labels, node.Properties)
-				for nodes.Next() {
-					node := nodes.Value().(Node)
-					if _, err := subg.AddNode(node.UID, node.Label, convertPropertiesToKV(node.Properties)...); err != nil {
+			for _, pattern := range match.Nodes {
+				where := []cypher.Predicate{}
+				for _, p := range rc.Where {
+					if p.Variable == pattern.Variable {
+						where = append(where, p)
+					}
+				}
+
+				var order *cypher.OrderBy
+				if rc.OrderBy != nil && rc.OrderBy.Variable == pattern.Variable {
+					order = rc.OrderBy
+				}
+
+				for _, node := range s.matchNodes(pattern, where, order, rc.Limit) {
+					if _, err := subg.copyNode(node); err != nil {
 						log.Printf("[Query] %v", err)
 					}
 
 					// query the edges and add the attached in and out bound nodes.
 					// ()-->(node)
 					for _, edgeUID := range node.InEdges() {
-						edge, err := g.Edge(edgeUID)
+						edge, err := s.Edge(edgeUID)
 						if err != nil {
 							return subg, fmt.Errorf("[Query] Error populating edges: %v", err)
 						}
 
-						sourceNode, err := g.Node(edge.SourceUID)
+						sourceNode, err := s.Node(edge.SourceUID)
 						if err != nil {
 							return subg, fmt.Errorf("[Query] Error fetching inbound node: %v", err)
 						}
 
-						if _, err := subg.AddNode(sourceNode.UID, sourceNode.Label, convertPropertiesToKV(sourceNode.Properties)...); err != nil {
+						if _, err := subg.copyNode(sourceNode); err != nil {
 							log.Printf("[Query] Error inserting source node: %v", err)
 						}
 
-						if _, err := subg.AddEdge(edge.UID, sourceNode.UID, edge.Label, node.UID, convertPropertiesToKV(edge.Properties)...); err != nil {
+						if _, err := subg.copyEdge(edge); err != nil {
 							log.Printf("[Query] Error inbound edge: %v", err)
 						}
 					}
 
 					// (node)-->()
 					for _, edgeUID := range node.OutEdges() {
-						edge, err := g.Edge(edgeUID)
+						edge, err := s.Edge(edgeUID)
 						if err != nil {
 							return subg, fmt.Errorf("[Query] Error populating edges: %v", err)
 						}
 
-						targetNode, err := g.Node(edge.TargetUID)
+						targetNode, err := s.Node(edge.TargetUID)
 						if err != nil {
 							return subg, fmt.Errorf("[Query] Error fetching outbound node: %v", err)
 						}
 
-						if _, err := subg.AddNode(targetNode.UID, targetNode.Label, convertPropertiesToKV(targetNode.Properties)...); err != nil {
+						if _, err := subg.copyNode(targetNode); err != nil {
 							log.Printf("[Query] Error inserting target node: %v", err)
 						}
 
-						if _, err := subg.AddEdge(edge.UID, node.UID, edge.Label, targetNode.UID, convertPropertiesToKV(edge.Properties)...); err != nil {
+						if _, err := subg.copyEdge(edge); err != nil {
 							log.Printf("[Query] Error outbound edge: %v", err)
 						}
 					}
@@ -77,3 +140,317 @@ func (g *Graph) Query(query string) (*Graph, error) {
 
 	return subg, nil
 }
+
+// constraintFromCommand converts the parsed constraint command into a constraint.
+func constraintFromCommand(cmd cypher.ConstraintCommand) Constraint {
+	c := Constraint{Item: NODE, Label: cmd.Label, Property: cmd.Property}
+
+	if cmd.Edge {
+		c.Item = EDGE
+	}
+
+	switch cmd.Kind {
+	case "UNIQUE":
+		c.Type = UNIQUE
+	case "EXISTS":
+		c.Type = EXISTS
+	case "TYPED":
+		c.Type = TYPED
+	}
+
+	switch cmd.ValueType {
+	case "INTEGER":
+		c.ValueType = INTEGER
+	case "FLOAT":
+		c.ValueType = FLOAT
+	case "BOOLEAN":
+		c.ValueType = BOOLEAN
+	}
+
+	return c
+}
+
+// predicateRange returns the range of values matched by the predicate.
+// False is returned for predicates which can not be expressed as a range.
+func predicateRange(p cypher.Predicate) (Range, bool) {
+	switch p.Operator {
+	case "=":
+		return Range{Min: p.Value, Max: p.Value}, true
+	case "<":
+		return Range{Max: p.Value, MaxExclusive: true}, true
+	case "<=":
+		return Range{Max: p.Value}, true
+	case ">":
+		return Range{Min: p.Value, MinExclusive: true}, true
+	case ">=":
+		return Range{Min: p.Value}, true
+	case "STARTS WITH":
+		return PrefixRange(p.Value), true
+	}
+	return Range{}, false
+}
+
+// wherePredicate returns the predicate matching the nodes with the property
+// compared by the WHERE predicate. Nodes without the property never match.
+func wherePredicate(p cypher.Predicate) Predicate {
+	if p.Operator == "<>" {
+		return And(PropRange(p.Property, Range{}), Not(PropRange(p.Property, Range{Min: p.Value, Max: p.Value})))
+	}
+
+	r, _ := predicateRange(p)
+	return PropRange(p.Property, r)
+}
+
+// patternPredicate returns the predicate matching the nodes with all the
+// labels and properties of the node pattern and the WHERE predicates.
+func patternPredicate(pattern cypher.Node, where []cypher.Predicate) Predicate {
+	predicates := []Predicate{labelsPredicate(pattern.Labels, ALL)}
+	predicates = append(predicates, propsPredicates(pattern.Properties)...)
+
+	for _, p := range where {
+		predicates = append(predicates, wherePredicate(p))
+	}
+
+	return And(predicates...)
+}
+
+// propertyRange merges the ranges of the predicates on the property into
+// a single range used for walking a range index. The first bound of each
+// kind is used, the nodes are still checked against every predicate.
+// False is returned if none of the predicates are on the property.
+func propertyRange(property string, where []cypher.Predicate) (Range, bool) {
+	merged := Range{}
+	found := false
+
+	for _, p := range where {
+		if p.Property != property {
+			continue
+		}
+
+		r, ok := predicateRange(p)
+		if !ok {
+			continue
+		}
+
+		found = true
+		if merged.Min == nil && r.Min != nil {
+			merged.Min, merged.MinExclusive = r.Min, r.MinExclusive
+		}
+		if merged.Max == nil && r.Max != nil {
+			merged.Max, merged.MaxExclusive = r.Max, r.MaxExclusive
+		}
+		if merged.Prefix == nil && r.Prefix != nil {
+			merged.Prefix = r.Prefix
+		}
+	}
+
+	return merged, found
+}
+
+// matchNodes returns the nodes matching the node pattern and the WHERE
+// predicates, ordered and limited. Range indexes are used for range
+// predicates and ordering when available, so ordering with a limit only
+// visits the nodes returned. Nodes without the ordered property are
+// ordered last.
+func (s *Snapshot) matchNodes(pattern cypher.Node, where []cypher.Predicate, order *cypher.OrderBy, limit int) []Node {
+	filter := patternPredicate(pattern, where)
+
+	// rangeLabel returns the first label with a range index on the property.
+	rangeLabel := func(property string) (string, bool) {
+		for _, label := range pattern.Labels {
+			if _, ok := s.ranges[0][IndexDef{Label: label, Property: property, Type: RANGE}]; ok {
+				return label, true
+			}
+		}
+		return "", false
+	}
+
+	if len(pattern.Labels) > 0 {
+		q := RangeQuery{Limit: limit, Filter: filter}
+		driving := false
+		bounded := false
+
+		if order != nil {
+			q.Property = order.Property
+			q.Descending = order.Descending
+			q.Range, bounded = propertyRange(order.Property, where)
+			q.Label = pattern.Labels[0]
+			if label, ok := rangeLabel(order.Property); ok {
+				q.Label = label
+			}
+			driving = true
+		} else {
+			for _, p := range where {
+				if label, ok := rangeLabel(p.Property); ok {
+					if r, ok := propertyRange(p.Property, where); ok {
+						q.Label, q.Property, q.Range = label, p.Property, r
+						driving, bounded = true, true
+						break
+					}
+				}
+			}
+		}
+
+		if driving {
+			nodes := []Node{}
+			iter := s.NodesByRange(q)
+			for iter.Next() {
+				nodes = append(nodes, iter.Node())
+			}
+
+			if bounded || limit > 0 && len(nodes) >= limit {
+				return nodes
+			}
+
+			// Add the nodes without the ordered property, which are
+			// already ordered by UID.
+			iter = s.NodesWhere(And(filter, Not(PropRange(q.Property, Range{}))))
+			for iter.Next() {
+				nodes = append(nodes, iter.Node())
+			}
+
+			if limit > 0 && len(nodes) > limit {
+				nodes = nodes[:limit]
+			}
+			return nodes
+		}
+	}
+
+	nodes := []Node{}
+	iter := s.NodesWhere(filter)
+	for iter.Next() {
+		nodes = append(nodes, iter.Node())
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		if order != nil {
+			a, aok := nodes[i].Properties[order.Property]
+			b, bok := nodes[j].Properties[order.Property]
+			switch {
+			case aok != bok:
+				return aok
+			case aok:
+				if c := CompareValues(a, b); c != 0 {
+					return c < 0 != order.Descending
+				}
+			}
+		}
+		return nodes[i].UID < nodes[j].UID
+	})
+
+	if limit > 0 && len(nodes) > limit {
+		nodes = nodes[:limit]
+	}
+
+	return nodes
+}
+
+// callProcedure calls the procedure and returns a subgraph with the
+// procedure results. The supported procedures are:
+//
+//	db.index.fulltext.createNodeIndex(name, labels, properties)
+//	db.index.fulltext.createRelationshipIndex(name, labels, properties)
+//	db.index.fulltext.drop(name)
+//	db.index.fulltext.query(name, query[, limit])
+func (g *Graph) callProcedure(call cypher.ProcedureCall) (*Graph, error) {
+	subg := New()
+
+	// checkArgs checks the argument types, a nil type is a optional int64.
+	checkArgs := func(types ...interface{}) error {
+		required := 0
+		for _, t := range types {
+			if t != nil {
+				required++
+			}
+		}
+
+		if len(call.Args) < required || len(call.Args) > len(types) {
+			return fmt.Errorf("[Query] %s expects %d arguments but got %d", call.Name, len(types), len(call.Args))
+		}
+
+		for i, arg := range call.Args {
+			want := types[i]
+			if want == nil {
+				want = int64(0)
+			}
+
+			if fmt.Sprintf("%T", arg) != fmt.Sprintf("%T", want) {
+				return fmt.Errorf("[Query] %s argument %d should be a %T but got %T", call.Name, i+1, want, arg)
+			}
+		}
+
+		return nil
+	}
+
+	switch call.Name {
+	case "db.index.fulltext.createNodeIndex", "db.index.fulltext.createRelationshipIndex":
+		if err := checkArgs("", []string{}, []string{}); err != nil {
+			return subg, err
+		}
+
+		def := SearchIndexDef{
+			Name:       call.Args[0].(string),
+			Type:       NODE,
+			Labels:     call.Args[1].([]string),
+			Properties: call.Args[2].([]string),
+		}
+
+		if call.Name == "db.index.fulltext.createRelationshipIndex" {
+			def.Type = EDGE
+		}
+
+		return subg, g.CreateSearchIndex(def)
+
+	case "db.index.fulltext.drop":
+		if err := checkArgs(""); err != nil {
+			return subg, err
+		}
+
+		return subg, g.DropSearchIndex(call.Args[0].(string))
+
+	case "db.index.fulltext.query":
+		if err := checkArgs("", "", nil); err != nil {
+			return subg, err
+		}
+
+		limit := 0
+		if len(call.Args) == 3 {
+			limit = int(call.Args[2].(int64))
+		}
+
+		results, err := g.Search(call.Args[0].(string), call.Args[1].(string), limit)
+		if err != nil {
+			return subg, err
+		}
+
+		for _, result := range results {
+			if result.Type == NODE {
+				node := result.Node
+				if _, err := subg.copyNode(node); err != nil {
+					log.Printf("[Query] %v", err)
+				}
+				continue
+			}
+
+			edge := result.Edge
+			for _, uid := range []string{edge.SourceUID, edge.TargetUID} {
+				node, err := g.Node(uid)
+				if err != nil {
+					return subg, fmt.Errorf("[Query] Error fetching edge node: %v", err)
+				}
+
+				if _, err := subg.copyNode(node); err != nil {
+					log.Printf("[Query] Error inserting edge node: %v", err)
+				}
+			}
+
+			if _, err := subg.copyEdge(edge); err != nil {
+				log.Printf("[Query] Error inserting edge: %v", err)
+			}
+		}
+
+		return subg, nil
+	}
+
+	return subg, fmt.Errorf("[Query] Unknown procedure %s", call.Name)
+}
@@ -24,9 +24,9 @@ func TestQuery(t *testing.T) {
 	}
 
 	// Add in some extra nodes to make the testing below easier
-	g.AddNode("node-flower", "flower")
-	g.AddNode("node-flower-rose", "flower", KV{Key: "name", Value: []byte("rose")})
-	g.AddNode("node-car", "car")
+	g.AddNode("node-flower", []string{"flower"})
+	g.AddNode("node-flower-rose", []string{"flower"}, KV{Key: "name", Value: []byte("rose")})
+	g.AddNode("node-car", []string{"car"})
 
 	tests := []TestCase{
 		TestCase{
@@ -36,29 +36,33 @@ func TestQuery(t *testing.T) {
 			Expected: []Node{
 				Node{
 					UID:        "node-car",
-					Label:      "car",
+					Labels:     []string{"car"},
 					Properties: map[string][]byte{},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges:   map[string]struct{}{},
 				},
 				Node{
 					UID:        "node-flower",
-					Label:      "flower",
+					Labels:     []string{"flower"},
 					Properties: map[string][]byte{},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges:   map[string]struct{}{},
 				},
 				Node{
 					UID:        "node-flower-rose",
-					Label:      "flower",
+					Labels:     []string{"flower"},
 					Properties: map[string][]byte{"name": []byte("rose")},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges:   map[string]struct{}{},
 				},
 				Node{
 					UID:        "node-foo",
-					Label:      "person",
+					Labels:     []string{"person"},
 					Properties: map[string][]byte{"name": []byte("foo")},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges: map[string]struct{}{
 						"edge-like":  {},
@@ -68,8 +72,9 @@ func TestQuery(t *testing.T) {
 				},
 				Node{
 					UID:        "node-bar",
-					Label:      "person",
+					Labels:     []string{"person"},
 					Properties: map[string][]byte{"name": []byte("bar")},
+					Version:    1,
 					inEdges: map[string]struct{}{
 						"edge-like":  {},
 						"edge-knows": {},
@@ -80,8 +85,9 @@ func TestQuery(t *testing.T) {
 				},
 				Node{
 					UID:        "node-dog",
-					Label:      "animal",
+					Labels:     []string{"animal"},
 					Properties: map[string][]byte{"name": []byte("socks")},
+					Version:    1,
 					inEdges: map[string]struct{}{
 						"edge-owns":    {},
 						"edge-dislike": {},
@@ -97,8 +103,9 @@ func TestQuery(t *testing.T) {
 			Expected: []Node{
 				Node{
 					UID:        "node-foo",
-					Label:      "person",
+					Labels:     []string{"person"},
 					Properties: map[string][]byte{"name": []byte("foo")},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges: map[string]struct{}{
 						"edge-owns": {},
@@ -106,8 +113,9 @@ func TestQuery(t *testing.T) {
 				},
 				Node{
 					UID:        "node-bar",
-					Label:      "person",
+					Labels:     []string{"person"},
 					Properties: map[string][]byte{"name": []byte("bar")},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges: map[string]struct{}{
 						"edge-dislike": {},
@@ -115,8 +123,9 @@ func TestQuery(t *testing.T) {
 				},
 				Node{
 					UID:        "node-dog",
-					Label:      "animal",
+					Labels:     []string{"animal"},
 					Properties: map[string][]byte{"name": []byte("socks")},
+					Version:    1,
 					inEdges: map[string]struct{}{
 						"edge-owns":    {},
 						"edge-dislike": {},
@@ -132,15 +141,17 @@ func TestQuery(t *testing.T) {
 			Expected: []Node{
 				Node{
 					UID:        "node-flower",
-					Label:      "flower",
+					Labels:     []string{"flower"},
 					Properties: map[string][]byte{},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges:   map[string]struct{}{},
 				},
 				Node{
 					UID:        "node-flower-rose",
-					Label:      "flower",
+					Labels:     []string{"flower"},
 					Properties: map[string][]byte{"name": []byte("rose")},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges:   map[string]struct{}{},
 				},
@@ -156,22 +167,25 @@ func TestQuery(t *testing.T) {
 			Expected: []Node{
 				Node{
 					UID:        "node-flower",
-					Label:      "flower",
+					Labels:     []string{"flower"},
 					Properties: map[string][]byte{},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges:   map[string]struct{}{},
 				},
 				Node{
 					UID:        "node-flower-rose",
-					Label:      "flower",
+					Labels:     []string{"flower"},
 					Properties: map[string][]byte{"name": []byte("rose")},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges:   map[string]struct{}{},
 				},
 				Node{
 					UID:        "node-car",
-					Label:      "car",
+					Labels:     []string{"car"},
 					Properties: map[string][]byte{},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges:   map[string]struct{}{},
 				},
@@ -187,26 +201,27 @@ func TestQuery(t *testing.T) {
 			Expected: []Node{
 				Node{
 					UID:        "node-flower-rose",
-					Label:      "flower",
+					Labels:     []string{"flower"},
 					Properties: map[string][]byte{"name": []byte("rose")},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges:   map[string]struct{}{},
 				},
 				Node{
 					UID:        "node-car",
-					Label:      "car",
+					Labels:     []string{"car"},
 					Properties: map[string][]byte{},
+					Version:    1,
 					inEdges:    map[string]struct{}{},
 					outEdges:   map[string]struct{}{},
 				},
 			},
 		},
 		TestCase{
-			g:           g,
-			Name:        "MultipleLablesNotSupported",
-			Query:       `MATCH (n:animal:person) RETURN n`,
-			Expected:    []Node{},
-			ShouldError: true,
+			g:        g,
+			Name:     "MultipleLablesMustAllMatch",
+			Query:    `MATCH (n:animal:person) RETURN n`,
+			Expected: []Node{},
 		},
 	}
 
@@ -232,3 +247,107 @@ func TestQuery(t *testing.T) {
 	}
 
 }
+
+func TestQuery_multiple_labels(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+	g.AddNode("node-bar", []string{"person", "employee"})
+	g.AddNode("node-baz", []string{"employee"})
+
+	subg, err := g.Query(`MATCH (n:person:employee) RETURN n`)
+	assert.Nil(t, err)
+
+	expected := []Node{
+		Node{
+			UID:        "node-bar",
+			Labels:     []string{"person", "employee"},
+			Properties: map[string][]byte{},
+			Version:    1,
+			inEdges:    map[string]struct{}{},
+			outEdges:   map[string]struct{}{},
+		},
+	}
+
+	actual := []Node{}
+	nodes := subg.Nodes()
+	for nodes.Next() {
+		actual = append(actual, nodes.Value().(Node))
+	}
+
+	assert.ElementsMatch(t, expected, actual)
+}
+
+func TestQuery_where_order_by_limit(t *testing.T) {
+	type TestCase struct {
+		Name     string
+		Query    string
+		Expected []string
+	}
+
+	tests := []TestCase{
+		TestCase{
+			Name:     "RangePredicate",
+			Query:    `MATCH (n:person) WHERE n.created_at >= 200 AND n.created_at < 400 RETURN n`,
+			Expected: []string{"node-bar", "node-baz"},
+		},
+		TestCase{
+			Name:     "StartsWith",
+			Query:    `MATCH (n:person) WHERE n.name STARTS WITH "ba" RETURN n`,
+			Expected: []string{"node-bar", "node-baz"},
+		},
+		TestCase{
+			Name:     "NotEqual",
+			Query:    `MATCH (n:person) WHERE n.name <> "foo" RETURN n`,
+			Expected: []string{"node-bar", "node-baz", "node-qux", "node-quux"},
+		},
+		TestCase{
+			Name:     "OrderByLimit",
+			Query:    `MATCH (n:person) RETURN n ORDER BY n.created_at DESC LIMIT 2`,
+			Expected: []string{"node-qux", "node-baz"},
+		},
+		TestCase{
+			Name:     "OrderByWithPredicateLimit",
+			Query:    `MATCH (n:person) WHERE n.name STARTS WITH "ba" RETURN n ORDER BY n.created_at LIMIT 1`,
+			Expected: []string{"node-bar"},
+		},
+		TestCase{
+			Name:     "MissingPropertyOrderedLast",
+			Query:    `MATCH (n:person) RETURN n ORDER BY n.created_at LIMIT 5`,
+			Expected: []string{"node-foo", "node-bar", "node-baz", "node-qux", "node-quux"},
+		},
+		TestCase{
+			Name:     "NoLabelsLimit",
+			Query:    `MATCH (n) RETURN n ORDER BY n.created_at LIMIT 1`,
+			Expected: []string{"node-foo"},
+		},
+	}
+
+	for _, indexed := range []bool{false, true} {
+		g := New()
+		g.AddNode("node-foo", []string{"person"}, KV{Key: "name", Value: []byte("foo")}, KV{Key: "created_at", Value: []byte("100")})
+		g.AddNode("node-bar", []string{"person"}, KV{Key: "name", Value: []byte("bar")}, KV{Key: "created_at", Value: []byte("200")})
+		g.AddNode("node-baz", []string{"person"}, KV{Key: "name", Value: []byte("baz")}, KV{Key: "created_at", Value: []byte("300")})
+		g.AddNode("node-qux", []string{"person"}, KV{Key: "name", Value: []byte("qux")}, KV{Key: "created_at", Value: []byte("400")})
+		g.AddNode("node-quux", []string{"person"}, KV{Key: "name", Value: []byte("quux")})
+
+		if indexed {
+			_, err := g.Query(`CREATE RANGE INDEX ON :person(created_at)`)
+			assert.Nil(t, err)
+			_, err = g.Query(`CREATE RANGE INDEX ON :person(name)`)
+			assert.Nil(t, err)
+		}
+
+		for _, test := range tests {
+			subg, err := g.Query(test.Query)
+			assert.Nil(t, err, "%s did not expect a error but got: %s", test.Name, err)
+
+			actual := []string{}
+			nodes := subg.Nodes()
+			for nodes.Next() {
+				actual = append(actual, nodes.Value().(Node).UID)
+			}
+
+			assert.ElementsMatch(t, test.Expected, actual, "%s (indexed: %t)", test.Name, indexed)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CreateSearchIndex creates a full-text search index over the properties
+// of the nodes or edges. Existing nodes or edges are indexed straight away.
+func (g *Graph) CreateSearchIndex(def SearchIndexDef) (err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+
+	if def.Name == "" {
+		return fmt.Errorf("[CreateSearchIndex] Search index name is required")
+	}
+
+	if len(def.Properties) == 0 {
+		return fmt.Errorf("[CreateSearchIndex] Search index %s needs at least one property", def.Name)
+	}
+
+	if _, ok := g.searches[def.Name]; ok {
+		return fmt.Errorf("[CreateSearchIndex] Search index %s already exists", def.Name)
+	}
+
+	return g.createSearchIndex(def)
+}
+
+// createSearchIndex creates the search index, which does not exist yet,
+// and indexes the existing nodes or edges. The caller is expected to be
+// holding the write lock.
+func (g *Graph) createSearchIndex(def SearchIndexDef) error {
+	idx := newSearchIndex(def)
+
+	switch def.Type {
+	case NODE:
+		iter := g.view().Nodes()
+		for iter.Next() {
+			node := iter.Node()
+			idx.add(node.UID, node.Labels, node.Properties)
+		}
+	case EDGE:
+		iter := g.view().Edges()
+		for iter.Next() {
+			edge := iter.Edge()
+			idx.add(edge.UID, []string{edge.Label}, edge.Properties)
+		}
+	default:
+		return fmt.Errorf("[CreateSearchIndex] Unknown item type %d", def.Type)
+	}
+
+	g.searches[def.Name] = idx
+	g.changed = true
+	g.recordSchema(walEntry{Op: walCreateSearchIndex, Search: &def}, func() { g.dropSearchIndex(def.Name) })
+	return nil
+}
+
+// DropSearchIndex removes the full-text search index.
+func (g *Graph) DropSearchIndex(name string) (err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+
+	if _, ok := g.searches[name]; !ok {
+		return fmt.Errorf("[DropSearchIndex] No such search index %s", name)
+	}
+
+	g.dropSearchIndex(name)
+	return nil
+}
+
+// dropSearchIndex removes the search index, which is expected to exist.
+// The caller is expected to be holding the write lock.
+func (g *Graph) dropSearchIndex(name string) {
+	idx := g.searches[name]
+	delete(g.searches, name)
+	g.changed = true
+	g.recordSchema(walEntry{Op: walDropSearchIndex, Name: name}, func() { g.searches[name] = idx })
+}
+
+// SearchIndexes returns all the full-text search index definitions sorted by name.
+func (g *Graph) SearchIndexes() []SearchIndexDef {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.listSearchIndexes()
+}
+
+// listSearchIndexes returns all the full-text search index definitions.
+// The caller is expected to be holding the read lock.
+func (g *Graph) listSearchIndexes() []SearchIndexDef {
+	defs := make([]SearchIndexDef, 0, len(g.searches))
+	for _, idx := range g.searches {
+		defs = append(defs, idx.def)
+	}
+
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Name < defs[j].Name
+	})
+
+	return defs
+}
+
+// Search searches the full-text index and returns the matching nodes or
+// edges ordered by relevance, with the best match first. The query is a
+// list of words, any of which can match. Words ending in `*` match terms
+// starting with the word and words ending in `~` (or `~2`) match terms
+// within one (or two) edits of the word. Limit is the maximum number of
+// results, zero is unlimited.
+func (g *Graph) Search(name, query string, limit int) ([]SearchResult, error) {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+
+	idx, ok := g.searches[name]
+	if !ok {
+		return nil, fmt.Errorf("[Search] No such search index %s", name)
+	}
+
+	// The index is changed along with the shards, before the changes are
+	// committed, so matches which are not in the snapshot yet are left out.
+	scores := idx.search(query)
+	snap := g.Snapshot()
+	results := []SearchResult{}
+	for uid, score := range scores {
+		result := SearchResult{Type: idx.def.Type, Score: score}
+
+		var err error
+		switch idx.def.Type {
+		case NODE:
+			result.Node, err = snap.Node(uid)
+		case EDGE:
+			result.Edge, err = snap.Edge(uid)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		results = append(results, result)
+	}
+
+	uid := func(result SearchResult) string {
+		if result.Type == EDGE {
+			return result.Edge.UID
+		}
+		return result.Node.UID
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return uid(results[i]) < uid(results[j])
+	})
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
@@ -0,0 +1,140 @@
+package graph
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func searchUIDs(t *testing.T, g *Graph, name, query string) []string {
+	results, err := g.Search(name, query, 0)
+	assert.Nil(t, err)
+
+	uids := []string{}
+	for _, result := range results {
+		if result.Type == EDGE {
+			uids = append(uids, result.Edge.UID)
+			continue
+		}
+		uids = append(uids, result.Node.UID)
+	}
+	return uids
+}
+
+func TestCreateSearchIndex(t *testing.T) {
+	g := New()
+	def := SearchIndexDef{Name: "people", Type: NODE, Labels: []string{"person"}, Properties: []string{"name"}}
+
+	assert.Nil(t, g.CreateSearchIndex(def))
+	assert.NotNil(t, g.CreateSearchIndex(def))
+	assert.NotNil(t, g.CreateSearchIndex(SearchIndexDef{Name: "empty", Type: NODE}))
+	assert.Equal(t, []SearchIndexDef{def}, g.SearchIndexes())
+
+	assert.Nil(t, g.DropSearchIndex("people"))
+	assert.NotNil(t, g.DropSearchIndex("people"))
+	assert.Equal(t, []SearchIndexDef{}, g.SearchIndexes())
+
+	_, err := g.Search("people", "foo", 0)
+	assert.NotNil(t, err)
+}
+
+func TestSearch(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("John Smith")}, KV{Key: "bio", Value: []byte("Likes gardening")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "name", Value: []byte("Jane Smyth")})
+	g.AddNode("node-3", []string{"person"}, KV{Key: "name", Value: []byte("Smith Smith")})
+	g.AddNode("node-4", []string{"pet"}, KV{Key: "name", Value: []byte("Smith")})
+
+	assert.Nil(t, g.CreateSearchIndex(SearchIndexDef{Name: "people", Type: NODE, Labels: []string{"person"}, Properties: []string{"name", "bio"}}))
+
+	// node-3 mentions smith twice so is more relevant.
+	assert.Equal(t, []string{"node-3", "node-1"}, searchUIDs(t, g, "people", "SMITH"))
+	assert.Equal(t, []string{"node-1"}, searchUIDs(t, g, "people", "garden*"))
+	assert.ElementsMatch(t, []string{"node-1", "node-2"}, searchUIDs(t, g, "people", "j*"))
+	assert.Equal(t, []string{"node-2", "node-3", "node-1"}, searchUIDs(t, g, "people", "smyth~"))
+	assert.Equal(t, []string{}, searchUIDs(t, g, "people", "nobody"))
+
+	// Both terms match node-1 so it is ranked first.
+	assert.Equal(t, "node-1", searchUIDs(t, g, "people", "john smith")[0])
+
+	results, _ := g.Search("people", "smith", 1)
+	assert.Equal(t, 1, len(results))
+
+	n2, _ := g.Node("node-2")
+	n2.Properties = map[string][]byte{"name": []byte("Jane Doe")}
+	g.UpdateNode(n2)
+	assert.Equal(t, []string{"node-2"}, searchUIDs(t, g, "people", "doe"))
+	assert.Equal(t, []string{}, searchUIDs(t, g, "people", "smyth"))
+
+	g.RemoveNode("node-2")
+	assert.Equal(t, []string{}, searchUIDs(t, g, "people", "doe"))
+
+	g.AddNode("node-5", []string{"person", "employee"}, KV{Key: "name", Value: []byte("Bob Doe")})
+	assert.Equal(t, []string{"node-5"}, searchUIDs(t, g, "people", "doe"))
+}
+
+func TestSearch_edges(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2", KV{Key: "note", Value: []byte("met at school")})
+
+	assert.Nil(t, g.CreateSearchIndex(SearchIndexDef{Name: "notes", Type: EDGE, Properties: []string{"note"}}))
+	assert.Equal(t, []string{"edge-1"}, searchUIDs(t, g, "notes", "school"))
+
+	g.AddEdge("edge-2", "node-2", "likes", "node-1", KV{Key: "note", Value: []byte("school friend")})
+	assert.ElementsMatch(t, []string{"edge-1", "edge-2"}, searchUIDs(t, g, "notes", "school"))
+
+	g.RemoveEdge("edge-1")
+	assert.Equal(t, []string{"edge-2"}, searchUIDs(t, g, "notes", "school"))
+}
+
+func TestMarshalJSON_search_indexes(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	g.CreateSearchIndex(SearchIndexDef{Name: "people", Type: NODE, Labels: []string{"person"}, Properties: []string{"name"}})
+
+	dump, err := json.Marshal(g)
+	assert.Nil(t, err)
+
+	actual := New()
+	assert.Nil(t, json.Unmarshal(dump, actual))
+	assert.Equal(t, g.SearchIndexes(), actual.SearchIndexes())
+	assert.Equal(t, []string{"node-1"}, searchUIDs(t, actual, "people", "foo"))
+}
+
+func TestQuery_fulltext_procedures(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("John Smith")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "name", Value: []byte("Jane Doe")})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2", KV{Key: "note", Value: []byte("neighbours")})
+
+	_, err := g.Query(`CALL db.index.fulltext.createNodeIndex("people", ["person"], ["name"])`)
+	assert.Nil(t, err)
+	_, err = g.Query(`CALL db.index.fulltext.createRelationshipIndex("notes", [], ["note"])`)
+	assert.Nil(t, err)
+
+	subg, err := g.Query(`CALL db.index.fulltext.query("people", "jon~") YIELD node, score`)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, subg.NodeCount())
+	assert.True(t, subg.HasNode("node-1"))
+
+	subg, err = g.Query(`CALL db.index.fulltext.query("notes", "neighbour*", 5)`)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, subg.NodeCount())
+	assert.True(t, subg.HasEdge("edge-1"))
+
+	_, err = g.Query(`CALL db.index.fulltext.query("people")`)
+	assert.NotNil(t, err)
+
+	_, err = g.Query(`CALL db.index.fulltext.query(1, "foo")`)
+	assert.NotNil(t, err)
+
+	_, err = g.Query(`CALL db.unknown()`)
+	assert.NotNil(t, err)
+
+	_, err = g.Query(`CALL db.index.fulltext.drop("people")`)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(g.SearchIndexes()))
+}
@@ -6,7 +6,6 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"testing"
-	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -35,8 +34,9 @@ func TestSubGraph_one_level(t *testing.T) {
 		t,
 		Node{
 			UID:        "node-foo",
-			Label:      "person",
+			Labels:     []string{"person"},
 			Properties: map[string][]byte{"name": []byte("foo")},
+			Version:    1,
 			inEdges:    map[string]struct{}{},
 			outEdges: map[string]struct{}{
 				"edge-owns": {},
@@ -50,8 +50,9 @@ func TestSubGraph_one_level(t *testing.T) {
 		t,
 		Node{
 			UID:        "node-bar",
-			Label:      "person",
+			Labels:     []string{"person"},
 			Properties: map[string][]byte{"name": []byte("bar")},
+			Version:    1,
 			inEdges:    map[string]struct{}{},
 			outEdges: map[string]struct{}{
 				"edge-dislike": {},
@@ -65,8 +66,9 @@ func TestSubGraph_one_level(t *testing.T) {
 		t,
 		Node{
 			UID:        "node-dog",
-			Label:      "animal",
+			Labels:     []string{"animal"},
 			Properties: map[string][]byte{"name": []byte("socks")},
+			Version:    1,
 			inEdges: map[string]struct{}{
 				"edge-owns":    {},
 				"edge-dislike": {},
@@ -78,19 +80,19 @@ func TestSubGraph_one_level(t *testing.T) {
 
 	e1, err := subg.Edge("edge-dislike")
 	assert.Nil(t, err)
-	assert.Equal(t, Edge{UID: "edge-dislike", SourceUID: "node-bar", Label: "dislikes", TargetUID: "node-dog", Properties: map[string][]byte{}}, e1)
+	assert.Equal(t, Edge{UID: "edge-dislike", SourceUID: "node-bar", Label: "dislikes", TargetUID: "node-dog", Properties: map[string][]byte{}, Version: 1}, e1)
 
 	e2, err := subg.Edge("edge-owns")
 	assert.Nil(t, err)
-	assert.Equal(t, Edge{UID: "edge-owns", SourceUID: "node-foo", Label: "owns", TargetUID: "node-dog", Properties: map[string][]byte{}}, e2)
+	assert.Equal(t, Edge{UID: "edge-owns", SourceUID: "node-foo", Label: "owns", TargetUID: "node-dog", Properties: map[string][]byte{}, Version: 1}, e2)
 }
 
 func TestMarshalJSON(t *testing.T) {
 	g := New()
 
-	n1, _ := g.AddNode("node-foo", "person", KV{Key: "name", Value: []byte("foo")})
-	n2, _ := g.AddNode("node-bar", "person", KV{Key: "name", Value: []byte("bar")})
-	n3, _ := g.AddNode("node-dog", "animal", KV{Key: "name", Value: []byte("socks")})
+	n1, _ := g.AddNode("node-foo", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	n2, _ := g.AddNode("node-bar", []string{"person"}, KV{Key: "name", Value: []byte("bar")})
+	n3, _ := g.AddNode("node-dog", []string{"animal"}, KV{Key: "name", Value: []byte("socks")})
 
 	g.AddEdge("edge-knows", n1.UID, "knows", n2.UID, KV{Key: "name", Value: []byte("2020")})
 	g.AddEdge("edge-owns", n1.UID, "owns", n3.UID)
@@ -102,15 +104,27 @@ func TestMarshalJSON(t *testing.T) {
 
 	actual := New()
 
-	// Hack the start times as they are not testable
-	now := time.Now()
-	g.startTime = now
-	actual.startTime = now
-
 	err = json.Unmarshal(dump, &actual)
 	assert.Nil(t, err)
 
-	assert.Equal(t, g, actual)
+	// Only compare the graph content as the start times
+	// and UID generators are not testable.
+	collect := func(g *Graph) ([]Node, []Edge) {
+		nodes, edges := []Node{}, []Edge{}
+		snap := g.Snapshot()
+		for iter := snap.Nodes(); iter.Next(); {
+			nodes = append(nodes, iter.Node())
+		}
+		for iter := snap.Edges(); iter.Next(); {
+			edges = append(edges, iter.Edge())
+		}
+		return nodes, edges
+	}
+
+	expectedNodes, expectedEdges := collect(g)
+	actualNodes, actualEdges := collect(actual)
+	assert.Equal(t, expectedNodes, actualNodes)
+	assert.Equal(t, expectedEdges, actualEdges)
 }
 
 func TestUnmarshalJSON(t *testing.T) {
@@ -127,8 +141,9 @@ func TestUnmarshalJSON(t *testing.T) {
 		t,
 		Node{
 			UID:        "node-foo",
-			Label:      "person",
+			Labels:     []string{"person"},
 			Properties: map[string][]byte{"name": []byte("foo")},
+			Version:    1,
 			inEdges:    map[string]struct{}{},
 			outEdges: map[string]struct{}{
 				"edge-like":  {},
@@ -145,8 +160,9 @@ func TestUnmarshalJSON(t *testing.T) {
 		t,
 		Node{
 			UID:        "node-bar",
-			Label:      "person",
+			Labels:     []string{"person"},
 			Properties: map[string][]byte{"name": []byte("bar")},
+			Version:    1,
 			inEdges: map[string]struct{}{
 				"edge-like":  {},
 				"edge-knows": {},
@@ -164,8 +180,9 @@ func TestUnmarshalJSON(t *testing.T) {
 		t,
 		Node{
 			UID:        "node-dog",
-			Label:      "animal",
+			Labels:     []string{"animal"},
 			Properties: map[string][]byte{"name": []byte("socks")},
+			Version:    1,
 			inEdges: map[string]struct{}{
 				"edge-dislike": {},
 				"edge-owns":    {},
@@ -177,17 +194,48 @@ func TestUnmarshalJSON(t *testing.T) {
 
 	e1, err := g.Edge("edge-like")
 	assert.Nil(t, err)
-	assert.Equal(t, Edge{UID: "edge-like", SourceUID: "node-foo", Label: "likes", TargetUID: "node-bar", Properties: map[string][]byte{}}, e1)
+	assert.Equal(t, Edge{UID: "edge-like", SourceUID: "node-foo", Label: "likes", TargetUID: "node-bar", Properties: map[string][]byte{}, Version: 1}, e1)
 
 	e2, err := g.Edge("edge-dislike")
 	assert.Nil(t, err)
-	assert.Equal(t, Edge{UID: "edge-dislike", SourceUID: "node-bar", Label: "dislikes", TargetUID: "node-dog", Properties: map[string][]byte{}}, e2)
+	assert.Equal(t, Edge{UID: "edge-dislike", SourceUID: "node-bar", Label: "dislikes", TargetUID: "node-dog", Properties: map[string][]byte{}, Version: 1}, e2)
 
 	e3, err := g.Edge("edge-knows")
 	assert.Nil(t, err)
-	assert.Equal(t, Edge{UID: "edge-knows", SourceUID: "node-foo", Label: "knows", TargetUID: "node-bar", Properties: map[string][]byte{"name": []byte("2020")}}, e3)
+	assert.Equal(t, Edge{UID: "edge-knows", SourceUID: "node-foo", Label: "knows", TargetUID: "node-bar", Properties: map[string][]byte{"name": []byte("2020")}, Version: 1}, e3)
 
 	e4, err := g.Edge("edge-owns")
 	assert.Nil(t, err)
-	assert.Equal(t, Edge{UID: "edge-owns", SourceUID: "node-foo", Label: "owns", TargetUID: "node-dog", Properties: map[string][]byte{}}, e4)
+	assert.Equal(t, Edge{UID: "edge-owns", SourceUID: "node-foo", Label: "owns", TargetUID: "node-dog", Properties: map[string][]byte{}, Version: 1}, e4)
+}
+
+func TestUnmarshalJSON_legacy_label(t *testing.T) {
+	// Dumps made before nodes had multiple labels have a single label.
+	dump := readTestData(t, "legacy-graph.json")
+	g := New()
+
+	err := json.Unmarshal(dump, &g)
+	assert.Nil(t, err)
+
+	expected := New()
+	err = json.Unmarshal(readTestData(t, "simple-graph.json"), &expected)
+	assert.Nil(t, err)
+
+	for _, uid := range []string{"node-foo", "node-bar", "node-dog"} {
+		actualNode, err := g.Node(uid)
+		assert.Nil(t, err)
+		expectedNode, _ := expected.Node(uid)
+		assert.Equal(t, expectedNode, actualNode)
+	}
+
+	assert.Equal(t, []string{"node-bar", "node-foo"}, uids(g.NodesWhere(HasLabel("person"))))
+	assert.Equal(t, 4, g.EdgeCount())
+}
+
+func TestNode_UnmarshalJSON(t *testing.T) {
+	node := Node{}
+	err := json.Unmarshal([]byte(`{"uid": "node-1", "label": "person", "labels": ["admin", "person"]}`), &node)
+	assert.Nil(t, err)
+	assert.Equal(t, "node-1", node.UID)
+	assert.Equal(t, []string{"person", "admin"}, node.Labels)
 }
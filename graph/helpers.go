@@ -7,7 +7,34 @@ func convertPropertiesToKV(props map[string][]byte) []KV {
 	count := 0
 	for k, v := range props {
 		kvs[count] = KV{Key: k, Value: v}
+		count++
 	}
 
 	return kvs
 }
+
+// uniqueLabels returns the labels with any duplicates removed.
+func uniqueLabels(labels []string) []string {
+	seen := make(map[string]struct{}, len(labels))
+	unique := make([]string, 0, len(labels))
+
+	for _, label := range labels {
+		if _, ok := seen[label]; ok {
+			continue
+		}
+		seen[label] = struct{}{}
+		unique = append(unique, label)
+	}
+
+	return unique
+}
+
+// containsString returns true if the value is one of the values.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
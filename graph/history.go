@@ -0,0 +1,241 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ErrNotRetained is returned when reading a version of the graph which
+// has been removed by the retention policy, or does not exist yet.
+var ErrNotRetained = errors.New("Version is not retained")
+
+// ErrNotVersioned is returned when reading a version of the graph as of a
+// time or version, with a storage backend which is not versioned.
+var ErrNotVersioned = errors.New("Storage is not versioned")
+
+// RetentionPolicy decides how many earlier versions of the graph are kept
+// for reading as of a earlier time or version. A version is removed once
+// it is over either of the limits, a zero limit is not used. If both
+// limits are zero no earlier versions are kept, which is the default.
+// The current version is always kept.
+type RetentionPolicy struct {
+	// Versions is the maximum number of earlier versions kept.
+	Versions int
+	// Age is how far back in time the graph can be read. The version
+	// current at that time is kept even if it is older.
+	Age time.Duration
+}
+
+// WithRetention sets the policy deciding which earlier versions of the
+// graph are kept.
+func WithRetention(policy RetentionPolicy) Option {
+	return func(g *Graph) {
+		g.retention = policy
+	}
+}
+
+// unlock logs and commits the changes made while holding the write lock as
+// a new version of the graph and releases the write lock. If the changes
+// can not be logged or committed, they are undone and the error is
+// returned.
+func (g *Graph) unlock() error {
+	err := g.save(1<<shardCount-1, true)
+	g.locked = false
+	g.lock.Unlock()
+	return err
+}
+
+// save logs the pending changes of the shards and commits the changed
+// shards as a new version of the graph, along with the indexes and schema
+// if schema is true. If the changes can not be logged or committed, they
+// are undone and the error is returned. The caller is expected to be
+// holding the locks of the shards, or the write lock if schema is true.
+func (g *Graph) save(shards shardSet, schema bool) error {
+	entries := g.takePending(shards)
+	err := g.logEntries(entries)
+	logged := err == nil
+
+	if logged {
+		var changed shardSet
+		shards.each(func(i int) {
+			if g.shards[i].store.Changed() {
+				changed |= 1 << uint(i)
+			}
+		})
+
+		if changed != 0 || (schema && g.changed) {
+			err = g.commit(changed, schema)
+		}
+	}
+
+	if err != nil {
+		g.undo(shards, entries, logged)
+		if schema {
+			g.changed = false
+		}
+	}
+
+	return err
+}
+
+// commit adds a snapshot of the graph as the next version and removes the
+// versions no longer retained. Only the storage and range indexes of the
+// changed shards are committed, the views of the rest are shared with the
+// previous version. If schema is true, the indexes and schema are taken
+// again as well. If the storage of a shard can not be committed, no
+// version is added and the error is returned. The caller is expected to be
+// holding the locks of the changed shards, or the write lock if schema is
+// true.
+func (g *Graph) commit(changed shardSet, schema bool) error {
+	// The schema is committed along with the changes to the shards, which
+	// are then already committed by the storage.
+	if backend, ok := g.storage.(schemaBackend); ok && schema && g.changed {
+		if err := backend.commitSchema(g.storedSchema()); err != nil {
+			return err
+		}
+	}
+
+	// The shards are committed before taking the history lock, so writers
+	// of other shards only wait for the next version to be added.
+	var views [shardCount]StorageView
+	var ranges [shardCount]map[IndexDef]*rangeIndex
+	var err error
+	changed.each(func(i int) {
+		if err != nil {
+			return
+		}
+		views[i], err = g.shards[i].store.Commit()
+		ranges[i] = g.shards[i].cloneRanges()
+	})
+
+	if err != nil {
+		return err
+	}
+
+	g.historyLock.Lock()
+	defer g.historyLock.Unlock()
+
+	latest := g.history[len(g.history)-1]
+
+	// The commit times never go backwards, even if the clock does.
+	now := g.now()
+	if now.Before(latest.time) {
+		now = latest.time
+	}
+
+	next := *latest
+	next.version = latest.version + 1
+	next.time = now
+
+	changed.each(func(i int) {
+		next.shards[i] = views[i]
+		next.ranges[i] = ranges[i]
+	})
+	next.link()
+
+	if schema {
+		g.snapshotSchema(&next)
+		g.changed = false
+	}
+
+	g.history = append(g.history, &next)
+	g.collectHistory(now)
+	return nil
+}
+
+// collectHistory removes the earlier versions which are not retained by
+// the retention policy. Earlier versions are never kept with a storage
+// backend which is not versioned, as they would read the storage as last
+// committed. The caller is expected to be holding the history lock.
+func (g *Graph) collectHistory(now time.Time) {
+	earlier := len(g.history) - 1
+	drop := earlier
+
+	if g.storage.Versioned() && (g.retention.Versions > 0 || g.retention.Age > 0) {
+		drop = 0
+	}
+
+	if g.retention.Versions > 0 && earlier > g.retention.Versions {
+		drop = earlier - g.retention.Versions
+	}
+
+	if g.retention.Age > 0 {
+		// Keep the version current at the cutoff, it is replaced by the
+		// next version only after the cutoff.
+		cutoff := now.Add(-g.retention.Age)
+		aged := 0
+		for aged < earlier && !g.history[aged+1].time.After(cutoff) {
+			aged++
+		}
+
+		if aged > drop {
+			drop = aged
+		}
+	}
+
+	if drop == 0 {
+		return
+	}
+
+	kept := copy(g.history, g.history[drop:])
+	for i := kept; i < len(g.history); i++ {
+		g.history[i] = nil
+	}
+	g.history = g.history[:kept]
+}
+
+// SnapshotAt returns the snapshot of the graph as it was at the time.
+func (g *Graph) SnapshotAt(t time.Time) (*Snapshot, error) {
+	if !g.storage.Versioned() {
+		return nil, fmt.Errorf("[SnapshotAt] %w", ErrNotVersioned)
+	}
+
+	g.historyLock.Lock()
+	defer g.historyLock.Unlock()
+
+	// Find the last version committed at or before the time.
+	i := sort.Search(len(g.history), func(i int) bool {
+		return g.history[i].time.After(t)
+	})
+
+	if i == 0 {
+		return nil, fmt.Errorf("[SnapshotAt] %w as of %s", ErrNotRetained, t.Format(time.RFC3339Nano))
+	}
+
+	return g.history[i-1], nil
+}
+
+// SnapshotAtVersion returns the snapshot of the graph at the version.
+func (g *Graph) SnapshotAtVersion(version uint64) (*Snapshot, error) {
+	if !g.storage.Versioned() {
+		return nil, fmt.Errorf("[SnapshotAtVersion] %w", ErrNotVersioned)
+	}
+
+	g.historyLock.Lock()
+	defer g.historyLock.Unlock()
+
+	// The retained versions are always consecutive.
+	oldest := g.history[0].version
+	if version < oldest || version-oldest >= uint64(len(g.history)) {
+		return nil, fmt.Errorf("[SnapshotAtVersion] %w: %d", ErrNotRetained, version)
+	}
+
+	return g.history[version-oldest], nil
+}
+
+// Versioned returns true if the snapshots of the graph never change, and
+// it can be read as of a earlier time or version. A graph in a storage
+// which is not versioned can only be read as last committed.
+func (g *Graph) Versioned() bool {
+	return g.storage.Versioned()
+}
+
+// Version returns the current version of the graph, which is increased
+// every time the graph is changed.
+func (g *Graph) Version() uint64 {
+	g.historyLock.Lock()
+	defer g.historyLock.Unlock()
+	return g.history[len(g.history)-1].version
+}
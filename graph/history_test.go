@@ -0,0 +1,176 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock returns a clock starting at start which is moved forward with
+// the returned function.
+func fakeClock(g *Graph, start time.Time) func(time.Duration) {
+	now := start
+	g.now = func() time.Time { return now }
+	return func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestSnapshotAtVersion(t *testing.T) {
+	g := New(WithRetention(RetentionPolicy{Versions: 10}))
+	assert.Equal(t, uint64(0), g.Version())
+
+	g.AddNode("node-foo", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("node-bar", []string{"person"})
+	g.PatchNode("node-foo", NodePatch{SetProperties: map[string][]byte{"name": []byte("changed")}})
+	assert.Equal(t, uint64(3), g.Version())
+
+	// Failed changes are not new versions.
+	_, err := g.AddNode("node-foo", []string{"person"})
+	assert.NotNil(t, err)
+	assert.Equal(t, uint64(3), g.Version())
+
+	snap, err := g.SnapshotAtVersion(1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), snap.Version())
+	assert.Equal(t, 1, snap.NodeCount())
+
+	foo, err := snap.Node("node-foo")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("foo"), foo.Properties["name"])
+
+	snap, err = g.SnapshotAtVersion(3)
+	assert.Nil(t, err)
+	foo, err = snap.Node("node-foo")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("changed"), foo.Properties["name"])
+	assert.Equal(t, uint64(2), foo.Version)
+
+	_, err = g.SnapshotAtVersion(4)
+	assert.True(t, errors.Is(err, ErrNotRetained))
+}
+
+func TestSnapshotAt(t *testing.T) {
+	g := New(WithRetention(RetentionPolicy{Versions: 10}))
+	start := g.Snapshot().Time()
+	advance := fakeClock(g, start)
+
+	advance(time.Hour)
+	g.AddNode("node-foo", []string{"person"})
+	advance(time.Hour)
+	g.AddNode("node-bar", []string{"person"})
+	advance(time.Hour)
+	g.RemoveNode("node-foo")
+
+	tests := []struct {
+		at       time.Time
+		expected []string
+	}{
+		{start.Add(time.Hour), []string{"node-foo"}},
+		{start.Add(90 * time.Minute), []string{"node-foo"}},
+		{start.Add(2 * time.Hour), []string{"node-bar", "node-foo"}},
+		{start.Add(24 * time.Hour), []string{"node-bar"}},
+	}
+
+	for _, tt := range tests {
+		snap, err := g.SnapshotAt(tt.at)
+		assert.Nil(t, err)
+		assert.Equal(t, tt.expected, collectUIDs(snap.NodesBy([]string{"person"}, ALL, nil)), tt.at)
+	}
+
+	// The graph was created after the time.
+	_, err := g.SnapshotAt(start.Add(-time.Hour))
+	assert.True(t, errors.Is(err, ErrNotRetained))
+}
+
+func TestRetentionPolicy_default(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+	g.AddNode("node-bar", []string{"person"})
+
+	_, err := g.SnapshotAtVersion(1)
+	assert.True(t, errors.Is(err, ErrNotRetained))
+
+	snap, err := g.SnapshotAtVersion(2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, snap.NodeCount())
+	assert.Equal(t, 1, len(g.history))
+}
+
+func TestRetentionPolicy_versions(t *testing.T) {
+	g := New(WithRetention(RetentionPolicy{Versions: 2}))
+	g.AddNode("node-1", nil)
+	g.AddNode("node-2", nil)
+	g.AddNode("node-3", nil)
+	g.AddNode("node-4", nil)
+
+	for version, retained := range map[uint64]bool{1: false, 2: true, 3: true, 4: true} {
+		_, err := g.SnapshotAtVersion(version)
+		assert.Equal(t, retained, err == nil, version)
+	}
+}
+
+func TestRetentionPolicy_age(t *testing.T) {
+	g := New(WithRetention(RetentionPolicy{Age: 2 * time.Hour}))
+	start := g.Snapshot().Time()
+	advance := fakeClock(g, start)
+
+	g.AddNode("node-1", nil) // +0h
+	advance(time.Hour)
+	g.AddNode("node-2", nil) // +1h
+	advance(time.Hour)
+	g.AddNode("node-3", nil) // +2h
+	advance(90 * time.Minute)
+	g.AddNode("node-4", nil) // +3h30m
+
+	// Version 2 was current at the cutoff of +1h30m so it is kept.
+	_, err := g.SnapshotAtVersion(1)
+	assert.True(t, errors.Is(err, ErrNotRetained))
+
+	snap, err := g.SnapshotAt(start.Add(90 * time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), snap.Version())
+	assert.Equal(t, 2, snap.NodeCount())
+}
+
+func TestSnapshot_Query(t *testing.T) {
+	g := New(WithRetention(RetentionPolicy{Versions: 10}))
+	g.AddNode("node-foo", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("node-bar", []string{"person"}, KV{Key: "name", Value: []byte("bar")})
+	g.AddEdge("edge-knows", "node-foo", "knows", "node-bar")
+	g.RemoveNode("node-bar", Detach())
+
+	snap, err := g.SnapshotAtVersion(3)
+	assert.Nil(t, err)
+
+	subg, err := snap.Query(`MATCH (n:person) WHERE n.name = "foo" RETURN n`)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, subg.NodeCount())
+	assert.Equal(t, true, subg.HasEdge("edge-knows"))
+
+	subg, err = g.Query(`MATCH (n:person) WHERE n.name = "foo" RETURN n`)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, subg.NodeCount())
+
+	_, err = snap.Query(`CREATE INDEX ON :person(name)`)
+	assert.NotNil(t, err)
+}
+
+func TestTx_commit_version(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+
+	tx := g.Begin()
+	tx.AddNode("node-bar", []string{"person"})
+	tx.AddNode("node-baz", []string{"person"})
+	assert.Nil(t, tx.Commit())
+	assert.Equal(t, uint64(2), g.Version())
+
+	// A failed commit is not a new version.
+	tx = g.Begin()
+	tx.AddNode("node-qux", []string{"person"})
+	tx.UpdateNode(Node{UID: "node-foo", Labels: []string{"person"}, Version: 1})
+	g.PatchNode("node-foo", NodePatch{AddLabels: []string{"employee"}})
+	assert.NotNil(t, tx.Commit())
+	assert.Equal(t, uint64(3), g.Version())
+}
@@ -0,0 +1,208 @@
+package graph
+
+import (
+	"fmt"
+	"math"
+	"unsafe"
+
+	"github.com/google/btree"
+)
+
+// rangeItem is a property value and node uid stored in a range index.
+type rangeItem struct {
+	value orderedValue
+	uid   string
+	// last sorts the item after every other item with the same value.
+	last bool
+}
+
+// Less orders the items by value and then uid.
+func (item rangeItem) Less(than btree.Item) bool {
+	other := than.(rangeItem)
+	if c := item.value.compare(other.value); c != 0 {
+		return c < 0
+	}
+	if item.last != other.last {
+		return other.last
+	}
+	return item.uid < other.uid
+}
+
+// rangeIndex is a ordered index of property values to node uids.
+type rangeIndex struct {
+	tree *btree.BTree
+}
+
+// newRangeIndex returns a new empty range index.
+func newRangeIndex() *rangeIndex {
+	return &rangeIndex{tree: btree.New(32)}
+}
+
+// add adds the uid with the property value.
+func (idx *rangeIndex) add(value []byte, uid string) {
+	idx.tree.ReplaceOrInsert(rangeItem{value: decodeValue(value), uid: uid})
+}
+
+// remove removes the uid with the property value.
+func (idx *rangeIndex) remove(value []byte, uid string) {
+	idx.tree.Delete(rangeItem{value: decodeValue(value), uid: uid})
+}
+
+// size returns a estimate of the memory used by the index in bytes.
+func (idx *rangeIndex) size() int {
+	size := treeSize(idx.tree, unsafe.Sizeof(rangeItem{}))
+	idx.tree.Ascend(func(i btree.Item) bool {
+		size += len(i.(rangeItem).value.text)
+		return true
+	})
+	return size
+}
+
+// scanAfter calls fn with the items having a value in the range, ordered
+// by the value and then uid, starting after the item after if it is set,
+// until fn returns false.
+func (idx *rangeIndex) scanAfter(vr valueRange, descending bool, after *rangeItem, fn func(item rangeItem) bool) {
+	visit := func(i btree.Item) bool {
+		item := i.(rangeItem)
+
+		if after != nil && (descending && !item.Less(*after) || !descending && !after.Less(item)) {
+			return true
+		}
+
+		if descending && vr.below(item.value) || !descending && vr.above(item.value) {
+			return false
+		}
+
+		if !vr.contains(item.value) {
+			return true
+		}
+
+		return fn(item)
+	}
+
+	if !descending {
+		switch kind, ok := vr.kind(); {
+		case after != nil:
+			idx.tree.AscendGreaterOrEqual(*after, visit)
+		case vr.min != nil:
+			idx.tree.AscendGreaterOrEqual(rangeItem{value: *vr.min}, visit)
+		case vr.prefix != nil:
+			idx.tree.AscendGreaterOrEqual(rangeItem{value: orderedValue{kind: stringValue, text: *vr.prefix}}, visit)
+		case ok && kind == numberValue:
+			idx.tree.AscendGreaterOrEqual(rangeItem{value: orderedValue{kind: numberValue, number: math.Inf(-1)}}, visit)
+		case ok && kind == stringValue:
+			idx.tree.AscendGreaterOrEqual(rangeItem{value: orderedValue{kind: stringValue}}, visit)
+		default:
+			idx.tree.Ascend(visit)
+		}
+		return
+	}
+
+	switch kind, ok := vr.kind(); {
+	case after != nil:
+		idx.tree.DescendLessOrEqual(*after, visit)
+	case vr.max != nil:
+		idx.tree.DescendLessOrEqual(rangeItem{value: *vr.max, last: true}, visit)
+	case vr.prefix != nil:
+		if end, ok := prefixEnd(*vr.prefix); ok {
+			idx.tree.DescendLessOrEqual(rangeItem{value: orderedValue{kind: stringValue, text: end}}, visit)
+			return
+		}
+		idx.tree.Descend(visit)
+	case ok && kind == numberValue:
+		idx.tree.DescendLessOrEqual(rangeItem{value: orderedValue{kind: numberValue, number: math.Inf(1)}, last: true}, visit)
+	default:
+		idx.tree.Descend(visit)
+	}
+}
+
+// rangeBatchSize is the number of items a range cursor reads at a time.
+const rangeBatchSize = 64
+
+// cursor returns the items having a value in the range in order, reading
+// them from the index a batch at a time.
+func (idx *rangeIndex) cursor(vr valueRange, descending bool) func() (rangeItem, bool) {
+	var (
+		batch []rangeItem
+		after *rangeItem
+		done  bool
+	)
+
+	return func() (rangeItem, bool) {
+		if len(batch) == 0 && !done {
+			idx.scanAfter(vr, descending, after, func(item rangeItem) bool {
+				batch = append(batch, item)
+				return len(batch) < rangeBatchSize
+			})
+			done = len(batch) < rangeBatchSize
+		}
+
+		if len(batch) == 0 {
+			return rangeItem{}, false
+		}
+
+		item := batch[0]
+		batch = batch[1:]
+		after = &item
+		return item, true
+	}
+}
+
+// scanRanges calls fn with the uids having a value in the range in any of
+// the range indexes, ordered by the value and then uid, until fn returns
+// false. The indexes are merged as they are read.
+func scanRanges(indexes []*rangeIndex, r Range, descending bool, fn func(uid string) bool) {
+	vr := r.compile()
+	cursors := make([]func() (rangeItem, bool), len(indexes))
+	heads := make([]rangeItem, len(indexes))
+	found := make([]bool, len(indexes))
+
+	for i, idx := range indexes {
+		cursors[i] = idx.cursor(vr, descending)
+		heads[i], found[i] = cursors[i]()
+	}
+
+	for {
+		next := -1
+		for i := range heads {
+			if !found[i] {
+				continue
+			}
+
+			if next < 0 || descending && heads[next].Less(heads[i]) || !descending && heads[i].Less(heads[next]) {
+				next = i
+			}
+		}
+
+		if next < 0 || !fn(heads[next].uid) {
+			return
+		}
+
+		heads[next], found[next] = cursors[next]()
+	}
+}
+
+// IndexType is the kind of property index.
+type IndexType int
+
+const (
+	// HASH is a index for looking up exact property values.
+	HASH IndexType = iota
+	// RANGE is a ordered index for range, prefix and ordered lookups.
+	RANGE
+)
+
+// IndexDef is a property index definition on nodes with a label.
+type IndexDef struct {
+	Label    string    `json:"label"`
+	Property string    `json:"property"`
+	Type     IndexType `json:"type,omitempty"`
+}
+
+// String returns the index in the Cypher notation.
+func (def IndexDef) String() string {
+	if def.Type == RANGE {
+		return fmt.Sprintf("RANGE :%s(%s)", def.Label, def.Property)
+	}
+	return fmt.Sprintf(":%s(%s)", def.Label, def.Property)
+}
@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodesBy__label_index_updated(t *testing.T) {
+	g := New()
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
+
+	n1.Labels = []string{"pet"}
+	n1, _ = g.UpdateNode(n1)
+	g.RemoveNode(n2.UID)
+
+	assert.Equal(t, 0, g.NodesBy([]string{"person"}, ANY, nil).Size())
+
+	iter := g.NodesBy([]string{"pet"}, ANY, nil)
+	assert.Equal(t, 1, iter.Size())
+	assert.Equal(t, n1, iter.Value().(Node))
+}
+
+func TestEdgesBy__label_index_updated(t *testing.T) {
+	g := New()
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
+	e1, _ := g.AddEdge("edge-1", n1.UID, "knows", n2.UID)
+	e2, _ := g.AddEdge("edge-2", n1.UID, "knows", n2.UID)
+
+	e1.Label = "likes"
+	e1, _ = g.UpdateEdge(e1)
+	g.RemoveEdge(e2.UID)
+
+	assert.Equal(t, 0, g.EdgesBy("", []string{"knows"}, "", nil).Size())
+
+	iter := g.EdgesBy("", []string{"likes"}, "", nil)
+	assert.Equal(t, 1, iter.Size())
+	assert.Equal(t, e1, iter.Value().(Edge))
+}
+
+func TestRangeIndex_scan(t *testing.T) {
+	idx := newRangeIndex()
+	idx.add([]byte("30"), "node-3")
+	idx.add([]byte("10"), "node-1")
+	idx.add([]byte("20"), "node-2")
+	idx.add([]byte("20"), "node-4")
+	idx.add([]byte("foo"), "node-5")
+	idx.add([]byte("food"), "node-6")
+	idx.add([]byte("bar"), "node-7")
+
+	scan := func(r Range, descending bool, limit int) []string {
+		uids := []string{}
+		idx.scanAfter(r.compile(), descending, nil, func(item rangeItem) bool {
+			uids = append(uids, item.uid)
+			return limit == 0 || len(uids) < limit
+		})
+		return uids
+	}
+
+	assert.Equal(t, []string{"node-1", "node-2", "node-4", "node-3", "node-7", "node-5", "node-6"}, scan(Range{}, false, 0))
+	assert.Equal(t, []string{"node-2", "node-4", "node-3"}, scan(Range{Min: []byte("15")}, false, 0))
+	assert.Equal(t, []string{"node-1", "node-2", "node-4"}, scan(Range{Max: []byte("30"), MaxExclusive: true}, false, 0))
+	assert.Equal(t, []string{"node-3", "node-4"}, scan(Range{Max: []byte("30")}, true, 2))
+	assert.Equal(t, []string{"node-4", "node-2"}, scan(Range{Min: []byte("10"), MinExclusive: true, Max: []byte("20")}, true, 0))
+	assert.Equal(t, []string{"node-5", "node-6"}, scan(PrefixRange([]byte("foo")), false, 0))
+	assert.Equal(t, []string{"node-6", "node-5"}, scan(PrefixRange([]byte("foo")), true, 0))
+
+	idx.remove([]byte("20"), "node-2")
+	assert.Equal(t, []string{"node-4"}, scan(Range{Min: []byte("20"), Max: []byte("20")}, false, 0))
+}
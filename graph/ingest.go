@@ -0,0 +1,247 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultIngestChunkSize is the number of records applied at a time when
+// no chunk size is given.
+const DefaultIngestChunkSize = 1000
+
+// IngestFailure is a record which failed to be ingested.
+type IngestFailure struct {
+	UID    string `json:"uid"`
+	Reason string `json:"reason"`
+}
+
+// IngestSummary summarises the records ingested. Records with the uid of
+// a existing node or edge are skipped.
+type IngestSummary struct {
+	NodesCreated int             `json:"nodes_created"`
+	EdgesCreated int             `json:"edges_created"`
+	Skipped      int             `json:"skipped"`
+	Failed       []IngestFailure `json:"failed,omitempty"`
+}
+
+// ingestRecord is a node or edge waiting to be ingested.
+type ingestRecord struct {
+	node *Node
+	edge *Edge
+}
+
+// Ingester loads nodes and edges into the graph in chunks, holding the
+// write lock once per chunk instead of once per record. Unlike a
+// transaction, records which fail are reported and the others are still
+// applied. Edges referencing nodes which have not been ingested yet are
+// held back until the nodes arrive or the ingester is closed. If a chunk
+// can not be logged to the write-ahead log or committed to the storage,
+// its records are reported as failed and the ingester stops, see Err.
+type Ingester struct {
+	graph     *Graph
+	chunkSize int
+	records   []ingestRecord
+	// pending holds back the edges by the uid of the node they are
+	// waiting on, so only those edges are retried when the node arrives.
+	pending map[string][]Edge
+	summary IngestSummary
+	err     error
+}
+
+// NewIngester returns a new ingester applying chunks of chunkSize records.
+// If chunkSize is zero or less, DefaultIngestChunkSize is used.
+func (g *Graph) NewIngester(chunkSize int) *Ingester {
+	if chunkSize <= 0 {
+		chunkSize = DefaultIngestChunkSize
+	}
+
+	return &Ingester{
+		graph:     g,
+		chunkSize: chunkSize,
+		records:   make([]ingestRecord, 0, chunkSize),
+		pending:   make(map[string][]Edge),
+	}
+}
+
+// AddNode queues the node to be ingested.
+func (i *Ingester) AddNode(node Node) {
+	node.UID = i.graph.ensureUID(node.UID)
+	i.add(ingestRecord{node: &node})
+}
+
+// AddEdge queues the edge to be ingested.
+func (i *Ingester) AddEdge(edge Edge) {
+	edge.UID = i.graph.ensureUID(edge.UID)
+	i.add(ingestRecord{edge: &edge})
+}
+
+// add queues the record and applies the chunk if it is full.
+func (i *Ingester) add(record ingestRecord) {
+	i.records = append(i.records, record)
+	if len(i.records) >= i.chunkSize {
+		i.Flush()
+	}
+}
+
+// Flush applies the queued records. Edges held back waiting on a node are
+// retried when the node is ingested.
+func (i *Ingester) Flush() {
+	uids := make([]string, len(i.records))
+	for n, record := range i.records {
+		if record.node != nil {
+			uids[n] = record.node.UID
+		} else {
+			uids[n] = record.edge.UID
+		}
+	}
+
+	i.apply(uids, func() {
+		for _, record := range i.records {
+			if record.node != nil {
+				i.ingestNode(*record.node)
+				continue
+			}
+			i.ingestEdge(*record.edge, true)
+		}
+	})
+	i.records = i.records[:0]
+}
+
+// Close applies all the queued records and returns the summary. Edges
+// still missing their source or target node are reported as failed.
+func (i *Ingester) Close() IngestSummary {
+	i.Flush()
+
+	// The edges are retried as the nodes may have been added to the graph
+	// other than by the ingester.
+	missing := make([]string, 0, len(i.pending))
+	for uid := range i.pending {
+		missing = append(missing, uid)
+	}
+	sort.Strings(missing)
+
+	edges := []Edge{}
+	for _, uid := range missing {
+		edges = append(edges, i.pending[uid]...)
+	}
+
+	uids := make([]string, len(edges))
+	for n, edge := range edges {
+		uids[n] = edge.UID
+	}
+
+	i.apply(uids, func() {
+		for _, edge := range edges {
+			i.ingestEdge(edge, false)
+		}
+	})
+	i.pending = make(map[string][]Edge)
+
+	return i.summary
+}
+
+// apply runs fn holding the write lock to ingest the records with the
+// uids. If the changes can not be logged or committed they are undone, so
+// the summary and the held back edges are put back as they were, the
+// records are reported as failed and the ingester stops.
+func (i *Ingester) apply(uids []string, fn func()) {
+	if i.err != nil {
+		for _, uid := range uids {
+			i.fail(uid, i.err)
+		}
+		return
+	}
+
+	summary := i.summary
+	summary.Failed = append([]IngestFailure(nil), i.summary.Failed...)
+	pending := make(map[string][]Edge, len(i.pending))
+	for uid, edges := range i.pending {
+		pending[uid] = edges
+	}
+
+	w := i.graph.exclusive()
+	fn()
+	if err := w.unlock(); err != nil {
+		i.summary = summary
+		i.pending = pending
+		i.err = fmt.Errorf("[Ingest] %w", err)
+		for _, uid := range uids {
+			i.fail(uid, i.err)
+		}
+	}
+}
+
+// Err returns the error which stopped the ingester, if any.
+func (i *Ingester) Err() error {
+	return i.err
+}
+
+// Abort drops the queued records and the edges held back without applying
+// them, and returns the summary of the records already applied.
+func (i *Ingester) Abort() IngestSummary {
+	i.records = i.records[:0]
+	i.pending = make(map[string][]Edge)
+	return i.summary
+}
+
+// fail records a failed record.
+func (i *Ingester) fail(uid string, err error) {
+	i.summary.Failed = append(i.summary.Failed, IngestFailure{UID: uid, Reason: err.Error()})
+}
+
+// ingestNode adds the node unless it already exists.
+// The caller is expected to be holding the write lock.
+func (i *Ingester) ingestNode(node Node) {
+	if _, ok := i.graph.node(node.UID); ok {
+		i.summary.Skipped++
+		return
+	}
+
+	if _, err := i.graph.addNode(node.UID, node.Labels, convertPropertiesToKV(node.Properties)...); err != nil {
+		i.fail(node.UID, err)
+		return
+	}
+
+	i.summary.NodesCreated++
+
+	held := i.pending[node.UID]
+	delete(i.pending, node.UID)
+	for _, edge := range held {
+		i.ingestEdge(edge, true)
+	}
+}
+
+// ingestEdge adds the edge unless it already exists. If hold is true and
+// the source or target node is missing, the edge is held back until the
+// missing node is ingested.
+// The caller is expected to be holding the write lock.
+func (i *Ingester) ingestEdge(edge Edge, hold bool) {
+	if _, ok := i.graph.edge(edge.UID); ok {
+		i.summary.Skipped++
+		return
+	}
+
+	_, sourceOK := i.graph.node(edge.SourceUID)
+	_, targetOK := i.graph.node(edge.TargetUID)
+	if !sourceOK || !targetOK {
+		missing := edge.SourceUID
+		if sourceOK {
+			missing = edge.TargetUID
+		}
+
+		if hold {
+			i.pending[missing] = append(i.pending[missing], edge)
+			return
+		}
+
+		i.fail(edge.UID, fmt.Errorf("[Ingest] No such node with UID %s", missing))
+		return
+	}
+
+	if _, err := i.graph.addEdge(edge.UID, edge.SourceUID, edge.Label, edge.TargetUID, convertPropertiesToKV(edge.Properties)...); err != nil {
+		i.fail(edge.UID, err)
+		return
+	}
+
+	i.summary.EdgesCreated++
+}
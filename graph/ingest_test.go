@@ -0,0 +1,112 @@
+package graph
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIngester(t *testing.T) {
+	g := New()
+	g.CreateConstraint(Constraint{Type: EXISTS, Item: NODE, Label: "person", Property: "name"})
+	g.AddNode("node-foo", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+
+	ingester := g.NewIngester(2)
+
+	// The edge references a node later in the stream.
+	ingester.AddEdge(NewEdge("edge-1", "node-foo", "knows", "node-bar"))
+	ingester.AddNode(NewNode("node-foo", []string{"person"}, KV{Key: "name", Value: []byte("foo")}))
+	ingester.AddNode(NewNode("node-baz", []string{"person"}))
+	ingester.AddEdge(NewEdge("edge-2", "node-foo", "knows", "node-missing"))
+	ingester.AddNode(NewNode("node-bar", []string{"person"}, KV{Key: "name", Value: []byte("bar")}))
+
+	// Nothing is applied until the chunk is full.
+	assert.False(t, g.HasNode("node-bar"))
+	ingester.AddEdge(NewEdge("edge-1", "node-foo", "knows", "node-bar"))
+
+	summary := ingester.Close()
+	assert.Equal(t, 1, summary.NodesCreated)
+	assert.Equal(t, 1, summary.EdgesCreated)
+	assert.Equal(t, 2, summary.Skipped)
+	assert.Equal(t, 2, len(summary.Failed))
+	assert.Equal(t, "node-baz", summary.Failed[0].UID)
+	assert.Equal(t, "edge-2", summary.Failed[1].UID)
+
+	assert.True(t, g.HasNode("node-bar"))
+	assert.True(t, g.HasEdge("edge-1"))
+	assert.False(t, g.HasEdge("edge-2"))
+}
+
+func TestIngester_pending(t *testing.T) {
+	g := New()
+	ingester := g.NewIngester(1)
+
+	// The edge waits on the source and then on the target.
+	ingester.AddEdge(NewEdge("edge-1", "node-foo", "knows", "node-bar"))
+	ingester.AddNode(NewNode("node-foo", []string{"person"}))
+	assert.False(t, g.HasEdge("edge-1"))
+	assert.Len(t, ingester.pending["node-bar"], 1)
+
+	ingester.AddNode(NewNode("node-bar", []string{"person"}))
+	assert.True(t, g.HasEdge("edge-1"))
+	assert.Empty(t, ingester.pending)
+
+	summary := ingester.Close()
+	assert.Equal(t, 2, summary.NodesCreated)
+	assert.Equal(t, 1, summary.EdgesCreated)
+	assert.Empty(t, summary.Failed)
+}
+
+func TestIngester_Abort(t *testing.T) {
+	g := New()
+	ingester := g.NewIngester(2)
+
+	ingester.AddNode(NewNode("node-foo", []string{"person"}))
+	ingester.AddEdge(NewEdge("edge-1", "node-foo", "knows", "node-bar"))
+	ingester.AddNode(NewNode("node-baz", []string{"person"}))
+
+	// Only the records of the full chunk were applied.
+	summary := ingester.Abort()
+	assert.Equal(t, 1, summary.NodesCreated)
+	assert.Equal(t, 0, summary.EdgesCreated)
+	assert.Empty(t, summary.Failed)
+
+	assert.Equal(t, 1, g.NodeCount())
+	assert.False(t, g.HasNode("node-baz"))
+	assert.False(t, g.HasEdge("edge-1"))
+}
+
+func TestIngester_closed_wal(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path)
+	ingester := g.NewIngester(2)
+
+	ingester.AddNode(NewNode("node-foo", []string{"person"}))
+	ingester.AddEdge(NewEdge("edge-1", "node-foo", "knows", "node-bar"))
+	assert.Nil(t, ingester.Err())
+
+	// The chunk with the node the edge is waiting on is undone.
+	assert.Nil(t, wal.Close())
+	ingester.AddNode(NewNode("node-bar", []string{"person"}))
+	ingester.AddNode(NewNode("node-baz", []string{"person"}))
+	assert.True(t, errors.Is(ingester.Err(), os.ErrClosed))
+	assert.False(t, g.HasNode("node-bar"))
+
+	ingester.AddNode(NewNode("node-qux", []string{"person"}))
+
+	summary := ingester.Close()
+	assert.Equal(t, 1, summary.NodesCreated)
+	assert.Equal(t, 0, summary.EdgesCreated)
+
+	failed := []string{}
+	for _, failure := range summary.Failed {
+		failed = append(failed, failure.UID)
+	}
+	assert.Equal(t, []string{"node-bar", "node-baz", "node-qux", "edge-1"}, failed)
+	assert.True(t, g.HasNode("node-foo"))
+	assert.False(t, g.HasNode("node-qux"))
+}
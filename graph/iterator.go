@@ -1,13 +1,281 @@
 package graph
 
-// Iterator is an iterator interface for iterating over a set of items.
+import (
+	"github.com/google/btree"
+)
+
+// Iterator is a lazy iterator over a set of items. The items are fetched
+// from a snapshot of the graph as the iterator is advanced, so iterating
+// sees the graph as it was when the iterator was created and does not
+// block changes to the graph.
 type Iterator interface {
-	// Value returns the Item.
+	// Value returns the current item. Before the first call to Next, it
+	// returns the first item.
 	Value() interface{}
 	// Next progresses the iterator and return true if there are still items to iterate over.
 	Next() bool
-	// Size returns the total item count in the iterator
+	// Size returns the total item count in the iterator. The items are
+	// counted without moving the iterator, which visits all the items.
 	Size() int
-	// Channel returns the items in the iterator as a channel.
-	Channel() <-chan interface{}
+	// Close stops the iterator, after which Next returns false.
+	Close() error
+	// Err returns the first error reading the storage of the snapshot,
+	// after which items may be missing.
+	Err() error
+}
+
+// NodeIterator is a lazy iterator over nodes.
+type NodeIterator interface {
+	Iterator
+	// Node returns the current node.
+	Node() Node
+}
+
+// EdgeIterator is a lazy iterator over edges.
+type EdgeIterator interface {
+	Iterator
+	// Edge returns the current edge.
+	Edge() Edge
+}
+
+// itemSource returns the next item and true, or false when there are no
+// more items.
+type itemSource func() (interface{}, bool)
+
+// lazyIterator iterates over the items of a source. As snapshots of a
+// versioned storage never change, a new source can be started from the
+// beginning at any time. Otherwise a new source sees the storage as last
+// committed.
+type lazyIterator struct {
+	start   func() itemSource
+	err     func() error
+	next    itemSource
+	value   interface{}
+	started bool
+	peeked  bool
+	closed  bool
+}
+
+// Value returns the current item.
+func (it *lazyIterator) Value() interface{} {
+	if !it.started {
+		it.Next()
+		it.peeked = true
+	}
+	return it.value
+}
+
+// Next progresses the iterator and return true if there are still items to iterate over.
+func (it *lazyIterator) Next() bool {
+	if it.peeked {
+		it.peeked = false
+		return it.value != nil
+	}
+
+	if it.closed {
+		return false
+	}
+
+	if !it.started {
+		it.started = true
+		it.next = it.start()
+	}
+
+	value, ok := it.next()
+	if !ok {
+		it.Close()
+		return false
+	}
+
+	it.value = value
+	return true
+}
+
+// Size returns the total item count in the iterator.
+func (it *lazyIterator) Size() int {
+	count := 0
+	next := it.start()
+	for _, ok := next(); ok; _, ok = next() {
+		count++
+	}
+	return count
+}
+
+// Close stops the iterator.
+func (it *lazyIterator) Close() error {
+	it.started = true
+	it.closed = true
+	it.next = nil
+	return nil
+}
+
+// Err returns the first error reading the storage of the snapshot.
+func (it *lazyIterator) Err() error {
+	return it.err()
+}
+
+// nodeIterator is a lazy iterator over nodes.
+type nodeIterator struct {
+	lazyIterator
+}
+
+// newNodeIterator returns a node iterator over the nodes of the source
+// started by start, with the errors reading the snapshot returned by err.
+func newNodeIterator(start func() itemSource, err func() error) *nodeIterator {
+	return &nodeIterator{lazyIterator{start: start, err: err}}
+}
+
+// Node returns the current node.
+func (it *nodeIterator) Node() Node {
+	node, _ := it.Value().(Node)
+	return node
+}
+
+// edgeIterator is a lazy iterator over edges.
+type edgeIterator struct {
+	lazyIterator
+}
+
+// newEdgeIterator returns a edge iterator over the edges of the source
+// started by start, with the errors reading the snapshot returned by err.
+func newEdgeIterator(start func() itemSource, err func() error) *edgeIterator {
+	return &edgeIterator{lazyIterator{start: start, err: err}}
+}
+
+// Edge returns the current edge.
+func (it *edgeIterator) Edge() Edge {
+	edge, _ := it.Value().(Edge)
+	return edge
+}
+
+// sliceSource returns a source over the items.
+func sliceSource(items []interface{}) itemSource {
+	return func() (interface{}, bool) {
+		if len(items) == 0 {
+			return nil, false
+		}
+
+		item := items[0]
+		items = items[1:]
+		return item, true
+	}
+}
+
+// cursorBatchSize is the number of items a cursor fetches at a time.
+const cursorBatchSize = 64
+
+// treeCursor walks a snapshot btree in order starting from a item. The
+// items are fetched in batches, continuing after the last item fetched,
+// so the walk does not need to hold on to the tree between batches.
+type treeCursor struct {
+	tree  *btree.BTree
+	from  btree.Item
+	after bool
+	// end returns true for the first item past the end of the walk.
+	end   func(btree.Item) bool
+	batch []btree.Item
+	done  bool
+}
+
+// newTreeCursor returns a cursor walking the tree from the item until end
+// returns true. If after is true, the walk starts after the item rather
+// than at it. If end is nil, the rest of the tree is walked.
+func newTreeCursor(tree *btree.BTree, from btree.Item, after bool, end func(btree.Item) bool) *treeCursor {
+	return &treeCursor{tree: tree, from: from, after: after, end: end}
+}
+
+// next returns the next item and true, or false at the end of the walk.
+func (c *treeCursor) next() (btree.Item, bool) {
+	if len(c.batch) == 0 && !c.done {
+		c.fill()
+	}
+
+	if len(c.batch) == 0 {
+		return nil, false
+	}
+
+	item := c.batch[0]
+	c.batch = c.batch[1:]
+	return item, true
+}
+
+// fill fetches the next batch of items.
+func (c *treeCursor) fill() {
+	c.batch = make([]btree.Item, 0, cursorBatchSize)
+	c.tree.AscendGreaterOrEqual(c.from, func(i btree.Item) bool {
+		// Skip the last item of the previous batch.
+		if c.after && !c.from.Less(i) {
+			return true
+		}
+
+		if c.end != nil && c.end(i) {
+			c.done = true
+			return false
+		}
+
+		c.batch = append(c.batch, i)
+		return len(c.batch) < cursorBatchSize
+	})
+
+	if len(c.batch) < cursorBatchSize {
+		c.done = true
+		return
+	}
+
+	c.from, c.after = c.batch[len(c.batch)-1], true
+}
+
+// uidSource returns the next uid and true, or false when there are no
+// more uids. The uids are returned in order.
+type uidSource func() (string, bool)
+
+// keySource returns the uids having the key in a key tree, starting after
+// the uid after if it is not empty.
+func keySource(tree *btree.BTree, key, after string) uidSource {
+	cursor := newTreeCursor(tree, keyItem{key: key, uid: after}, after != "", func(i btree.Item) bool {
+		return i.(keyItem).key != key
+	})
+
+	return func() (string, bool) {
+		item, ok := cursor.next()
+		if !ok {
+			return "", false
+		}
+		return item.(keyItem).uid, true
+	}
+}
+
+// mergeSources returns the uids of all the sources in order, without
+// duplicates.
+func mergeSources(sources ...uidSource) uidSource {
+	if len(sources) == 1 {
+		return sources[0]
+	}
+
+	heads := make([]string, len(sources))
+	ok := make([]bool, len(sources))
+	for i, source := range sources {
+		heads[i], ok[i] = source()
+	}
+
+	return func() (string, bool) {
+		min, found := "", false
+		for i := range sources {
+			if ok[i] && (!found || heads[i] < min) {
+				min, found = heads[i], true
+			}
+		}
+
+		if !found {
+			return "", false
+		}
+
+		for i, source := range sources {
+			if ok[i] && heads[i] == min {
+				heads[i], ok[i] = source()
+			}
+		}
+
+		return min, true
+	}
 }
@@ -1,25 +1,84 @@
 package graph
 
+import "encoding/json"
+
 // NewNode returns a new node instance.
-func NewNode(uid, label string, kv ...KV) Node {
+// Duplicate labels are dropped keeping the first occurrence order.
+func NewNode(uid string, labels []string, kv ...KV) Node {
 	return Node{
 		UID:        uid,
-		Label:      label,
+		Labels:     uniqueLabels(labels),
 		Properties: NewProperties(kv...),
 		inEdges:    make(map[string]struct{}),
 		outEdges:   make(map[string]struct{}),
 	}
 }
 
-// Node is a node in the graph.
+// Node is a node in the graph. It is a copy of the node as it was when
+// read, so its edges do not change as edges are added or removed.
+// Version is increased every time the node is changed. When updating a
+// node, a non zero version must be the current version of the node.
 type Node struct {
 	UID        string            `json:"uid"`
-	Label      string            `json:"label"`
+	Labels     []string          `json:"labels"`
 	Properties map[string][]byte `json:"properties"`
+	Version    uint64            `json:"version,omitempty"`
 	inEdges    map[string]struct{}
 	outEdges   map[string]struct{}
 }
 
+// UnmarshalJSON unmarshals the node. The single "label" of dumps made
+// before nodes had multiple labels is read as the first label.
+func (n *Node) UnmarshalJSON(b []byte) error {
+	type node Node
+	legacy := struct {
+		*node
+		Label string `json:"label"`
+	}{node: (*node)(n)}
+
+	if err := json.Unmarshal(b, &legacy); err != nil {
+		return err
+	}
+
+	if legacy.Label != "" {
+		n.Labels = uniqueLabels(append([]string{legacy.Label}, n.Labels...))
+	}
+
+	return nil
+}
+
+// HasLabel returns true if the node has the given label.
+func (n Node) HasLabel(label string) bool {
+	for _, l := range n.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// HasLabels returns true if the node matches the given labels using
+// the provided match. An empty list of labels always matches.
+func (n Node) HasLabels(labels []string, match LabelMatch) bool {
+	if len(labels) == 0 {
+		return true
+	}
+
+	for _, label := range labels {
+		has := n.HasLabel(label)
+
+		if has && match == ANY {
+			return true
+		}
+
+		if !has && match == ALL {
+			return false
+		}
+	}
+
+	return match == ALL
+}
+
 // InEdges returns all the inbound edges.
 // ()-->(n)
 func (n Node) InEdges() []string {
@@ -18,104 +18,2158 @@ var g = &grammar{
 	rules: []*rule{
 		{
 			name: "Statement",
-			pos:  position{line: 6, col: 1, offset: 84},
+			pos:  position{line: 6, col: 1, offset: 79},
+			expr: &choiceExpr{
+				pos: position{line: 6, col: 14, offset: 92},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 6, col: 14, offset: 92},
+						run: (*parser).callonStatement2,
+						expr: &seqExpr{
+							pos: position{line: 6, col: 14, offset: 92},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 6, col: 14, offset: 92},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 6, col: 16, offset: 94},
+									label: "command",
+									expr: &ruleRefExpr{
+										pos:  position{line: 6, col: 24, offset: 102},
+										name: "IndexCommand",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 6, col: 37, offset: 115},
+									name: "_",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 6, col: 39, offset: 117},
+									name: "EOF",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 9, col: 5, offset: 204},
+						run: (*parser).callonStatement9,
+						expr: &seqExpr{
+							pos: position{line: 9, col: 5, offset: 204},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 9, col: 5, offset: 204},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 9, col: 7, offset: 206},
+									label: "command",
+									expr: &ruleRefExpr{
+										pos:  position{line: 9, col: 15, offset: 214},
+										name: "ConstraintCommand",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 9, col: 33, offset: 232},
+									name: "_",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 9, col: 35, offset: 234},
+									name: "EOF",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 12, col: 5, offset: 341},
+						run: (*parser).callonStatement16,
+						expr: &seqExpr{
+							pos: position{line: 12, col: 5, offset: 341},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 12, col: 5, offset: 341},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 12, col: 7, offset: 343},
+									label: "call",
+									expr: &ruleRefExpr{
+										pos:  position{line: 12, col: 12, offset: 348},
+										name: "Call",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 12, col: 17, offset: 353},
+									name: "_",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 12, col: 19, offset: 355},
+									name: "EOF",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 15, col: 5, offset: 447},
+						run: (*parser).callonStatement23,
+						expr: &seqExpr{
+							pos: position{line: 15, col: 5, offset: 447},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 15, col: 5, offset: 447},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 15, col: 7, offset: 449},
+									label: "query",
+									expr: &ruleRefExpr{
+										pos:  position{line: 15, col: 13, offset: 455},
+										name: "Query",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 15, col: 19, offset: 461},
+									name: "_",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 15, col: 21, offset: 463},
+									name: "EOF",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "IndexCommand",
+			pos:  position{line: 23, col: 1, offset: 580},
+			expr: &choiceExpr{
+				pos: position{line: 23, col: 17, offset: 596},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 23, col: 17, offset: 596},
+						name: "CreateIndex",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 23, col: 31, offset: 610},
+						name: "DropIndex",
+					},
+				},
+			},
+		},
+		{
+			name: "CreateIndex",
+			pos:  position{line: 25, col: 1, offset: 621},
+			expr: &actionExpr{
+				pos: position{line: 25, col: 16, offset: 636},
+				run: (*parser).callonCreateIndex1,
+				expr: &seqExpr{
+					pos: position{line: 25, col: 16, offset: 636},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 16, offset: 636},
+							name: "C",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 18, offset: 638},
+							name: "R",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 20, offset: 640},
+							name: "E",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 22, offset: 642},
+							name: "A",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 24, offset: 644},
+							name: "T",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 26, offset: 646},
+							name: "E",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 28, offset: 648},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 25, col: 30, offset: 650},
+							label: "kind",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 25, col: 35, offset: 655},
+								expr: &seqExpr{
+									pos: position{line: 25, col: 36, offset: 656},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 25, col: 36, offset: 656},
+											name: "R",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 25, col: 38, offset: 658},
+											name: "A",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 25, col: 40, offset: 660},
+											name: "N",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 25, col: 42, offset: 662},
+											name: "G",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 25, col: 44, offset: 664},
+											name: "E",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 25, col: 46, offset: 666},
+											name: "_",
+										},
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 50, offset: 670},
+							name: "I",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 52, offset: 672},
+							name: "N",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 54, offset: 674},
+							name: "D",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 56, offset: 676},
+							name: "E",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 58, offset: 678},
+							name: "X",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 60, offset: 680},
+							name: "_",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 62, offset: 682},
+							name: "O",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 64, offset: 684},
+							name: "N",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 66, offset: 686},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 25, col: 68, offset: 688},
+							val:        ":",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 72, offset: 692},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 25, col: 74, offset: 694},
+							label: "label",
+							expr: &ruleRefExpr{
+								pos:  position{line: 25, col: 80, offset: 700},
+								name: "String",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 87, offset: 707},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 25, col: 89, offset: 709},
+							val:        "(",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 93, offset: 713},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 25, col: 95, offset: 715},
+							label: "property",
+							expr: &ruleRefExpr{
+								pos:  position{line: 25, col: 104, offset: 724},
+								name: "String",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 25, col: 111, offset: 731},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 25, col: 113, offset: 733},
+							val:        ")",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "DropIndex",
+			pos:  position{line: 29, col: 1, offset: 843},
+			expr: &actionExpr{
+				pos: position{line: 29, col: 14, offset: 856},
+				run: (*parser).callonDropIndex1,
+				expr: &seqExpr{
+					pos: position{line: 29, col: 14, offset: 856},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 14, offset: 856},
+							name: "D",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 16, offset: 858},
+							name: "R",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 18, offset: 860},
+							name: "O",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 20, offset: 862},
+							name: "P",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 22, offset: 864},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 29, col: 24, offset: 866},
+							label: "kind",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 29, col: 29, offset: 871},
+								expr: &seqExpr{
+									pos: position{line: 29, col: 30, offset: 872},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 29, col: 30, offset: 872},
+											name: "R",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 29, col: 32, offset: 874},
+											name: "A",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 29, col: 34, offset: 876},
+											name: "N",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 29, col: 36, offset: 878},
+											name: "G",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 29, col: 38, offset: 880},
+											name: "E",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 29, col: 40, offset: 882},
+											name: "_",
+										},
+									},
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 44, offset: 886},
+							name: "I",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 46, offset: 888},
+							name: "N",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 48, offset: 890},
+							name: "D",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 50, offset: 892},
+							name: "E",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 52, offset: 894},
+							name: "X",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 54, offset: 896},
+							name: "_",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 56, offset: 898},
+							name: "O",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 58, offset: 900},
+							name: "N",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 60, offset: 902},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 29, col: 62, offset: 904},
+							val:        ":",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 66, offset: 908},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 29, col: 68, offset: 910},
+							label: "label",
+							expr: &ruleRefExpr{
+								pos:  position{line: 29, col: 74, offset: 916},
+								name: "String",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 81, offset: 923},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 29, col: 83, offset: 925},
+							val:        "(",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 87, offset: 929},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 29, col: 89, offset: 931},
+							label: "property",
+							expr: &ruleRefExpr{
+								pos:  position{line: 29, col: 98, offset: 940},
+								name: "String",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 29, col: 105, offset: 947},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 29, col: 107, offset: 949},
+							val:        ")",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ConstraintCommand",
+			pos:  position{line: 33, col: 1, offset: 1071},
+			expr: &actionExpr{
+				pos: position{line: 33, col: 22, offset: 1092},
+				run: (*parser).callonConstraintCommand1,
+				expr: &seqExpr{
+					pos: position{line: 33, col: 22, offset: 1092},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 33, col: 22, offset: 1092},
+							label: "drop",
+							expr: &ruleRefExpr{
+								pos:  position{line: 33, col: 27, offset: 1097},
+								name: "ConstraintAction",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 44, offset: 1114},
+							name: "_",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 46, offset: 1116},
+							name: "C",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 48, offset: 1118},
+							name: "O",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 50, offset: 1120},
+							name: "N",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 52, offset: 1122},
+							name: "S",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 54, offset: 1124},
+							name: "T",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 56, offset: 1126},
+							name: "R",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 58, offset: 1128},
+							name: "A",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 60, offset: 1130},
+							name: "I",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 62, offset: 1132},
+							name: "N",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 64, offset: 1134},
+							name: "T",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 66, offset: 1136},
+							name: "_",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 68, offset: 1138},
+							name: "O",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 70, offset: 1140},
+							name: "N",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 72, offset: 1142},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 33, col: 74, offset: 1144},
+							label: "pattern",
+							expr: &ruleRefExpr{
+								pos:  position{line: 33, col: 82, offset: 1152},
+								name: "ConstraintPattern",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 100, offset: 1170},
+							name: "_",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 102, offset: 1172},
+							name: "A",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 104, offset: 1174},
+							name: "S",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 106, offset: 1176},
+							name: "S",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 108, offset: 1178},
+							name: "E",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 110, offset: 1180},
+							name: "R",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 112, offset: 1182},
+							name: "T",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 33, col: 114, offset: 1184},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 33, col: 116, offset: 1186},
+							label: "assertion",
+							expr: &ruleRefExpr{
+								pos:  position{line: 33, col: 126, offset: 1196},
+								name: "Assertion",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ConstraintAction",
+			pos:  position{line: 47, col: 1, offset: 1552},
+			expr: &choiceExpr{
+				pos: position{line: 47, col: 21, offset: 1572},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 47, col: 21, offset: 1572},
+						run: (*parser).callonConstraintAction2,
+						expr: &seqExpr{
+							pos: position{line: 47, col: 21, offset: 1572},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 47, col: 21, offset: 1572},
+									name: "C",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 47, col: 23, offset: 1574},
+									name: "R",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 47, col: 25, offset: 1576},
+									name: "E",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 47, col: 27, offset: 1578},
+									name: "A",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 47, col: 29, offset: 1580},
+									name: "T",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 47, col: 31, offset: 1582},
+									name: "E",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 47, col: 57, offset: 1608},
+						run: (*parser).callonConstraintAction10,
+						expr: &seqExpr{
+							pos: position{line: 47, col: 57, offset: 1608},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 47, col: 57, offset: 1608},
+									name: "D",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 47, col: 59, offset: 1610},
+									name: "R",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 47, col: 61, offset: 1612},
+									name: "O",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 47, col: 63, offset: 1614},
+									name: "P",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ConstraintPattern",
+			pos:  position{line: 49, col: 1, offset: 1638},
+			expr: &choiceExpr{
+				pos: position{line: 49, col: 22, offset: 1659},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 49, col: 22, offset: 1659},
+						run: (*parser).callonConstraintPattern2,
+						expr: &seqExpr{
+							pos: position{line: 49, col: 22, offset: 1659},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 49, col: 22, offset: 1659},
+									val:        "(",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 49, col: 26, offset: 1663},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 49, col: 28, offset: 1665},
+									label: "variable",
+									expr: &ruleRefExpr{
+										pos:  position{line: 49, col: 37, offset: 1674},
+										name: "Variable",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 49, col: 46, offset: 1683},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 49, col: 48, offset: 1685},
+									val:        ":",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 49, col: 52, offset: 1689},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 49, col: 54, offset: 1691},
+									label: "label",
+									expr: &ruleRefExpr{
+										pos:  position{line: 49, col: 60, offset: 1697},
+										name: "String",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 49, col: 67, offset: 1704},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 49, col: 69, offset: 1706},
+									val:        ")",
+									ignoreCase: false,
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 51, col: 5, offset: 1802},
+						run: (*parser).callonConstraintPattern15,
+						expr: &seqExpr{
+							pos: position{line: 51, col: 5, offset: 1802},
+							exprs: []interface{}{
+								&litMatcher{
+									pos:        position{line: 51, col: 5, offset: 1802},
+									val:        "(",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 51, col: 9, offset: 1806},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 51, col: 11, offset: 1808},
+									val:        ")",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 51, col: 15, offset: 1812},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 51, col: 17, offset: 1814},
+									val:        "-",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 51, col: 21, offset: 1818},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 51, col: 23, offset: 1820},
+									val:        "[",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 51, col: 27, offset: 1824},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 51, col: 29, offset: 1826},
+									label: "variable",
+									expr: &ruleRefExpr{
+										pos:  position{line: 51, col: 38, offset: 1835},
+										name: "Variable",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 51, col: 47, offset: 1844},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 51, col: 49, offset: 1846},
+									val:        ":",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 51, col: 53, offset: 1850},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 51, col: 55, offset: 1852},
+									label: "label",
+									expr: &ruleRefExpr{
+										pos:  position{line: 51, col: 61, offset: 1858},
+										name: "String",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 51, col: 68, offset: 1865},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 51, col: 70, offset: 1867},
+									val:        "]",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 51, col: 74, offset: 1871},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 51, col: 76, offset: 1873},
+									val:        "-",
+									ignoreCase: false,
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 51, col: 80, offset: 1877},
+									expr: &litMatcher{
+										pos:        position{line: 51, col: 80, offset: 1877},
+										val:        ">",
+										ignoreCase: false,
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 51, col: 85, offset: 1882},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 51, col: 87, offset: 1884},
+									val:        "(",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 51, col: 91, offset: 1888},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 51, col: 93, offset: 1890},
+									val:        ")",
+									ignoreCase: false,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Assertion",
+			pos:  position{line: 55, col: 1, offset: 1997},
+			expr: &choiceExpr{
+				pos: position{line: 55, col: 14, offset: 2010},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 55, col: 14, offset: 2010},
+						run: (*parser).callonAssertion2,
+						expr: &seqExpr{
+							pos: position{line: 55, col: 14, offset: 2010},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 55, col: 14, offset: 2010},
+									name: "E",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 55, col: 16, offset: 2012},
+									name: "X",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 55, col: 18, offset: 2014},
+									name: "I",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 55, col: 20, offset: 2016},
+									name: "S",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 55, col: 22, offset: 2018},
+									name: "T",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 55, col: 24, offset: 2020},
+									name: "S",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 55, col: 26, offset: 2022},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 55, col: 28, offset: 2024},
+									val:        "(",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 55, col: 32, offset: 2028},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 55, col: 34, offset: 2030},
+									label: "variable",
+									expr: &ruleRefExpr{
+										pos:  position{line: 55, col: 43, offset: 2039},
+										name: "Variable",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 55, col: 52, offset: 2048},
+									val:        ".",
+									ignoreCase: false,
+								},
+								&labeledExpr{
+									pos:   position{line: 55, col: 56, offset: 2052},
+									label: "property",
+									expr: &ruleRefExpr{
+										pos:  position{line: 55, col: 65, offset: 2061},
+										name: "String",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 55, col: 72, offset: 2068},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 55, col: 74, offset: 2070},
+									val:        ")",
+									ignoreCase: false,
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 57, col: 5, offset: 2188},
+						run: (*parser).callonAssertion20,
+						expr: &seqExpr{
+							pos: position{line: 57, col: 5, offset: 2188},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 57, col: 5, offset: 2188},
+									label: "variable",
+									expr: &ruleRefExpr{
+										pos:  position{line: 57, col: 14, offset: 2197},
+										name: "Variable",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 57, col: 23, offset: 2206},
+									val:        ".",
+									ignoreCase: false,
+								},
+								&labeledExpr{
+									pos:   position{line: 57, col: 27, offset: 2210},
+									label: "property",
+									expr: &ruleRefExpr{
+										pos:  position{line: 57, col: 36, offset: 2219},
+										name: "String",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 57, col: 43, offset: 2226},
+									name: "_",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 57, col: 45, offset: 2228},
+									name: "I",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 57, col: 47, offset: 2230},
+									name: "S",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 57, col: 49, offset: 2232},
+									name: "_",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 57, col: 51, offset: 2234},
+									name: "U",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 57, col: 53, offset: 2236},
+									name: "N",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 57, col: 55, offset: 2238},
+									name: "I",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 57, col: 57, offset: 2240},
+									name: "Q",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 57, col: 59, offset: 2242},
+									name: "U",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 57, col: 61, offset: 2244},
+									name: "E",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 59, col: 5, offset: 2360},
+						run: (*parser).callonAssertion37,
+						expr: &seqExpr{
+							pos: position{line: 59, col: 5, offset: 2360},
+							exprs: []interface{}{
+								&labeledExpr{
+									pos:   position{line: 59, col: 5, offset: 2360},
+									label: "variable",
+									expr: &ruleRefExpr{
+										pos:  position{line: 59, col: 14, offset: 2369},
+										name: "Variable",
+									},
+								},
+								&litMatcher{
+									pos:        position{line: 59, col: 23, offset: 2378},
+									val:        ".",
+									ignoreCase: false,
+								},
+								&labeledExpr{
+									pos:   position{line: 59, col: 27, offset: 2382},
+									label: "property",
+									expr: &ruleRefExpr{
+										pos:  position{line: 59, col: 36, offset: 2391},
+										name: "String",
+									},
+								},
+								&ruleRefExpr{
+									pos:  position{line: 59, col: 43, offset: 2398},
+									name: "_",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 59, col: 45, offset: 2400},
+									name: "I",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 59, col: 47, offset: 2402},
+									name: "S",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 59, col: 49, offset: 2404},
+									name: "_",
+								},
+								&litMatcher{
+									pos:        position{line: 59, col: 51, offset: 2406},
+									val:        "::",
+									ignoreCase: false,
+								},
+								&ruleRefExpr{
+									pos:  position{line: 59, col: 56, offset: 2411},
+									name: "_",
+								},
+								&labeledExpr{
+									pos:   position{line: 59, col: 58, offset: 2413},
+									label: "valueType",
+									expr: &ruleRefExpr{
+										pos:  position{line: 59, col: 68, offset: 2423},
+										name: "ValueType",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ValueType",
+			pos:  position{line: 63, col: 1, offset: 2576},
+			expr: &choiceExpr{
+				pos: position{line: 63, col: 14, offset: 2589},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 63, col: 14, offset: 2589},
+						run: (*parser).callonValueType2,
+						expr: &seqExpr{
+							pos: position{line: 63, col: 14, offset: 2589},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 14, offset: 2589},
+									name: "S",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 16, offset: 2591},
+									name: "T",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 18, offset: 2593},
+									name: "R",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 20, offset: 2595},
+									name: "I",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 22, offset: 2597},
+									name: "N",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 24, offset: 2599},
+									name: "G",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 63, col: 53, offset: 2628},
+						run: (*parser).callonValueType10,
+						expr: &seqExpr{
+							pos: position{line: 63, col: 53, offset: 2628},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 53, offset: 2628},
+									name: "I",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 55, offset: 2630},
+									name: "N",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 57, offset: 2632},
+									name: "T",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 59, offset: 2634},
+									name: "E",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 61, offset: 2636},
+									name: "G",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 63, offset: 2638},
+									name: "E",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 65, offset: 2640},
+									name: "R",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 63, col: 95, offset: 2670},
+						run: (*parser).callonValueType19,
+						expr: &seqExpr{
+							pos: position{line: 63, col: 95, offset: 2670},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 95, offset: 2670},
+									name: "F",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 97, offset: 2672},
+									name: "L",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 99, offset: 2674},
+									name: "O",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 101, offset: 2676},
+									name: "A",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 103, offset: 2678},
+									name: "T",
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 63, col: 131, offset: 2706},
+						run: (*parser).callonValueType26,
+						expr: &seqExpr{
+							pos: position{line: 63, col: 131, offset: 2706},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 131, offset: 2706},
+									name: "B",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 133, offset: 2708},
+									name: "O",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 135, offset: 2710},
+									name: "O",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 137, offset: 2712},
+									name: "L",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 139, offset: 2714},
+									name: "E",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 141, offset: 2716},
+									name: "A",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 63, col: 143, offset: 2718},
+									name: "N",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Call",
+			pos:  position{line: 65, col: 1, offset: 2747},
+			expr: &actionExpr{
+				pos: position{line: 65, col: 9, offset: 2755},
+				run: (*parser).callonCall1,
+				expr: &seqExpr{
+					pos: position{line: 65, col: 9, offset: 2755},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 65, col: 9, offset: 2755},
+							name: "C",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 65, col: 11, offset: 2757},
+							name: "A",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 65, col: 13, offset: 2759},
+							name: "L",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 65, col: 15, offset: 2761},
+							name: "L",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 65, col: 17, offset: 2763},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 65, col: 19, offset: 2765},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 65, col: 24, offset: 2770},
+								name: "ProcedureName",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 65, col: 38, offset: 2784},
+							name: "_",
+						},
+						&litMatcher{
+							pos:        position{line: 65, col: 40, offset: 2786},
+							val:        "(",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 65, col: 44, offset: 2790},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 65, col: 46, offset: 2792},
+							label: "args",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 65, col: 51, offset: 2797},
+								expr: &seqExpr{
+									pos: position{line: 65, col: 52, offset: 2798},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 52, offset: 2798},
+											name: "Argument",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 61, offset: 2807},
+											name: "_",
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 65, col: 63, offset: 2809},
+											expr: &seqExpr{
+												pos: position{line: 65, col: 64, offset: 2810},
+												exprs: []interface{}{
+													&litMatcher{
+														pos:        position{line: 65, col: 64, offset: 2810},
+														val:        ",",
+														ignoreCase: false,
+													},
+													&ruleRefExpr{
+														pos:  position{line: 65, col: 68, offset: 2814},
+														name: "_",
+													},
+													&ruleRefExpr{
+														pos:  position{line: 65, col: 70, offset: 2816},
+														name: "Argument",
+													},
+													&ruleRefExpr{
+														pos:  position{line: 65, col: 79, offset: 2825},
+														name: "_",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 65, col: 85, offset: 2831},
+							val:        ")",
+							ignoreCase: false,
+						},
+						&labeledExpr{
+							pos:   position{line: 65, col: 89, offset: 2835},
+							label: "yields",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 65, col: 96, offset: 2842},
+								expr: &seqExpr{
+									pos: position{line: 65, col: 97, offset: 2843},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 97, offset: 2843},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 99, offset: 2845},
+											name: "Y",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 101, offset: 2847},
+											name: "I",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 103, offset: 2849},
+											name: "E",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 105, offset: 2851},
+											name: "L",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 107, offset: 2853},
+											name: "D",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 109, offset: 2855},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 111, offset: 2857},
+											name: "Variable",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 65, col: 120, offset: 2866},
+											name: "_",
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 65, col: 122, offset: 2868},
+											expr: &seqExpr{
+												pos: position{line: 65, col: 123, offset: 2869},
+												exprs: []interface{}{
+													&litMatcher{
+														pos:        position{line: 65, col: 123, offset: 2869},
+														val:        ",",
+														ignoreCase: false,
+													},
+													&ruleRefExpr{
+														pos:  position{line: 65, col: 127, offset: 2873},
+														name: "_",
+													},
+													&ruleRefExpr{
+														pos:  position{line: 65, col: 129, offset: 2875},
+														name: "Variable",
+													},
+													&ruleRefExpr{
+														pos:  position{line: 65, col: 138, offset: 2884},
+														name: "_",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ProcedureName",
+			pos:  position{line: 87, col: 1, offset: 3452},
+			expr: &actionExpr{
+				pos: position{line: 87, col: 18, offset: 3469},
+				run: (*parser).callonProcedureName1,
+				expr: &seqExpr{
+					pos: position{line: 87, col: 18, offset: 3469},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 87, col: 18, offset: 3469},
+							name: "String",
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 87, col: 25, offset: 3476},
+							expr: &seqExpr{
+								pos: position{line: 87, col: 26, offset: 3477},
+								exprs: []interface{}{
+									&litMatcher{
+										pos:        position{line: 87, col: 26, offset: 3477},
+										val:        ".",
+										ignoreCase: false,
+									},
+									&ruleRefExpr{
+										pos:  position{line: 87, col: 30, offset: 3481},
+										name: "String",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Argument",
+			pos:  position{line: 91, col: 1, offset: 3526},
+			expr: &choiceExpr{
+				pos: position{line: 91, col: 13, offset: 3538},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 91, col: 13, offset: 3538},
+						name: "StringLiteral",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 91, col: 29, offset: 3554},
+						name: "Integer",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 91, col: 39, offset: 3564},
+						name: "ListLiteral",
+					},
+				},
+			},
+		},
+		{
+			name: "ListLiteral",
+			pos:  position{line: 93, col: 1, offset: 3577},
+			expr: &actionExpr{
+				pos: position{line: 93, col: 16, offset: 3592},
+				run: (*parser).callonListLiteral1,
+				expr: &seqExpr{
+					pos: position{line: 93, col: 16, offset: 3592},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 93, col: 16, offset: 3592},
+							val:        "[",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 93, col: 20, offset: 3596},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 93, col: 22, offset: 3598},
+							label: "items",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 93, col: 28, offset: 3604},
+								expr: &seqExpr{
+									pos: position{line: 93, col: 29, offset: 3605},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 93, col: 29, offset: 3605},
+											name: "StringLiteral",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 93, col: 43, offset: 3619},
+											name: "_",
+										},
+										&zeroOrMoreExpr{
+											pos: position{line: 93, col: 45, offset: 3621},
+											expr: &seqExpr{
+												pos: position{line: 93, col: 46, offset: 3622},
+												exprs: []interface{}{
+													&litMatcher{
+														pos:        position{line: 93, col: 46, offset: 3622},
+														val:        ",",
+														ignoreCase: false,
+													},
+													&ruleRefExpr{
+														pos:  position{line: 93, col: 50, offset: 3626},
+														name: "_",
+													},
+													&ruleRefExpr{
+														pos:  position{line: 93, col: 52, offset: 3628},
+														name: "StringLiteral",
+													},
+													&ruleRefExpr{
+														pos:  position{line: 93, col: 66, offset: 3642},
+														name: "_",
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 93, col: 72, offset: 3648},
+							val:        "]",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Query",
+			pos:  position{line: 107, col: 1, offset: 3931},
+			expr: &actionExpr{
+				pos: position{line: 107, col: 10, offset: 3940},
+				run: (*parser).callonQuery1,
+				expr: &labeledExpr{
+					pos:   position{line: 107, col: 10, offset: 3940},
+					label: "regularQuery",
+					expr: &ruleRefExpr{
+						pos:  position{line: 107, col: 23, offset: 3953},
+						name: "RegularQuery",
+					},
+				},
+			},
+		},
+		{
+			name: "RegularQuery",
+			pos:  position{line: 111, col: 1, offset: 4000},
+			expr: &actionExpr{
+				pos: position{line: 111, col: 18, offset: 4017},
+				run: (*parser).callonRegularQuery1,
+				expr: &labeledExpr{
+					pos:   position{line: 111, col: 18, offset: 4017},
+					label: "singleQuery",
+					expr: &ruleRefExpr{
+						pos:  position{line: 111, col: 30, offset: 4029},
+						name: "SingleQuery",
+					},
+				},
+			},
+		},
+		{
+			name: "SingleQuery",
+			pos:  position{line: 115, col: 1, offset: 4074},
+			expr: &actionExpr{
+				pos: position{line: 115, col: 16, offset: 4089},
+				run: (*parser).callonSingleQuery1,
+				expr: &seqExpr{
+					pos: position{line: 115, col: 16, offset: 4089},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 115, col: 16, offset: 4089},
+							label: "matches",
+							expr: &oneOrMoreExpr{
+								pos: position{line: 115, col: 24, offset: 4097},
+								expr: &seqExpr{
+									pos: position{line: 115, col: 25, offset: 4098},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 115, col: 25, offset: 4098},
+											name: "ReadingClause",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 115, col: 39, offset: 4112},
+											name: "_",
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 115, col: 43, offset: 4116},
+							label: "where",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 115, col: 49, offset: 4122},
+								expr: &seqExpr{
+									pos: position{line: 115, col: 50, offset: 4123},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 115, col: 50, offset: 4123},
+											name: "Where",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 115, col: 56, offset: 4129},
+											name: "_",
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 115, col: 60, offset: 4133},
+							label: "returns",
+							expr: &ruleRefExpr{
+								pos:  position{line: 115, col: 68, offset: 4141},
+								name: "Return",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 115, col: 75, offset: 4148},
+							label: "order",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 115, col: 81, offset: 4154},
+								expr: &seqExpr{
+									pos: position{line: 115, col: 82, offset: 4155},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 115, col: 82, offset: 4155},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 115, col: 84, offset: 4157},
+											name: "OrderBy",
+										},
+									},
+								},
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 115, col: 94, offset: 4167},
+							label: "limit",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 115, col: 100, offset: 4173},
+								expr: &seqExpr{
+									pos: position{line: 115, col: 101, offset: 4174},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 115, col: 101, offset: 4174},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 115, col: 103, offset: 4176},
+											name: "Limit",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Where",
+			pos:  position{line: 168, col: 1, offset: 5553},
+			expr: &actionExpr{
+				pos: position{line: 168, col: 10, offset: 5562},
+				run: (*parser).callonWhere1,
+				expr: &seqExpr{
+					pos: position{line: 168, col: 10, offset: 5562},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 168, col: 10, offset: 5562},
+							name: "W",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 168, col: 12, offset: 5564},
+							name: "H",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 168, col: 14, offset: 5566},
+							name: "E",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 168, col: 16, offset: 5568},
+							name: "R",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 168, col: 18, offset: 5570},
+							name: "E",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 168, col: 20, offset: 5572},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 168, col: 22, offset: 5574},
+							label: "predicate",
+							expr: &ruleRefExpr{
+								pos:  position{line: 168, col: 32, offset: 5584},
+								name: "Predicate",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 168, col: 42, offset: 5594},
+							name: "_",
+						},
+						&labeledExpr{
+							pos:   position{line: 168, col: 44, offset: 5596},
+							label: "extra",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 168, col: 50, offset: 5602},
+								expr: &seqExpr{
+									pos: position{line: 168, col: 51, offset: 5603},
+									exprs: []interface{}{
+										&ruleRefExpr{
+											pos:  position{line: 168, col: 51, offset: 5603},
+											name: "A",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 168, col: 53, offset: 5605},
+											name: "N",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 168, col: 55, offset: 5607},
+											name: "D",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 168, col: 57, offset: 5609},
+											name: "_",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 168, col: 59, offset: 5611},
+											name: "Predicate",
+										},
+										&ruleRefExpr{
+											pos:  position{line: 168, col: 69, offset: 5621},
+											name: "_",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Predicate",
+			pos:  position{line: 177, col: 1, offset: 5856},
 			expr: &actionExpr{
-				pos: position{line: 6, col: 14, offset: 97},
-				run: (*parser).callonStatement1,
+				pos: position{line: 177, col: 14, offset: 5869},
+				run: (*parser).callonPredicate1,
 				expr: &seqExpr{
-					pos: position{line: 6, col: 14, offset: 97},
+					pos: position{line: 177, col: 14, offset: 5869},
 					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 177, col: 14, offset: 5869},
+							label: "variable",
+							expr: &ruleRefExpr{
+								pos:  position{line: 177, col: 23, offset: 5878},
+								name: "Variable",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 177, col: 32, offset: 5887},
+							val:        ".",
+							ignoreCase: false,
+						},
+						&labeledExpr{
+							pos:   position{line: 177, col: 36, offset: 5891},
+							label: "property",
+							expr: &ruleRefExpr{
+								pos:  position{line: 177, col: 45, offset: 5900},
+								name: "String",
+							},
+						},
 						&ruleRefExpr{
-							pos:  position{line: 6, col: 14, offset: 97},
+							pos:  position{line: 177, col: 52, offset: 5907},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 6, col: 16, offset: 99},
-							label: "query",
+							pos:   position{line: 177, col: 54, offset: 5909},
+							label: "operator",
 							expr: &ruleRefExpr{
-								pos:  position{line: 6, col: 22, offset: 105},
-								name: "Query",
+								pos:  position{line: 177, col: 63, offset: 5918},
+								name: "Operator",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 6, col: 28, offset: 111},
+							pos:  position{line: 177, col: 72, offset: 5927},
 							name: "_",
 						},
-						&ruleRefExpr{
-							pos:  position{line: 6, col: 30, offset: 113},
-							name: "EOF",
+						&labeledExpr{
+							pos:   position{line: 177, col: 74, offset: 5929},
+							label: "value",
+							expr: &choiceExpr{
+								pos: position{line: 177, col: 81, offset: 5936},
+								alternatives: []interface{}{
+									&ruleRefExpr{
+										pos:  position{line: 177, col: 81, offset: 5936},
+										name: "StringLiteral",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 177, col: 95, offset: 5950},
+										name: "Integer",
+									},
+									&ruleRefExpr{
+										pos:  position{line: 177, col: 103, offset: 5958},
+										name: "BoolLiteral",
+									},
+								},
+							},
 						},
 					},
 				},
 			},
 		},
 		{
-			name: "Query",
-			pos:  position{line: 14, col: 1, offset: 238},
+			name: "Operator",
+			pos:  position{line: 202, col: 1, offset: 6534},
+			expr: &choiceExpr{
+				pos: position{line: 202, col: 13, offset: 6546},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 202, col: 13, offset: 6546},
+						name: "StartsWith",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 202, col: 26, offset: 6559},
+						name: "Comparison",
+					},
+				},
+			},
+		},
+		{
+			name: "StartsWith",
+			pos:  position{line: 204, col: 1, offset: 6571},
 			expr: &actionExpr{
-				pos: position{line: 14, col: 10, offset: 247},
-				run: (*parser).callonQuery1,
-				expr: &labeledExpr{
-					pos:   position{line: 14, col: 10, offset: 247},
-					label: "regularQuery",
-					expr: &ruleRefExpr{
-						pos:  position{line: 14, col: 23, offset: 260},
-						name: "RegularQuery",
+				pos: position{line: 204, col: 15, offset: 6585},
+				run: (*parser).callonStartsWith1,
+				expr: &seqExpr{
+					pos: position{line: 204, col: 15, offset: 6585},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 15, offset: 6585},
+							name: "S",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 17, offset: 6587},
+							name: "T",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 19, offset: 6589},
+							name: "A",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 21, offset: 6591},
+							name: "R",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 23, offset: 6593},
+							name: "T",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 25, offset: 6595},
+							name: "S",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 27, offset: 6597},
+							name: "_",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 29, offset: 6599},
+							name: "W",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 31, offset: 6601},
+							name: "I",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 33, offset: 6603},
+							name: "T",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 204, col: 35, offset: 6605},
+							name: "H",
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "RegularQuery",
-			pos:  position{line: 18, col: 1, offset: 311},
+			name: "Comparison",
+			pos:  position{line: 208, col: 1, offset: 6642},
 			expr: &actionExpr{
-				pos: position{line: 18, col: 18, offset: 328},
-				run: (*parser).callonRegularQuery1,
-				expr: &labeledExpr{
-					pos:   position{line: 18, col: 18, offset: 328},
-					label: "singleQuery",
-					expr: &ruleRefExpr{
-						pos:  position{line: 18, col: 30, offset: 340},
-						name: "SingleQuery",
+				pos: position{line: 208, col: 15, offset: 6656},
+				run: (*parser).callonComparison1,
+				expr: &choiceExpr{
+					pos: position{line: 208, col: 16, offset: 6657},
+					alternatives: []interface{}{
+						&litMatcher{
+							pos:        position{line: 208, col: 16, offset: 6657},
+							val:        "<>",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 208, col: 23, offset: 6664},
+							val:        "<=",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 208, col: 30, offset: 6671},
+							val:        ">=",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 208, col: 37, offset: 6678},
+							val:        "=",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 208, col: 43, offset: 6684},
+							val:        "<",
+							ignoreCase: false,
+						},
+						&litMatcher{
+							pos:        position{line: 208, col: 49, offset: 6690},
+							val:        ">",
+							ignoreCase: false,
+						},
 					},
 				},
 			},
 		},
 		{
-			name: "SingleQuery",
-			pos:  position{line: 22, col: 1, offset: 389},
+			name: "OrderBy",
+			pos:  position{line: 212, col: 1, offset: 6731},
 			expr: &actionExpr{
-				pos: position{line: 22, col: 16, offset: 404},
-				run: (*parser).callonSingleQuery1,
+				pos: position{line: 212, col: 12, offset: 6742},
+				run: (*parser).callonOrderBy1,
 				expr: &seqExpr{
-					pos: position{line: 22, col: 16, offset: 404},
+					pos: position{line: 212, col: 12, offset: 6742},
 					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 212, col: 12, offset: 6742},
+							name: "O",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 212, col: 14, offset: 6744},
+							name: "R",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 212, col: 16, offset: 6746},
+							name: "D",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 212, col: 18, offset: 6748},
+							name: "E",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 212, col: 20, offset: 6750},
+							name: "R",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 212, col: 22, offset: 6752},
+							name: "_",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 212, col: 24, offset: 6754},
+							name: "B",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 212, col: 26, offset: 6756},
+							name: "Y",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 212, col: 28, offset: 6758},
+							name: "_",
+						},
 						&labeledExpr{
-							pos:   position{line: 22, col: 16, offset: 404},
-							label: "matches",
-							expr: &oneOrMoreExpr{
-								pos: position{line: 22, col: 24, offset: 412},
+							pos:   position{line: 212, col: 30, offset: 6760},
+							label: "variable",
+							expr: &ruleRefExpr{
+								pos:  position{line: 212, col: 39, offset: 6769},
+								name: "Variable",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 212, col: 48, offset: 6778},
+							val:        ".",
+							ignoreCase: false,
+						},
+						&labeledExpr{
+							pos:   position{line: 212, col: 52, offset: 6782},
+							label: "property",
+							expr: &ruleRefExpr{
+								pos:  position{line: 212, col: 61, offset: 6791},
+								name: "String",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 212, col: 68, offset: 6798},
+							label: "descending",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 212, col: 79, offset: 6809},
 								expr: &seqExpr{
-									pos: position{line: 22, col: 25, offset: 413},
+									pos: position{line: 212, col: 80, offset: 6810},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 22, col: 25, offset: 413},
-											name: "ReadingClause",
+											pos:  position{line: 212, col: 80, offset: 6810},
+											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 22, col: 39, offset: 427},
-											name: "_",
+											pos:  position{line: 212, col: 82, offset: 6812},
+											name: "Direction",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Direction",
+			pos:  position{line: 225, col: 1, offset: 7038},
+			expr: &choiceExpr{
+				pos: position{line: 225, col: 14, offset: 7051},
+				alternatives: []interface{}{
+					&actionExpr{
+						pos: position{line: 225, col: 14, offset: 7051},
+						run: (*parser).callonDirection2,
+						expr: &seqExpr{
+							pos: position{line: 225, col: 14, offset: 7051},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 225, col: 14, offset: 7051},
+									name: "D",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 225, col: 16, offset: 7053},
+									name: "E",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 225, col: 18, offset: 7055},
+									name: "S",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 225, col: 20, offset: 7057},
+									name: "C",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 225, col: 22, offset: 7059},
+									expr: &seqExpr{
+										pos: position{line: 225, col: 23, offset: 7060},
+										exprs: []interface{}{
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 23, offset: 7060},
+												name: "E",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 25, offset: 7062},
+												name: "N",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 27, offset: 7064},
+												name: "D",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 29, offset: 7066},
+												name: "I",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 31, offset: 7068},
+												name: "N",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 33, offset: 7070},
+												name: "G",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					&actionExpr{
+						pos: position{line: 225, col: 60, offset: 7097},
+						run: (*parser).callonDirection16,
+						expr: &seqExpr{
+							pos: position{line: 225, col: 60, offset: 7097},
+							exprs: []interface{}{
+								&ruleRefExpr{
+									pos:  position{line: 225, col: 60, offset: 7097},
+									name: "A",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 225, col: 62, offset: 7099},
+									name: "S",
+								},
+								&ruleRefExpr{
+									pos:  position{line: 225, col: 64, offset: 7101},
+									name: "C",
+								},
+								&zeroOrOneExpr{
+									pos: position{line: 225, col: 66, offset: 7103},
+									expr: &seqExpr{
+										pos: position{line: 225, col: 67, offset: 7104},
+										exprs: []interface{}{
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 67, offset: 7104},
+												name: "E",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 69, offset: 7106},
+												name: "N",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 71, offset: 7108},
+												name: "D",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 73, offset: 7110},
+												name: "I",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 75, offset: 7112},
+												name: "N",
+											},
+											&ruleRefExpr{
+												pos:  position{line: 225, col: 77, offset: 7114},
+												name: "G",
+											},
 										},
 									},
 								},
 							},
 						},
+					},
+				},
+			},
+		},
+		{
+			name: "Limit",
+			pos:  position{line: 227, col: 1, offset: 7141},
+			expr: &actionExpr{
+				pos: position{line: 227, col: 10, offset: 7150},
+				run: (*parser).callonLimit1,
+				expr: &seqExpr{
+					pos: position{line: 227, col: 10, offset: 7150},
+					exprs: []interface{}{
+						&ruleRefExpr{
+							pos:  position{line: 227, col: 10, offset: 7150},
+							name: "L",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 227, col: 12, offset: 7152},
+							name: "I",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 227, col: 14, offset: 7154},
+							name: "M",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 227, col: 16, offset: 7156},
+							name: "I",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 227, col: 18, offset: 7158},
+							name: "T",
+						},
+						&ruleRefExpr{
+							pos:  position{line: 227, col: 20, offset: 7160},
+							name: "_",
+						},
 						&labeledExpr{
-							pos:   position{line: 22, col: 43, offset: 431},
-							label: "returns",
+							pos:   position{line: 227, col: 22, offset: 7162},
+							label: "count",
 							expr: &ruleRefExpr{
-								pos:  position{line: 22, col: 51, offset: 439},
-								name: "Return",
+								pos:  position{line: 227, col: 28, offset: 7168},
+								name: "Integer",
 							},
 						},
 					},
@@ -124,15 +2178,15 @@ var g = &grammar{
 		},
 		{
 			name: "ReadingClause",
-			pos:  position{line: 54, col: 1, offset: 1203},
+			pos:  position{line: 231, col: 1, offset: 7203},
 			expr: &actionExpr{
-				pos: position{line: 54, col: 18, offset: 1220},
+				pos: position{line: 231, col: 18, offset: 7220},
 				run: (*parser).callonReadingClause1,
 				expr: &labeledExpr{
-					pos:   position{line: 54, col: 18, offset: 1220},
+					pos:   position{line: 231, col: 18, offset: 7220},
 					label: "match",
 					expr: &ruleRefExpr{
-						pos:  position{line: 54, col: 24, offset: 1226},
+						pos:  position{line: 231, col: 24, offset: 7226},
 						name: "Match",
 					},
 				},
@@ -140,72 +2194,72 @@ var g = &grammar{
 		},
 		{
 			name: "Return",
-			pos:  position{line: 58, col: 1, offset: 1271},
+			pos:  position{line: 235, col: 1, offset: 7267},
 			expr: &actionExpr{
-				pos: position{line: 58, col: 11, offset: 1281},
+				pos: position{line: 235, col: 11, offset: 7277},
 				run: (*parser).callonReturn1,
 				expr: &seqExpr{
-					pos: position{line: 58, col: 11, offset: 1281},
+					pos: position{line: 235, col: 11, offset: 7277},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 11, offset: 1281},
+							pos:  position{line: 235, col: 11, offset: 7277},
 							name: "R",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 13, offset: 1283},
+							pos:  position{line: 235, col: 13, offset: 7279},
 							name: "E",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 15, offset: 1285},
+							pos:  position{line: 235, col: 15, offset: 7281},
 							name: "T",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 17, offset: 1287},
+							pos:  position{line: 235, col: 17, offset: 7283},
 							name: "U",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 19, offset: 1289},
+							pos:  position{line: 235, col: 19, offset: 7285},
 							name: "R",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 21, offset: 1291},
+							pos:  position{line: 235, col: 21, offset: 7287},
 							name: "N",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 24, offset: 1294},
+							pos:  position{line: 235, col: 24, offset: 7290},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 58, col: 26, offset: 1296},
+							pos:   position{line: 235, col: 26, offset: 7292},
 							label: "variable",
 							expr: &ruleRefExpr{
-								pos:  position{line: 58, col: 35, offset: 1305},
+								pos:  position{line: 235, col: 35, offset: 7301},
 								name: "Variable",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 58, col: 44, offset: 1314},
+							pos:  position{line: 235, col: 44, offset: 7310},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 58, col: 46, offset: 1316},
+							pos:   position{line: 235, col: 46, offset: 7312},
 							label: "extra",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 58, col: 52, offset: 1322},
+								pos: position{line: 235, col: 52, offset: 7318},
 								expr: &seqExpr{
-									pos: position{line: 58, col: 53, offset: 1323},
+									pos: position{line: 235, col: 53, offset: 7319},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 58, col: 53, offset: 1323},
+											pos:        position{line: 235, col: 53, offset: 7319},
 											val:        ",",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 58, col: 57, offset: 1327},
+											pos:  position{line: 235, col: 57, offset: 7323},
 											name: "_",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 58, col: 59, offset: 1329},
+											pos:  position{line: 235, col: 59, offset: 7325},
 											name: "Variable",
 										},
 									},
@@ -218,42 +2272,42 @@ var g = &grammar{
 		},
 		{
 			name: "Match",
-			pos:  position{line: 68, col: 1, offset: 1588},
+			pos:  position{line: 245, col: 1, offset: 7574},
 			expr: &actionExpr{
-				pos: position{line: 68, col: 10, offset: 1597},
+				pos: position{line: 245, col: 10, offset: 7583},
 				run: (*parser).callonMatch1,
 				expr: &seqExpr{
-					pos: position{line: 68, col: 10, offset: 1597},
+					pos: position{line: 245, col: 10, offset: 7583},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 68, col: 10, offset: 1597},
+							pos:  position{line: 245, col: 10, offset: 7583},
 							name: "M",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 68, col: 12, offset: 1599},
+							pos:  position{line: 245, col: 12, offset: 7585},
 							name: "A",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 68, col: 14, offset: 1601},
+							pos:  position{line: 245, col: 14, offset: 7587},
 							name: "T",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 68, col: 16, offset: 1603},
+							pos:  position{line: 245, col: 16, offset: 7589},
 							name: "C",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 68, col: 18, offset: 1605},
+							pos:  position{line: 245, col: 18, offset: 7591},
 							name: "H",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 68, col: 20, offset: 1607},
+							pos:  position{line: 245, col: 20, offset: 7593},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 68, col: 22, offset: 1609},
+							pos:   position{line: 245, col: 22, offset: 7595},
 							label: "pattern",
 							expr: &ruleRefExpr{
-								pos:  position{line: 68, col: 30, offset: 1617},
+								pos:  position{line: 245, col: 30, offset: 7603},
 								name: "Pattern",
 							},
 						},
@@ -263,94 +2317,94 @@ var g = &grammar{
 		},
 		{
 			name: "Pattern",
-			pos:  position{line: 73, col: 1, offset: 1707},
+			pos:  position{line: 250, col: 1, offset: 7688},
 			expr: &ruleRefExpr{
-				pos:  position{line: 73, col: 12, offset: 1718},
+				pos:  position{line: 250, col: 12, offset: 7699},
 				name: "PatternPart",
 			},
 		},
 		{
 			name: "PatternPart",
-			pos:  position{line: 75, col: 1, offset: 1734},
+			pos:  position{line: 252, col: 1, offset: 7713},
 			expr: &ruleRefExpr{
-				pos:  position{line: 75, col: 16, offset: 1749},
+				pos:  position{line: 252, col: 16, offset: 7728},
 				name: "AnonymousPatternPart",
 			},
 		},
 		{
 			name: "AnonymousPatternPart",
-			pos:  position{line: 77, col: 1, offset: 1773},
+			pos:  position{line: 254, col: 1, offset: 7750},
 			expr: &ruleRefExpr{
-				pos:  position{line: 77, col: 25, offset: 1797},
+				pos:  position{line: 254, col: 25, offset: 7774},
 				name: "PatternElement",
 			},
 		},
 		{
 			name: "PatternElement",
-			pos:  position{line: 79, col: 1, offset: 1815},
+			pos:  position{line: 256, col: 1, offset: 7790},
 			expr: &ruleRefExpr{
-				pos:  position{line: 79, col: 19, offset: 1833},
+				pos:  position{line: 256, col: 19, offset: 7808},
 				name: "NodePattern",
 			},
 		},
 		{
 			name: "NodePattern",
-			pos:  position{line: 81, col: 1, offset: 1848},
+			pos:  position{line: 258, col: 1, offset: 7821},
 			expr: &actionExpr{
-				pos: position{line: 81, col: 16, offset: 1863},
+				pos: position{line: 258, col: 16, offset: 7836},
 				run: (*parser).callonNodePattern1,
 				expr: &seqExpr{
-					pos: position{line: 81, col: 16, offset: 1863},
+					pos: position{line: 258, col: 16, offset: 7836},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 81, col: 16, offset: 1863},
+							pos:        position{line: 258, col: 16, offset: 7836},
 							val:        "(",
 							ignoreCase: false,
 						},
 						&labeledExpr{
-							pos:   position{line: 81, col: 20, offset: 1867},
+							pos:   position{line: 258, col: 20, offset: 7840},
 							label: "variable",
 							expr: &ruleRefExpr{
-								pos:  position{line: 81, col: 29, offset: 1876},
+								pos:  position{line: 258, col: 29, offset: 7849},
 								name: "Variable",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 81, col: 38, offset: 1885},
+							pos:  position{line: 258, col: 38, offset: 7858},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 81, col: 40, offset: 1887},
+							pos:   position{line: 258, col: 40, offset: 7860},
 							label: "labels",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 81, col: 47, offset: 1894},
+								pos: position{line: 258, col: 47, offset: 7867},
 								expr: &ruleRefExpr{
-									pos:  position{line: 81, col: 47, offset: 1894},
+									pos:  position{line: 258, col: 47, offset: 7867},
 									name: "NodeLabels",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 81, col: 59, offset: 1906},
+							pos:  position{line: 258, col: 59, offset: 7879},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 81, col: 61, offset: 1908},
+							pos:   position{line: 258, col: 61, offset: 7881},
 							label: "props",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 81, col: 67, offset: 1914},
+								pos: position{line: 258, col: 67, offset: 7887},
 								expr: &ruleRefExpr{
-									pos:  position{line: 81, col: 68, offset: 1915},
+									pos:  position{line: 258, col: 68, offset: 7888},
 									name: "Properties",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 81, col: 81, offset: 1928},
+							pos:  position{line: 258, col: 81, offset: 7901},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 81, col: 83, offset: 1930},
+							pos:        position{line: 258, col: 83, offset: 7903},
 							val:        ")",
 							ignoreCase: false,
 						},
@@ -360,32 +2414,32 @@ var g = &grammar{
 		},
 		{
 			name: "NodeLabels",
-			pos:  position{line: 97, col: 1, offset: 2189},
+			pos:  position{line: 274, col: 1, offset: 8146},
 			expr: &actionExpr{
-				pos: position{line: 97, col: 15, offset: 2203},
+				pos: position{line: 274, col: 15, offset: 8160},
 				run: (*parser).callonNodeLabels1,
 				expr: &seqExpr{
-					pos: position{line: 97, col: 15, offset: 2203},
+					pos: position{line: 274, col: 15, offset: 8160},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 97, col: 15, offset: 2203},
+							pos:   position{line: 274, col: 15, offset: 8160},
 							label: "label",
 							expr: &ruleRefExpr{
-								pos:  position{line: 97, col: 21, offset: 2209},
+								pos:  position{line: 274, col: 21, offset: 8166},
 								name: "NodeLabel",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 97, col: 31, offset: 2219},
+							pos:  position{line: 274, col: 31, offset: 8176},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 97, col: 33, offset: 2221},
+							pos:   position{line: 274, col: 33, offset: 8178},
 							label: "labels",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 97, col: 40, offset: 2228},
+								pos: position{line: 274, col: 40, offset: 8185},
 								expr: &ruleRefExpr{
-									pos:  position{line: 97, col: 41, offset: 2229},
+									pos:  position{line: 274, col: 41, offset: 8186},
 									name: "NodeLabel",
 								},
 							},
@@ -396,27 +2450,27 @@ var g = &grammar{
 		},
 		{
 			name: "NodeLabel",
-			pos:  position{line: 114, col: 1, offset: 2571},
+			pos:  position{line: 287, col: 1, offset: 8410},
 			expr: &actionExpr{
-				pos: position{line: 114, col: 14, offset: 2584},
+				pos: position{line: 287, col: 14, offset: 8423},
 				run: (*parser).callonNodeLabel1,
 				expr: &seqExpr{
-					pos: position{line: 114, col: 14, offset: 2584},
+					pos: position{line: 287, col: 14, offset: 8423},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 114, col: 14, offset: 2584},
+							pos:        position{line: 287, col: 14, offset: 8423},
 							val:        ":",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 114, col: 18, offset: 2588},
+							pos:  position{line: 287, col: 18, offset: 8427},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 114, col: 20, offset: 2590},
+							pos:   position{line: 287, col: 20, offset: 8429},
 							label: "label",
 							expr: &ruleRefExpr{
-								pos:  position{line: 114, col: 26, offset: 2596},
+								pos:  position{line: 287, col: 26, offset: 8435},
 								name: "String",
 							},
 						},
@@ -426,74 +2480,74 @@ var g = &grammar{
 		},
 		{
 			name: "Variable",
-			pos:  position{line: 118, col: 1, offset: 2634},
+			pos:  position{line: 291, col: 1, offset: 8469},
 			expr: &ruleRefExpr{
-				pos:  position{line: 118, col: 13, offset: 2646},
+				pos:  position{line: 291, col: 13, offset: 8481},
 				name: "SymbolicName",
 			},
 		},
 		{
 			name: "SymbolicName",
-			pos:  position{line: 120, col: 1, offset: 2662},
+			pos:  position{line: 293, col: 1, offset: 8495},
 			expr: &ruleRefExpr{
-				pos:  position{line: 120, col: 17, offset: 2678},
+				pos:  position{line: 293, col: 17, offset: 8511},
 				name: "String",
 			},
 		},
 		{
 			name: "Properties",
-			pos:  position{line: 122, col: 1, offset: 2688},
+			pos:  position{line: 295, col: 1, offset: 8519},
 			expr: &ruleRefExpr{
-				pos:  position{line: 122, col: 15, offset: 2702},
+				pos:  position{line: 295, col: 15, offset: 8533},
 				name: "MapLiteral",
 			},
 		},
 		{
 			name: "ProperyKV",
-			pos:  position{line: 123, col: 1, offset: 2714},
+			pos:  position{line: 296, col: 1, offset: 8544},
 			expr: &actionExpr{
-				pos: position{line: 123, col: 14, offset: 2727},
+				pos: position{line: 296, col: 14, offset: 8557},
 				run: (*parser).callonProperyKV1,
 				expr: &seqExpr{
-					pos: position{line: 123, col: 14, offset: 2727},
+					pos: position{line: 296, col: 14, offset: 8557},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 123, col: 14, offset: 2727},
+							pos:   position{line: 296, col: 14, offset: 8557},
 							label: "key",
 							expr: &ruleRefExpr{
-								pos:  position{line: 123, col: 18, offset: 2731},
+								pos:  position{line: 296, col: 18, offset: 8561},
 								name: "String",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 123, col: 25, offset: 2738},
+							pos:  position{line: 296, col: 25, offset: 8568},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 123, col: 27, offset: 2740},
+							pos:        position{line: 296, col: 27, offset: 8570},
 							val:        ":",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 123, col: 31, offset: 2744},
+							pos:  position{line: 296, col: 31, offset: 8574},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 123, col: 33, offset: 2746},
+							pos:   position{line: 296, col: 33, offset: 8576},
 							label: "value",
 							expr: &choiceExpr{
-								pos: position{line: 123, col: 40, offset: 2753},
+								pos: position{line: 296, col: 40, offset: 8583},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 123, col: 40, offset: 2753},
+										pos:  position{line: 296, col: 40, offset: 8583},
 										name: "StringLiteral",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 123, col: 54, offset: 2767},
+										pos:  position{line: 296, col: 54, offset: 8597},
 										name: "Integer",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 123, col: 62, offset: 2775},
+										pos:  position{line: 296, col: 62, offset: 8605},
 										name: "BoolLiteral",
 									},
 								},
@@ -505,54 +2559,54 @@ var g = &grammar{
 		},
 		{
 			name: "MapLiteral",
-			pos:  position{line: 136, col: 1, offset: 3188},
+			pos:  position{line: 309, col: 1, offset: 9005},
 			expr: &actionExpr{
-				pos: position{line: 136, col: 15, offset: 3202},
+				pos: position{line: 309, col: 15, offset: 9019},
 				run: (*parser).callonMapLiteral1,
 				expr: &seqExpr{
-					pos: position{line: 136, col: 15, offset: 3202},
+					pos: position{line: 309, col: 15, offset: 9019},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 136, col: 15, offset: 3202},
+							pos:        position{line: 309, col: 15, offset: 9019},
 							val:        "{",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 136, col: 19, offset: 3206},
+							pos:  position{line: 309, col: 19, offset: 9023},
 							name: "_",
 						},
 						&labeledExpr{
-							pos:   position{line: 136, col: 21, offset: 3208},
+							pos:   position{line: 309, col: 21, offset: 9025},
 							label: "kv",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 136, col: 24, offset: 3211},
+								pos: position{line: 309, col: 24, offset: 9028},
 								expr: &seqExpr{
-									pos: position{line: 136, col: 25, offset: 3212},
+									pos: position{line: 309, col: 25, offset: 9029},
 									exprs: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 136, col: 25, offset: 3212},
+											pos:  position{line: 309, col: 25, offset: 9029},
 											name: "ProperyKV",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 136, col: 35, offset: 3222},
+											pos:  position{line: 309, col: 35, offset: 9039},
 											name: "_",
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 136, col: 37, offset: 3224},
+											pos: position{line: 309, col: 37, offset: 9041},
 											expr: &seqExpr{
-												pos: position{line: 136, col: 38, offset: 3225},
+												pos: position{line: 309, col: 38, offset: 9042},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 136, col: 38, offset: 3225},
+														pos:        position{line: 309, col: 38, offset: 9042},
 														val:        ",",
 														ignoreCase: false,
 													},
 													&ruleRefExpr{
-														pos:  position{line: 136, col: 42, offset: 3229},
+														pos:  position{line: 309, col: 42, offset: 9046},
 														name: "_",
 													},
 													&ruleRefExpr{
-														pos:  position{line: 136, col: 44, offset: 3231},
+														pos:  position{line: 309, col: 44, offset: 9048},
 														name: "ProperyKV",
 													},
 												},
@@ -563,11 +2617,11 @@ var g = &grammar{
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 136, col: 59, offset: 3246},
+							pos:  position{line: 309, col: 59, offset: 9063},
 							name: "_",
 						},
 						&litMatcher{
-							pos:        position{line: 136, col: 61, offset: 3248},
+							pos:        position{line: 309, col: 61, offset: 9065},
 							val:        "}",
 							ignoreCase: false,
 						},
@@ -577,51 +2631,51 @@ var g = &grammar{
 		},
 		{
 			name: "StringLiteral",
-			pos:  position{line: 160, col: 1, offset: 3784},
+			pos:  position{line: 333, col: 1, offset: 9577},
 			expr: &actionExpr{
-				pos: position{line: 160, col: 18, offset: 3801},
+				pos: position{line: 333, col: 18, offset: 9594},
 				run: (*parser).callonStringLiteral1,
 				expr: &choiceExpr{
-					pos: position{line: 160, col: 19, offset: 3802},
+					pos: position{line: 333, col: 19, offset: 9595},
 					alternatives: []interface{}{
 						&seqExpr{
-							pos: position{line: 160, col: 19, offset: 3802},
+							pos: position{line: 333, col: 19, offset: 9595},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 160, col: 19, offset: 3802},
+									pos:        position{line: 333, col: 19, offset: 9595},
 									val:        "\"",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 160, col: 23, offset: 3806},
+									pos: position{line: 333, col: 23, offset: 9599},
 									expr: &choiceExpr{
-										pos: position{line: 160, col: 25, offset: 3808},
+										pos: position{line: 333, col: 25, offset: 9601},
 										alternatives: []interface{}{
 											&seqExpr{
-												pos: position{line: 160, col: 25, offset: 3808},
+												pos: position{line: 333, col: 25, offset: 9601},
 												exprs: []interface{}{
 													&notExpr{
-														pos: position{line: 160, col: 25, offset: 3808},
+														pos: position{line: 333, col: 25, offset: 9601},
 														expr: &ruleRefExpr{
-															pos:  position{line: 160, col: 26, offset: 3809},
+															pos:  position{line: 333, col: 26, offset: 9602},
 															name: "EscapedChar",
 														},
 													},
 													&anyMatcher{
-														line: 160, col: 38, offset: 3821,
+														line: 333, col: 38, offset: 9614,
 													},
 												},
 											},
 											&seqExpr{
-												pos: position{line: 160, col: 42, offset: 3825},
+												pos: position{line: 333, col: 42, offset: 9618},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 160, col: 42, offset: 3825},
+														pos:        position{line: 333, col: 42, offset: 9618},
 														val:        "\\",
 														ignoreCase: false,
 													},
 													&ruleRefExpr{
-														pos:  position{line: 160, col: 47, offset: 3830},
+														pos:  position{line: 333, col: 47, offset: 9623},
 														name: "EscapeSequence",
 													},
 												},
@@ -630,50 +2684,50 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 160, col: 65, offset: 3848},
+									pos:        position{line: 333, col: 65, offset: 9641},
 									val:        "\"",
 									ignoreCase: false,
 								},
 							},
 						},
 						&seqExpr{
-							pos: position{line: 160, col: 71, offset: 3854},
+							pos: position{line: 333, col: 71, offset: 9647},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 160, col: 71, offset: 3854},
+									pos:        position{line: 333, col: 71, offset: 9647},
 									val:        "'",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 160, col: 75, offset: 3858},
+									pos: position{line: 333, col: 75, offset: 9651},
 									expr: &choiceExpr{
-										pos: position{line: 160, col: 77, offset: 3860},
+										pos: position{line: 333, col: 77, offset: 9653},
 										alternatives: []interface{}{
 											&seqExpr{
-												pos: position{line: 160, col: 77, offset: 3860},
+												pos: position{line: 333, col: 77, offset: 9653},
 												exprs: []interface{}{
 													&notExpr{
-														pos: position{line: 160, col: 77, offset: 3860},
+														pos: position{line: 333, col: 77, offset: 9653},
 														expr: &ruleRefExpr{
-															pos:  position{line: 160, col: 78, offset: 3861},
+															pos:  position{line: 333, col: 78, offset: 9654},
 															name: "EscapedChar",
 														},
 													},
 													&anyMatcher{
-														line: 160, col: 90, offset: 3873,
+														line: 333, col: 90, offset: 9666,
 													},
 												},
 											},
 											&seqExpr{
-												pos: position{line: 160, col: 94, offset: 3877},
+												pos: position{line: 333, col: 94, offset: 9670},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 160, col: 94, offset: 3877},
+														pos:        position{line: 333, col: 94, offset: 9670},
 														val:        "\\",
 														ignoreCase: false,
 													},
 													&ruleRefExpr{
-														pos:  position{line: 160, col: 99, offset: 3882},
+														pos:  position{line: 333, col: 99, offset: 9675},
 														name: "EscapeSequence",
 													},
 												},
@@ -682,7 +2736,7 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 160, col: 117, offset: 3900},
+									pos:        position{line: 333, col: 117, offset: 9693},
 									val:        "'",
 									ignoreCase: false,
 								},
@@ -694,9 +2748,9 @@ var g = &grammar{
 		},
 		{
 			name: "EscapedChar",
-			pos:  position{line: 175, col: 1, offset: 4387},
+			pos:  position{line: 348, col: 1, offset: 10165},
 			expr: &charClassMatcher{
-				pos:        position{line: 175, col: 16, offset: 4402},
+				pos:        position{line: 348, col: 16, offset: 10180},
 				val:        "[\\x00-\\x1f'\"\\\\]",
 				chars:      []rune{'\'', '"', '\\'},
 				ranges:     []rune{'\x00', '\x1f'},
@@ -706,16 +2760,16 @@ var g = &grammar{
 		},
 		{
 			name: "EscapeSequence",
-			pos:  position{line: 177, col: 1, offset: 4421},
+			pos:  position{line: 350, col: 1, offset: 10197},
 			expr: &choiceExpr{
-				pos: position{line: 177, col: 19, offset: 4439},
+				pos: position{line: 350, col: 19, offset: 10215},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 177, col: 19, offset: 4439},
+						pos:  position{line: 350, col: 19, offset: 10215},
 						name: "SingleCharEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 177, col: 38, offset: 4458},
+						pos:  position{line: 350, col: 38, offset: 10234},
 						name: "UnicodeEscape",
 					},
 				},
@@ -723,9 +2777,9 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharEscape",
-			pos:  position{line: 179, col: 1, offset: 4475},
+			pos:  position{line: 352, col: 1, offset: 10249},
 			expr: &charClassMatcher{
-				pos:        position{line: 179, col: 21, offset: 4495},
+				pos:        position{line: 352, col: 21, offset: 10269},
 				val:        "['\"\\\\/bfnrt]",
 				chars:      []rune{'\'', '"', '\\', '/', 'b', 'f', 'n', 'r', 't'},
 				ignoreCase: false,
@@ -734,29 +2788,29 @@ var g = &grammar{
 		},
 		{
 			name: "UnicodeEscape",
-			pos:  position{line: 181, col: 1, offset: 4511},
+			pos:  position{line: 354, col: 1, offset: 10283},
 			expr: &seqExpr{
-				pos: position{line: 181, col: 18, offset: 4528},
+				pos: position{line: 354, col: 18, offset: 10300},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 181, col: 18, offset: 4528},
+						pos:        position{line: 354, col: 18, offset: 10300},
 						val:        "u",
 						ignoreCase: false,
 					},
 					&ruleRefExpr{
-						pos:  position{line: 181, col: 22, offset: 4532},
+						pos:  position{line: 354, col: 22, offset: 10304},
 						name: "HexDigit",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 181, col: 31, offset: 4541},
+						pos:  position{line: 354, col: 31, offset: 10313},
 						name: "HexDigit",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 181, col: 40, offset: 4550},
+						pos:  position{line: 354, col: 40, offset: 10322},
 						name: "HexDigit",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 181, col: 49, offset: 4559},
+						pos:  position{line: 354, col: 49, offset: 10331},
 						name: "HexDigit",
 					},
 				},
@@ -764,15 +2818,16 @@ var g = &grammar{
 		},
 		{
 			name: "String",
-			pos:  position{line: 183, col: 1, offset: 4571},
+			pos:  position{line: 356, col: 1, offset: 10341},
 			expr: &actionExpr{
-				pos: position{line: 183, col: 11, offset: 4581},
+				pos: position{line: 356, col: 11, offset: 10351},
 				run: (*parser).callonString1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 183, col: 11, offset: 4581},
+					pos: position{line: 356, col: 11, offset: 10351},
 					expr: &charClassMatcher{
-						pos:        position{line: 183, col: 11, offset: 4581},
-						val:        "[a-zA-Z0-9]",
+						pos:        position{line: 356, col: 11, offset: 10351},
+						val:        "[a-zA-Z0-9_]",
+						chars:      []rune{'_'},
 						ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
 						ignoreCase: false,
 						inverted:   false,
@@ -782,40 +2837,53 @@ var g = &grammar{
 		},
 		{
 			name: "Integer",
-			pos:  position{line: 187, col: 1, offset: 4634},
+			pos:  position{line: 360, col: 1, offset: 10401},
 			expr: &actionExpr{
-				pos: position{line: 187, col: 12, offset: 4645},
+				pos: position{line: 360, col: 12, offset: 10412},
 				run: (*parser).callonInteger1,
-				expr: &oneOrMoreExpr{
-					pos: position{line: 187, col: 12, offset: 4645},
-					expr: &charClassMatcher{
-						pos:        position{line: 187, col: 12, offset: 4645},
-						val:        "[0-9]",
-						ranges:     []rune{'0', '9'},
-						ignoreCase: false,
-						inverted:   false,
+				expr: &seqExpr{
+					pos: position{line: 360, col: 12, offset: 10412},
+					exprs: []interface{}{
+						&zeroOrOneExpr{
+							pos: position{line: 360, col: 12, offset: 10412},
+							expr: &litMatcher{
+								pos:        position{line: 360, col: 12, offset: 10412},
+								val:        "-",
+								ignoreCase: false,
+							},
+						},
+						&oneOrMoreExpr{
+							pos: position{line: 360, col: 17, offset: 10417},
+							expr: &charClassMatcher{
+								pos:        position{line: 360, col: 17, offset: 10417},
+								val:        "[0-9]",
+								ranges:     []rune{'0', '9'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
 					},
 				},
 			},
 		},
 		{
 			name: "BoolLiteral",
-			pos:  position{line: 191, col: 1, offset: 4713},
+			pos:  position{line: 364, col: 1, offset: 10481},
 			expr: &choiceExpr{
-				pos: position{line: 191, col: 16, offset: 4728},
+				pos: position{line: 364, col: 16, offset: 10496},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 191, col: 16, offset: 4728},
+						pos: position{line: 364, col: 16, offset: 10496},
 						run: (*parser).callonBoolLiteral2,
 						expr: &seqExpr{
-							pos: position{line: 191, col: 16, offset: 4728},
+							pos: position{line: 364, col: 16, offset: 10496},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 191, col: 16, offset: 4728},
+									pos:  position{line: 364, col: 16, offset: 10496},
 									name: "T",
 								},
 								&litMatcher{
-									pos:        position{line: 191, col: 18, offset: 4730},
+									pos:        position{line: 364, col: 18, offset: 10498},
 									val:        "rue",
 									ignoreCase: false,
 								},
@@ -823,17 +2891,17 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 191, col: 47, offset: 4759},
+						pos: position{line: 364, col: 47, offset: 10527},
 						run: (*parser).callonBoolLiteral6,
 						expr: &seqExpr{
-							pos: position{line: 191, col: 47, offset: 4759},
+							pos: position{line: 364, col: 47, offset: 10527},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 191, col: 47, offset: 4759},
+									pos:  position{line: 364, col: 47, offset: 10527},
 									name: "F",
 								},
 								&litMatcher{
-									pos:        position{line: 191, col: 49, offset: 4761},
+									pos:        position{line: 364, col: 49, offset: 10529},
 									val:        "alse",
 									ignoreCase: false,
 								},
@@ -846,11 +2914,11 @@ var g = &grammar{
 		{
 			name:        "_",
 			displayName: "\"whitespace\"",
-			pos:         position{line: 193, col: 1, offset: 4792},
+			pos:         position{line: 366, col: 1, offset: 10558},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 193, col: 19, offset: 4810},
+				pos: position{line: 366, col: 19, offset: 10576},
 				expr: &charClassMatcher{
-					pos:        position{line: 193, col: 19, offset: 4810},
+					pos:        position{line: 366, col: 19, offset: 10576},
 					val:        "[ \\t\\r\\n]",
 					chars:      []rune{' ', '\t', '\r', '\n'},
 					ignoreCase: false,
@@ -860,17 +2928,17 @@ var g = &grammar{
 		},
 		{
 			name: "A",
-			pos:  position{line: 195, col: 1, offset: 4824},
+			pos:  position{line: 368, col: 1, offset: 10588},
 			expr: &choiceExpr{
-				pos: position{line: 195, col: 7, offset: 4830},
+				pos: position{line: 368, col: 7, offset: 10594},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 195, col: 7, offset: 4830},
+						pos:        position{line: 368, col: 7, offset: 10594},
 						val:        "A",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 195, col: 13, offset: 4836},
+						pos:        position{line: 368, col: 13, offset: 10600},
 						val:        "a",
 						ignoreCase: false,
 					},
@@ -879,17 +2947,17 @@ var g = &grammar{
 		},
 		{
 			name: "B",
-			pos:  position{line: 196, col: 1, offset: 4842},
+			pos:  position{line: 369, col: 1, offset: 10605},
 			expr: &choiceExpr{
-				pos: position{line: 196, col: 7, offset: 4848},
+				pos: position{line: 369, col: 7, offset: 10611},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 196, col: 7, offset: 4848},
+						pos:        position{line: 369, col: 7, offset: 10611},
 						val:        "B",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 196, col: 13, offset: 4854},
+						pos:        position{line: 369, col: 13, offset: 10617},
 						val:        "b",
 						ignoreCase: false,
 					},
@@ -898,17 +2966,17 @@ var g = &grammar{
 		},
 		{
 			name: "C",
-			pos:  position{line: 197, col: 1, offset: 4860},
+			pos:  position{line: 370, col: 1, offset: 10622},
 			expr: &choiceExpr{
-				pos: position{line: 197, col: 7, offset: 4866},
+				pos: position{line: 370, col: 7, offset: 10628},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 197, col: 7, offset: 4866},
+						pos:        position{line: 370, col: 7, offset: 10628},
 						val:        "C",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 197, col: 13, offset: 4872},
+						pos:        position{line: 370, col: 13, offset: 10634},
 						val:        "c",
 						ignoreCase: false,
 					},
@@ -917,17 +2985,17 @@ var g = &grammar{
 		},
 		{
 			name: "D",
-			pos:  position{line: 198, col: 1, offset: 4878},
+			pos:  position{line: 371, col: 1, offset: 10639},
 			expr: &choiceExpr{
-				pos: position{line: 198, col: 7, offset: 4884},
+				pos: position{line: 371, col: 7, offset: 10645},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 198, col: 7, offset: 4884},
+						pos:        position{line: 371, col: 7, offset: 10645},
 						val:        "D",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 198, col: 13, offset: 4890},
+						pos:        position{line: 371, col: 13, offset: 10651},
 						val:        "d",
 						ignoreCase: false,
 					},
@@ -936,17 +3004,17 @@ var g = &grammar{
 		},
 		{
 			name: "E",
-			pos:  position{line: 199, col: 1, offset: 4896},
+			pos:  position{line: 372, col: 1, offset: 10656},
 			expr: &choiceExpr{
-				pos: position{line: 199, col: 7, offset: 4902},
+				pos: position{line: 372, col: 7, offset: 10662},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 199, col: 7, offset: 4902},
+						pos:        position{line: 372, col: 7, offset: 10662},
 						val:        "E",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 199, col: 13, offset: 4908},
+						pos:        position{line: 372, col: 13, offset: 10668},
 						val:        "e",
 						ignoreCase: false,
 					},
@@ -955,17 +3023,17 @@ var g = &grammar{
 		},
 		{
 			name: "F",
-			pos:  position{line: 200, col: 1, offset: 4914},
+			pos:  position{line: 373, col: 1, offset: 10673},
 			expr: &choiceExpr{
-				pos: position{line: 200, col: 7, offset: 4920},
+				pos: position{line: 373, col: 7, offset: 10679},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 200, col: 7, offset: 4920},
+						pos:        position{line: 373, col: 7, offset: 10679},
 						val:        "F",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 200, col: 13, offset: 4926},
+						pos:        position{line: 373, col: 13, offset: 10685},
 						val:        "f",
 						ignoreCase: false,
 					},
@@ -974,17 +3042,17 @@ var g = &grammar{
 		},
 		{
 			name: "G",
-			pos:  position{line: 201, col: 1, offset: 4932},
+			pos:  position{line: 374, col: 1, offset: 10690},
 			expr: &choiceExpr{
-				pos: position{line: 201, col: 7, offset: 4938},
+				pos: position{line: 374, col: 7, offset: 10696},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 201, col: 7, offset: 4938},
+						pos:        position{line: 374, col: 7, offset: 10696},
 						val:        "G",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 201, col: 13, offset: 4944},
+						pos:        position{line: 374, col: 13, offset: 10702},
 						val:        "g",
 						ignoreCase: false,
 					},
@@ -993,17 +3061,17 @@ var g = &grammar{
 		},
 		{
 			name: "H",
-			pos:  position{line: 202, col: 1, offset: 4950},
+			pos:  position{line: 375, col: 1, offset: 10707},
 			expr: &choiceExpr{
-				pos: position{line: 202, col: 7, offset: 4956},
+				pos: position{line: 375, col: 7, offset: 10713},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 202, col: 7, offset: 4956},
+						pos:        position{line: 375, col: 7, offset: 10713},
 						val:        "H",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 202, col: 13, offset: 4962},
+						pos:        position{line: 375, col: 13, offset: 10719},
 						val:        "h",
 						ignoreCase: false,
 					},
@@ -1012,17 +3080,17 @@ var g = &grammar{
 		},
 		{
 			name: "I",
-			pos:  position{line: 203, col: 1, offset: 4968},
+			pos:  position{line: 376, col: 1, offset: 10724},
 			expr: &choiceExpr{
-				pos: position{line: 203, col: 7, offset: 4974},
+				pos: position{line: 376, col: 7, offset: 10730},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 203, col: 7, offset: 4974},
+						pos:        position{line: 376, col: 7, offset: 10730},
 						val:        "I",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 203, col: 13, offset: 4980},
+						pos:        position{line: 376, col: 13, offset: 10736},
 						val:        "i",
 						ignoreCase: false,
 					},
@@ -1031,17 +3099,17 @@ var g = &grammar{
 		},
 		{
 			name: "K",
-			pos:  position{line: 204, col: 1, offset: 4986},
+			pos:  position{line: 377, col: 1, offset: 10741},
 			expr: &choiceExpr{
-				pos: position{line: 204, col: 7, offset: 4992},
+				pos: position{line: 377, col: 7, offset: 10747},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 204, col: 7, offset: 4992},
+						pos:        position{line: 377, col: 7, offset: 10747},
 						val:        "K",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 204, col: 13, offset: 4998},
+						pos:        position{line: 377, col: 13, offset: 10753},
 						val:        "k",
 						ignoreCase: false,
 					},
@@ -1050,17 +3118,17 @@ var g = &grammar{
 		},
 		{
 			name: "L",
-			pos:  position{line: 205, col: 1, offset: 5004},
+			pos:  position{line: 378, col: 1, offset: 10758},
 			expr: &choiceExpr{
-				pos: position{line: 205, col: 7, offset: 5010},
+				pos: position{line: 378, col: 7, offset: 10764},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 205, col: 7, offset: 5010},
+						pos:        position{line: 378, col: 7, offset: 10764},
 						val:        "L",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 205, col: 13, offset: 5016},
+						pos:        position{line: 378, col: 13, offset: 10770},
 						val:        "l",
 						ignoreCase: false,
 					},
@@ -1069,17 +3137,17 @@ var g = &grammar{
 		},
 		{
 			name: "M",
-			pos:  position{line: 206, col: 1, offset: 5022},
+			pos:  position{line: 379, col: 1, offset: 10775},
 			expr: &choiceExpr{
-				pos: position{line: 206, col: 7, offset: 5028},
+				pos: position{line: 379, col: 7, offset: 10781},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 206, col: 7, offset: 5028},
+						pos:        position{line: 379, col: 7, offset: 10781},
 						val:        "M",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 206, col: 13, offset: 5034},
+						pos:        position{line: 379, col: 13, offset: 10787},
 						val:        "m",
 						ignoreCase: false,
 					},
@@ -1088,17 +3156,17 @@ var g = &grammar{
 		},
 		{
 			name: "N",
-			pos:  position{line: 207, col: 1, offset: 5040},
+			pos:  position{line: 380, col: 1, offset: 10792},
 			expr: &choiceExpr{
-				pos: position{line: 207, col: 7, offset: 5046},
+				pos: position{line: 380, col: 7, offset: 10798},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 207, col: 7, offset: 5046},
+						pos:        position{line: 380, col: 7, offset: 10798},
 						val:        "N",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 207, col: 13, offset: 5052},
+						pos:        position{line: 380, col: 13, offset: 10804},
 						val:        "n",
 						ignoreCase: false,
 					},
@@ -1107,17 +3175,17 @@ var g = &grammar{
 		},
 		{
 			name: "O",
-			pos:  position{line: 208, col: 1, offset: 5058},
+			pos:  position{line: 381, col: 1, offset: 10809},
 			expr: &choiceExpr{
-				pos: position{line: 208, col: 7, offset: 5064},
+				pos: position{line: 381, col: 7, offset: 10815},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 208, col: 7, offset: 5064},
+						pos:        position{line: 381, col: 7, offset: 10815},
 						val:        "O",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 208, col: 13, offset: 5070},
+						pos:        position{line: 381, col: 13, offset: 10821},
 						val:        "o",
 						ignoreCase: false,
 					},
@@ -1126,17 +3194,17 @@ var g = &grammar{
 		},
 		{
 			name: "P",
-			pos:  position{line: 209, col: 1, offset: 5076},
+			pos:  position{line: 382, col: 1, offset: 10826},
 			expr: &choiceExpr{
-				pos: position{line: 209, col: 7, offset: 5082},
+				pos: position{line: 382, col: 7, offset: 10832},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 209, col: 7, offset: 5082},
+						pos:        position{line: 382, col: 7, offset: 10832},
 						val:        "P",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 209, col: 13, offset: 5088},
+						pos:        position{line: 382, col: 13, offset: 10838},
 						val:        "p",
 						ignoreCase: false,
 					},
@@ -1145,17 +3213,17 @@ var g = &grammar{
 		},
 		{
 			name: "Q",
-			pos:  position{line: 210, col: 1, offset: 5094},
+			pos:  position{line: 383, col: 1, offset: 10843},
 			expr: &choiceExpr{
-				pos: position{line: 210, col: 7, offset: 5100},
+				pos: position{line: 383, col: 7, offset: 10849},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 210, col: 7, offset: 5100},
+						pos:        position{line: 383, col: 7, offset: 10849},
 						val:        "Q",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 210, col: 13, offset: 5106},
+						pos:        position{line: 383, col: 13, offset: 10855},
 						val:        "q",
 						ignoreCase: false,
 					},
@@ -1164,17 +3232,17 @@ var g = &grammar{
 		},
 		{
 			name: "R",
-			pos:  position{line: 211, col: 1, offset: 5112},
+			pos:  position{line: 384, col: 1, offset: 10860},
 			expr: &choiceExpr{
-				pos: position{line: 211, col: 7, offset: 5118},
+				pos: position{line: 384, col: 7, offset: 10866},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 211, col: 7, offset: 5118},
+						pos:        position{line: 384, col: 7, offset: 10866},
 						val:        "R",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 211, col: 13, offset: 5124},
+						pos:        position{line: 384, col: 13, offset: 10872},
 						val:        "r",
 						ignoreCase: false,
 					},
@@ -1183,17 +3251,17 @@ var g = &grammar{
 		},
 		{
 			name: "S",
-			pos:  position{line: 212, col: 1, offset: 5130},
+			pos:  position{line: 385, col: 1, offset: 10877},
 			expr: &choiceExpr{
-				pos: position{line: 212, col: 7, offset: 5136},
+				pos: position{line: 385, col: 7, offset: 10883},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 212, col: 7, offset: 5136},
+						pos:        position{line: 385, col: 7, offset: 10883},
 						val:        "S",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 212, col: 13, offset: 5142},
+						pos:        position{line: 385, col: 13, offset: 10889},
 						val:        "s",
 						ignoreCase: false,
 					},
@@ -1202,17 +3270,17 @@ var g = &grammar{
 		},
 		{
 			name: "T",
-			pos:  position{line: 213, col: 1, offset: 5148},
+			pos:  position{line: 386, col: 1, offset: 10894},
 			expr: &choiceExpr{
-				pos: position{line: 213, col: 7, offset: 5154},
+				pos: position{line: 386, col: 7, offset: 10900},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 213, col: 7, offset: 5154},
+						pos:        position{line: 386, col: 7, offset: 10900},
 						val:        "T",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 213, col: 13, offset: 5160},
+						pos:        position{line: 386, col: 13, offset: 10906},
 						val:        "t",
 						ignoreCase: false,
 					},
@@ -1221,17 +3289,17 @@ var g = &grammar{
 		},
 		{
 			name: "U",
-			pos:  position{line: 214, col: 1, offset: 5166},
+			pos:  position{line: 387, col: 1, offset: 10911},
 			expr: &choiceExpr{
-				pos: position{line: 214, col: 7, offset: 5172},
+				pos: position{line: 387, col: 7, offset: 10917},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 214, col: 7, offset: 5172},
+						pos:        position{line: 387, col: 7, offset: 10917},
 						val:        "U",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 214, col: 13, offset: 5178},
+						pos:        position{line: 387, col: 13, offset: 10923},
 						val:        "u",
 						ignoreCase: false,
 					},
@@ -1240,17 +3308,17 @@ var g = &grammar{
 		},
 		{
 			name: "V",
-			pos:  position{line: 215, col: 1, offset: 5184},
+			pos:  position{line: 388, col: 1, offset: 10928},
 			expr: &choiceExpr{
-				pos: position{line: 215, col: 7, offset: 5190},
+				pos: position{line: 388, col: 7, offset: 10934},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 215, col: 7, offset: 5190},
+						pos:        position{line: 388, col: 7, offset: 10934},
 						val:        "V",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 215, col: 13, offset: 5196},
+						pos:        position{line: 388, col: 13, offset: 10940},
 						val:        "v",
 						ignoreCase: false,
 					},
@@ -1259,17 +3327,17 @@ var g = &grammar{
 		},
 		{
 			name: "W",
-			pos:  position{line: 216, col: 1, offset: 5202},
+			pos:  position{line: 389, col: 1, offset: 10945},
 			expr: &choiceExpr{
-				pos: position{line: 216, col: 7, offset: 5208},
+				pos: position{line: 389, col: 7, offset: 10951},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 216, col: 7, offset: 5208},
+						pos:        position{line: 389, col: 7, offset: 10951},
 						val:        "W",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 216, col: 13, offset: 5214},
+						pos:        position{line: 389, col: 13, offset: 10957},
 						val:        "w",
 						ignoreCase: false,
 					},
@@ -1278,17 +3346,17 @@ var g = &grammar{
 		},
 		{
 			name: "X",
-			pos:  position{line: 217, col: 1, offset: 5220},
+			pos:  position{line: 390, col: 1, offset: 10962},
 			expr: &choiceExpr{
-				pos: position{line: 217, col: 7, offset: 5226},
+				pos: position{line: 390, col: 7, offset: 10968},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 217, col: 7, offset: 5226},
+						pos:        position{line: 390, col: 7, offset: 10968},
 						val:        "X",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 217, col: 13, offset: 5232},
+						pos:        position{line: 390, col: 13, offset: 10974},
 						val:        "x",
 						ignoreCase: false,
 					},
@@ -1297,17 +3365,17 @@ var g = &grammar{
 		},
 		{
 			name: "Y",
-			pos:  position{line: 218, col: 1, offset: 5238},
+			pos:  position{line: 391, col: 1, offset: 10979},
 			expr: &choiceExpr{
-				pos: position{line: 218, col: 7, offset: 5244},
+				pos: position{line: 391, col: 7, offset: 10985},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 218, col: 7, offset: 5244},
+						pos:        position{line: 391, col: 7, offset: 10985},
 						val:        "Y",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 218, col: 13, offset: 5250},
+						pos:        position{line: 391, col: 13, offset: 10991},
 						val:        "y",
 						ignoreCase: false,
 					},
@@ -1316,18 +3384,54 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 220, col: 1, offset: 5258},
+			pos:  position{line: 393, col: 1, offset: 10997},
 			expr: &notExpr{
-				pos: position{line: 220, col: 8, offset: 5265},
+				pos: position{line: 393, col: 8, offset: 11004},
 				expr: &anyMatcher{
-					line: 220, col: 9, offset: 5266,
+					line: 393, col: 9, offset: 11005,
 				},
 			},
 		},
 	},
 }
 
-func (c *current) onStatement1(query interface{}) (interface{}, error) {
+func (c *current) onStatement2(command interface{}) (interface{}, error) {
+
+	index := command.(IndexCommand)
+	return QueryPlan{Index: &index}, nil
+}
+
+func (p *parser) callonStatement2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStatement2(stack["command"])
+}
+
+func (c *current) onStatement9(command interface{}) (interface{}, error) {
+
+	constraint := command.(ConstraintCommand)
+	return QueryPlan{Constraint: &constraint}, nil
+}
+
+func (p *parser) callonStatement9() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStatement9(stack["command"])
+}
+
+func (c *current) onStatement16(call interface{}) (interface{}, error) {
+
+	procedure := call.(ProcedureCall)
+	return QueryPlan{Call: &procedure}, nil
+}
+
+func (p *parser) callonStatement16() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStatement16(stack["call"])
+}
+
+func (c *current) onStatement23(query interface{}) (interface{}, error) {
 
 	q := QueryPlan{
 		ReadingClause: []ReadingClause{query.(ReadingClause)},
@@ -1336,10 +3440,229 @@ func (c *current) onStatement1(query interface{}) (interface{}, error) {
 	return q, nil
 }
 
-func (p *parser) callonStatement1() (interface{}, error) {
+func (p *parser) callonStatement23() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStatement23(stack["query"])
+}
+
+func (c *current) onCreateIndex1(kind, label, property interface{}) (interface{}, error) {
+
+	return IndexCommand{Range: kind != nil, Label: label.(string), Property: property.(string)}, nil
+}
+
+func (p *parser) callonCreateIndex1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCreateIndex1(stack["kind"], stack["label"], stack["property"])
+}
+
+func (c *current) onDropIndex1(kind, label, property interface{}) (interface{}, error) {
+
+	return IndexCommand{Drop: true, Range: kind != nil, Label: label.(string), Property: property.(string)}, nil
+}
+
+func (p *parser) callonDropIndex1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDropIndex1(stack["kind"], stack["label"], stack["property"])
+}
+
+func (c *current) onConstraintCommand1(drop, pattern, assertion interface{}) (interface{}, error) {
+
+	command := assertion.(ConstraintCommand)
+	target := pattern.(ConstraintCommand)
+
+	if command.Variable != target.Variable {
+		return nil, fmt.Errorf("Unknown variable %s in ASSERT", command.Variable)
+	}
+
+	command.Drop = drop.(bool)
+	command.Edge = target.Edge
+	command.Label = target.Label
+	return command, nil
+}
+
+func (p *parser) callonConstraintCommand1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onConstraintCommand1(stack["drop"], stack["pattern"], stack["assertion"])
+}
+
+func (c *current) onConstraintAction2() (interface{}, error) {
+	return false, nil
+}
+
+func (p *parser) callonConstraintAction2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onConstraintAction2()
+}
+
+func (c *current) onConstraintAction10() (interface{}, error) {
+	return true, nil
+}
+
+func (p *parser) callonConstraintAction10() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onConstraintAction10()
+}
+
+func (c *current) onConstraintPattern2(variable, label interface{}) (interface{}, error) {
+
+	return ConstraintCommand{Variable: variable.(string), Label: label.(string)}, nil
+}
+
+func (p *parser) callonConstraintPattern2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onConstraintPattern2(stack["variable"], stack["label"])
+}
+
+func (c *current) onConstraintPattern15(variable, label interface{}) (interface{}, error) {
+
+	return ConstraintCommand{Edge: true, Variable: variable.(string), Label: label.(string)}, nil
+}
+
+func (p *parser) callonConstraintPattern15() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onConstraintPattern15(stack["variable"], stack["label"])
+}
+
+func (c *current) onAssertion2(variable, property interface{}) (interface{}, error) {
+
+	return ConstraintCommand{Kind: "EXISTS", Variable: variable.(string), Property: property.(string)}, nil
+}
+
+func (p *parser) callonAssertion2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAssertion2(stack["variable"], stack["property"])
+}
+
+func (c *current) onAssertion20(variable, property interface{}) (interface{}, error) {
+
+	return ConstraintCommand{Kind: "UNIQUE", Variable: variable.(string), Property: property.(string)}, nil
+}
+
+func (p *parser) callonAssertion20() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAssertion20(stack["variable"], stack["property"])
+}
+
+func (c *current) onAssertion37(variable, property, valueType interface{}) (interface{}, error) {
+
+	return ConstraintCommand{Kind: "TYPED", Variable: variable.(string), Property: property.(string), ValueType: valueType.(string)}, nil
+}
+
+func (p *parser) callonAssertion37() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onAssertion37(stack["variable"], stack["property"], stack["valueType"])
+}
+
+func (c *current) onValueType2() (interface{}, error) {
+	return "STRING", nil
+}
+
+func (p *parser) callonValueType2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onValueType2()
+}
+
+func (c *current) onValueType10() (interface{}, error) {
+	return "INTEGER", nil
+}
+
+func (p *parser) callonValueType10() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onValueType10()
+}
+
+func (c *current) onValueType19() (interface{}, error) {
+	return "FLOAT", nil
+}
+
+func (p *parser) callonValueType19() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onValueType19()
+}
+
+func (c *current) onValueType26() (interface{}, error) {
+	return "BOOLEAN", nil
+}
+
+func (p *parser) callonValueType26() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onValueType26()
+}
+
+func (c *current) onCall1(name, args, yields interface{}) (interface{}, error) {
+
+	call := ProcedureCall{Name: name.(string)}
+
+	if args != nil {
+		a := toIfaceSlice(args)
+		call.Args = append(call.Args, a[0])
+		for _, rest := range toIfaceSlice(a[2]) {
+			call.Args = append(call.Args, toIfaceSlice(rest)[2])
+		}
+	}
+
+	if yields != nil {
+		y := toIfaceSlice(yields)
+		call.Yields = append(call.Yields, y[7].(string))
+		for _, rest := range toIfaceSlice(y[9]) {
+			call.Yields = append(call.Yields, toIfaceSlice(rest)[2].(string))
+		}
+	}
+
+	return call, nil
+}
+
+func (p *parser) callonCall1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onCall1(stack["name"], stack["args"], stack["yields"])
+}
+
+func (c *current) onProcedureName1() (interface{}, error) {
+
+	return string(c.text), nil
+}
+
+func (p *parser) callonProcedureName1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onProcedureName1()
+}
+
+func (c *current) onListLiteral1(items interface{}) (interface{}, error) {
+
+	list := []string{}
+
+	if items != nil {
+		i := toIfaceSlice(items)
+		list = append(list, i[0].(string))
+		for _, rest := range toIfaceSlice(i[2]) {
+			list = append(list, toIfaceSlice(rest)[2].(string))
+		}
+	}
+
+	return list, nil
+}
+
+func (p *parser) callonListLiteral1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onStatement1(stack["query"])
+	return p.cur.onListLiteral1(stack["items"])
 }
 
 func (c *current) onQuery1(regularQuery interface{}) (interface{}, error) {
@@ -1364,7 +3687,7 @@ func (p *parser) callonRegularQuery1() (interface{}, error) {
 	return p.cur.onRegularQuery1(stack["singleQuery"])
 }
 
-func (c *current) onSingleQuery1(matches, returns interface{}) (interface{}, error) {
+func (c *current) onSingleQuery1(matches, where, returns, order, limit interface{}) (interface{}, error) {
 
 	if returns == nil {
 		return nil, fmt.Errorf("RETURN missing and is required")
@@ -1394,13 +3717,155 @@ func (c *current) onSingleQuery1(matches, returns interface{}) (interface{}, err
 		}
 	}
 
+	if where != nil {
+		clause.Where = toIfaceSlice(where)[0].([]Predicate)
+		for _, p := range clause.Where {
+			if _, ok := expected[p.Variable]; !ok {
+				return nil, fmt.Errorf("Unknown variable %s in WHERE", p.Variable)
+			}
+		}
+	}
+
+	if order != nil {
+		orderBy := toIfaceSlice(order)[1].(OrderBy)
+		if _, ok := expected[orderBy.Variable]; !ok {
+			return nil, fmt.Errorf("Unknown variable %s in ORDER BY", orderBy.Variable)
+		}
+		clause.OrderBy = &orderBy
+	}
+
+	if limit != nil {
+		clause.Limit = int(toIfaceSlice(limit)[1].(int64))
+	}
+
 	return clause, nil
 }
 
 func (p *parser) callonSingleQuery1() (interface{}, error) {
 	stack := p.vstack[len(p.vstack)-1]
 	_ = stack
-	return p.cur.onSingleQuery1(stack["matches"], stack["returns"])
+	return p.cur.onSingleQuery1(stack["matches"], stack["where"], stack["returns"], stack["order"], stack["limit"])
+}
+
+func (c *current) onWhere1(predicate, extra interface{}) (interface{}, error) {
+
+	predicates := []Predicate{predicate.(Predicate)}
+	for _, p := range toIfaceSlice(extra) {
+		v := toIfaceSlice(p)
+		predicates = append(predicates, v[len(v)-2].(Predicate))
+	}
+	return predicates, nil
+}
+
+func (p *parser) callonWhere1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onWhere1(stack["predicate"], stack["extra"])
+}
+
+func (c *current) onPredicate1(variable, property, operator, value interface{}) (interface{}, error) {
+
+	p := Predicate{
+		Variable: variable.(string),
+		Property: property.(string),
+		Operator: operator.(string),
+	}
+
+	switch value.(type) {
+	case string:
+		p.Value = []byte(value.(string))
+	case int64:
+		p.Value = []byte(fmt.Sprintf("%d", value.(int64)))
+	case bool:
+		p.Value = []byte(fmt.Sprintf("%t", value.(bool)))
+	}
+
+	if p.Operator == "STARTS WITH" {
+		if _, ok := value.(string); !ok {
+			return nil, fmt.Errorf("STARTS WITH expects a string")
+		}
+	}
+
+	return p, nil
+}
+
+func (p *parser) callonPredicate1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onPredicate1(stack["variable"], stack["property"], stack["operator"], stack["value"])
+}
+
+func (c *current) onStartsWith1() (interface{}, error) {
+
+	return "STARTS WITH", nil
+}
+
+func (p *parser) callonStartsWith1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onStartsWith1()
+}
+
+func (c *current) onComparison1() (interface{}, error) {
+
+	return string(c.text), nil
+}
+
+func (p *parser) callonComparison1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onComparison1()
+}
+
+func (c *current) onOrderBy1(variable, property, descending interface{}) (interface{}, error) {
+
+	o := OrderBy{
+		Variable: variable.(string),
+		Property: property.(string),
+	}
+
+	if descending != nil {
+		o.Descending = toIfaceSlice(descending)[1].(bool)
+	}
+
+	return o, nil
+}
+
+func (p *parser) callonOrderBy1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onOrderBy1(stack["variable"], stack["property"], stack["descending"])
+}
+
+func (c *current) onDirection2() (interface{}, error) {
+	return true, nil
+}
+
+func (p *parser) callonDirection2() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDirection2()
+}
+
+func (c *current) onDirection16() (interface{}, error) {
+	return false, nil
+}
+
+func (p *parser) callonDirection16() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onDirection16()
+}
+
+func (c *current) onLimit1(count interface{}) (interface{}, error) {
+
+	return count, nil
+}
+
+func (p *parser) callonLimit1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onLimit1(stack["count"])
 }
 
 func (c *current) onReadingClause1(match interface{}) (interface{}, error) {
@@ -1477,10 +3942,6 @@ func (c *current) onNodeLabels1(label, labels interface{}) (interface{}, error)
 		l[i+1] = label.(string)
 	}
 
-	if len(l) > 1 {
-		return nil, fmt.Errorf("Draft nodes only support a single label")
-	}
-
 	return l, nil
 }
 
@@ -1587,7 +4048,7 @@ func (p *parser) callonString1() (interface{}, error) {
 
 func (c *current) onInteger1() (interface{}, error) {
 
-	return strconv.ParseInt(string(c.text), 10, 32)
+	return strconv.ParseInt(string(c.text), 10, 64)
 }
 
 func (p *parser) callonInteger1() (interface{}, error) {
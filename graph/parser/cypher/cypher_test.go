@@ -109,11 +109,32 @@ func TestMatchQueries(t *testing.T) {
 			Expected:    QueryPlan{},
 		},
 		TestCase{
-			Name:        "SingleMatchMultipleLabelNotSupported",
-			Query:       `MATCH (n:Person:Mutliple:Not:Supported)`,
+			Name:        "SingleMatchMultipleLabelNoReturn",
+			Query:       `MATCH (n:Person:Employee)`,
 			ShouldError: true,
 			Expected:    QueryPlan{},
 		},
+		TestCase{
+			Name:  "SingleMatchMultipleLabels",
+			Query: `MATCH (n:Person:Employee:Manager) RETURN n`,
+			Expected: QueryPlan{
+				ReadingClause: []ReadingClause{
+					ReadingClause{
+						Returns: []string{"n"},
+						Matches: []Match{
+							Match{
+								Nodes: []Node{
+									Node{
+										Variable: "n",
+										Labels:   []string{"Person", "Employee", "Manager"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		TestCase{
 			Name:  "SingleMatchSingleLabelWithMultipleProperties",
 			Query: `MATCH (n:Person {name: "Foo", surname: 'Bar', age: 21, active: true, address: "My address is private"}) RETURN n`,
@@ -161,3 +182,227 @@ func TestMatchQueries(t *testing.T) {
 		}
 	}
 }
+
+func TestIndexCommands(t *testing.T) {
+	tests := []TestCase{
+		TestCase{
+			Name:     "CreateIndex",
+			Query:    `CREATE INDEX ON :Person(email)`,
+			Expected: QueryPlan{Index: &IndexCommand{Label: "Person", Property: "email"}},
+		},
+		TestCase{
+			Name:     "CreateIndexLowercaseWithSpaces",
+			Query:    `create index on : Person ( email )`,
+			Expected: QueryPlan{Index: &IndexCommand{Label: "Person", Property: "email"}},
+		},
+		TestCase{
+			Name:     "DropIndex",
+			Query:    `DROP INDEX ON :Person(email)`,
+			Expected: QueryPlan{Index: &IndexCommand{Drop: true, Label: "Person", Property: "email"}},
+		},
+		TestCase{
+			Name:     "CreateRangeIndex",
+			Query:    `CREATE RANGE INDEX ON :Person(created_at)`,
+			Expected: QueryPlan{Index: &IndexCommand{Range: true, Label: "Person", Property: "created_at"}},
+		},
+		TestCase{
+			Name:     "DropRangeIndex",
+			Query:    `drop range index on :Person(created_at)`,
+			Expected: QueryPlan{Index: &IndexCommand{Drop: true, Range: true, Label: "Person", Property: "created_at"}},
+		},
+		TestCase{
+			Name:        "CreateIndexMissingProperty",
+			Query:       `CREATE INDEX ON :Person`,
+			ShouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := Parse("", []byte(test.Query))
+		if !test.ShouldError {
+			assert.Nil(t, err, "%s did not expect an error to be raises: %s (Query: %s)", test.Name, err, test.Query)
+			actual := got.(QueryPlan)
+			assert.Equal(t, test.Expected, actual, "%s expected %#v but got %#v", test.Name, test.Expected, actual)
+		} else {
+			assert.NotNil(t, err, "%s Expected query %s to fail", test.Name, test.Query)
+		}
+	}
+}
+
+func TestWhereOrderByLimit(t *testing.T) {
+	tests := []TestCase{
+		TestCase{
+			Name:  "WhereRangeAndPrefix",
+			Query: `MATCH (n:Person) WHERE n.created_at > 1000 AND n.created_at <= 2000 AND n.name STARTS WITH "Fo" RETURN n`,
+			Expected: QueryPlan{
+				ReadingClause: []ReadingClause{
+					ReadingClause{
+						Returns: []string{"n"},
+						Matches: []Match{
+							Match{Nodes: []Node{Node{Variable: "n", Labels: []string{"Person"}}}},
+						},
+						Where: []Predicate{
+							Predicate{Variable: "n", Property: "created_at", Operator: ">", Value: []byte("1000")},
+							Predicate{Variable: "n", Property: "created_at", Operator: "<=", Value: []byte("2000")},
+							Predicate{Variable: "n", Property: "name", Operator: "STARTS WITH", Value: []byte("Fo")},
+						},
+					},
+				},
+			},
+		},
+		TestCase{
+			Name: "OrderByDescendingLimit",
+			Query: `
+			MATCH (n:Person)
+			WHERE n.age <> -1
+			RETURN n
+			ORDER BY n.age DESC
+			LIMIT 10
+			`,
+			Expected: QueryPlan{
+				ReadingClause: []ReadingClause{
+					ReadingClause{
+						Returns: []string{"n"},
+						Matches: []Match{
+							Match{Nodes: []Node{Node{Variable: "n", Labels: []string{"Person"}}}},
+						},
+						Where: []Predicate{
+							Predicate{Variable: "n", Property: "age", Operator: "<>", Value: []byte("-1")},
+						},
+						OrderBy: &OrderBy{Variable: "n", Property: "age", Descending: true},
+						Limit:   10,
+					},
+				},
+			},
+		},
+		TestCase{
+			Name:  "OrderByAscending",
+			Query: `MATCH (n) RETURN n ORDER BY n.name ASC`,
+			Expected: QueryPlan{
+				ReadingClause: []ReadingClause{
+					ReadingClause{
+						Returns: []string{"n"},
+						Matches: []Match{
+							Match{Nodes: []Node{Node{Variable: "n"}}},
+						},
+						OrderBy: &OrderBy{Variable: "n", Property: "name"},
+					},
+				},
+			},
+		},
+		TestCase{
+			Name:        "WhereUnknownVariable",
+			Query:       `MATCH (n) WHERE m.age > 1 RETURN n`,
+			ShouldError: true,
+		},
+		TestCase{
+			Name:        "OrderByUnknownVariable",
+			Query:       `MATCH (n) RETURN n ORDER BY m.age`,
+			ShouldError: true,
+		},
+		TestCase{
+			Name:        "StartsWithNumber",
+			Query:       `MATCH (n) WHERE n.name STARTS WITH 1 RETURN n`,
+			ShouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := Parse("", []byte(test.Query))
+		if !test.ShouldError {
+			assert.Nil(t, err, "%s did not expect an error to be raises: %s (Query: %s)", test.Name, err, test.Query)
+			actual := got.(QueryPlan)
+			assert.Equal(t, test.Expected, actual, "%s expected %#v but got %#v", test.Name, test.Expected, actual)
+		} else {
+			assert.NotNil(t, err, "%s Expected query %s to fail", test.Name, test.Query)
+		}
+	}
+}
+
+func TestProcedureCalls(t *testing.T) {
+	tests := []TestCase{
+		TestCase{
+			Name:  "CreateNodeIndex",
+			Query: `CALL db.index.fulltext.createNodeIndex("people", ["Person", "Employee"], ["name"])`,
+			Expected: QueryPlan{
+				Call: &ProcedureCall{
+					Name: "db.index.fulltext.createNodeIndex",
+					Args: []interface{}{"people", []string{"Person", "Employee"}, []string{"name"}},
+				},
+			},
+		},
+		TestCase{
+			Name:  "QueryWithLimitAndYield",
+			Query: `call db.index.fulltext.query('people', "jon~ smi*", 10) YIELD node, score`,
+			Expected: QueryPlan{
+				Call: &ProcedureCall{
+					Name:   "db.index.fulltext.query",
+					Args:   []interface{}{"people", "jon~ smi*", int64(10)},
+					Yields: []string{"node", "score"},
+				},
+			},
+		},
+		TestCase{
+			Name:     "NoArguments",
+			Query:    `CALL db.labels()`,
+			Expected: QueryPlan{Call: &ProcedureCall{Name: "db.labels"}},
+		},
+		TestCase{
+			Name:        "MissingParentheses",
+			Query:       `CALL db.labels`,
+			ShouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := Parse("", []byte(test.Query))
+		if !test.ShouldError {
+			assert.Nil(t, err, "%s did not expect an error to be raises: %s (Query: %s)", test.Name, err, test.Query)
+			actual := got.(QueryPlan)
+			assert.Equal(t, test.Expected, actual, "%s expected %#v but got %#v", test.Name, test.Expected, actual)
+		} else {
+			assert.NotNil(t, err, "%s Expected query %s to fail", test.Name, test.Query)
+		}
+	}
+}
+
+func TestConstraintCommands(t *testing.T) {
+	tests := []TestCase{
+		TestCase{
+			Name:     "CreateUnique",
+			Query:    `CREATE CONSTRAINT ON (n:Person) ASSERT n.email IS UNIQUE`,
+			Expected: QueryPlan{Constraint: &ConstraintCommand{Variable: "n", Label: "Person", Property: "email", Kind: "UNIQUE"}},
+		},
+		TestCase{
+			Name:     "DropExists",
+			Query:    `drop constraint on (n:Person) assert exists(n.name)`,
+			Expected: QueryPlan{Constraint: &ConstraintCommand{Drop: true, Variable: "n", Label: "Person", Property: "name", Kind: "EXISTS"}},
+		},
+		TestCase{
+			Name:     "EdgeTyped",
+			Query:    `CREATE CONSTRAINT ON ()-[r:KNOWS]-() ASSERT r.since IS :: INTEGER`,
+			Expected: QueryPlan{Constraint: &ConstraintCommand{Edge: true, Variable: "r", Label: "KNOWS", Property: "since", Kind: "TYPED", ValueType: "INTEGER"}},
+		},
+		TestCase{
+			Name:        "UnknownVariable",
+			Query:       `CREATE CONSTRAINT ON (n:Person) ASSERT m.email IS UNIQUE`,
+			ShouldError: true,
+		},
+		TestCase{
+			Name:        "UnknownValueType",
+			Query:       `CREATE CONSTRAINT ON (n:Person) ASSERT n.age IS :: DATE`,
+			ShouldError: true,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := Parse("", []byte(test.Query))
+		if !test.ShouldError {
+			assert.Nil(t, err, "%s did not expect an error to be raises: %s (Query: %s)", test.Name, err, test.Query)
+			actual := got.(QueryPlan)
+			assert.Equal(t, test.Expected, actual, "%s expected %#v but got %#v", test.Name, test.Expected, actual)
+		} else {
+			assert.NotNil(t, err, "%s Expected query %s to fail", test.Name, test.Query)
+		}
+	}
+}
@@ -18,7 +18,28 @@ type KV struct {
 // ReadingClause is a immutable read/query.
 type ReadingClause struct {
 	Matches []Match
+	Where   []Predicate
 	Returns []string
+	OrderBy *OrderBy
+	// Limit is the maximum number of nodes matched by each node
+	// pattern, zero is unlimited.
+	Limit int
+}
+
+// Predicate is a property comparison in a WHERE clause. Operator is one of
+// "=", "<>", "<", "<=", ">", ">=" or "STARTS WITH".
+type Predicate struct {
+	Variable string
+	Property string
+	Operator string
+	Value    []byte
+}
+
+// OrderBy orders the matched nodes by a property.
+type OrderBy struct {
+	Variable   string
+	Property   string
+	Descending bool
 }
 
 // Match is the match query.
@@ -34,7 +55,40 @@ type Node struct {
 	Properties map[string][]byte
 }
 
+// IndexCommand creates or drops a property index on nodes with a label.
+// Range is set for ordered indexes.
+type IndexCommand struct {
+	Drop     bool
+	Range    bool
+	Label    string
+	Property string
+}
+
+// ConstraintCommand creates or drops a schema constraint on a property of
+// nodes or edges with a label. Kind is one of "UNIQUE", "EXISTS" or
+// "TYPED", and ValueType is one of "STRING", "INTEGER", "FLOAT" or
+// "BOOLEAN" for TYPED constraints.
+type ConstraintCommand struct {
+	Drop      bool
+	Edge      bool
+	Variable  string
+	Label     string
+	Property  string
+	Kind      string
+	ValueType string
+}
+
+// ProcedureCall calls a procedure. Args are strings, int64s or lists of strings.
+type ProcedureCall struct {
+	Name   string
+	Args   []interface{}
+	Yields []string
+}
+
 // QueryPlan is a query plan for applying a query.
 type QueryPlan struct {
 	ReadingClause []ReadingClause
+	Index         *IndexCommand
+	Constraint    *ConstraintCommand
+	Call          *ProcedureCall
 }
@@ -0,0 +1,78 @@
+package graph
+
+// NodePatch is a partial change to a node. The labels in RemoveLabels are
+// removed after adding AddLabels. The properties in DeleteProperties are
+// deleted after setting SetProperties. If Version is set, the patch fails
+// with a VersionError unless it is the current version of the node.
+type NodePatch struct {
+	AddLabels        []string
+	RemoveLabels     []string
+	SetProperties    map[string][]byte
+	DeleteProperties []string
+	Version          uint64
+}
+
+// apply returns a copy of the node with the patch applied.
+func (p NodePatch) apply(node Node) Node {
+	labels := append(append([]string{}, node.Labels...), p.AddLabels...)
+	remove := make(map[string]struct{}, len(p.RemoveLabels))
+	for _, label := range p.RemoveLabels {
+		remove[label] = struct{}{}
+	}
+
+	patched := node
+	patched.Labels = []string{}
+	for _, label := range uniqueLabels(labels) {
+		if _, ok := remove[label]; !ok {
+			patched.Labels = append(patched.Labels, label)
+		}
+	}
+
+	patched.Properties = patchProperties(node.Properties, p.SetProperties, p.DeleteProperties)
+	patched.Version = p.Version
+
+	return patched
+}
+
+// EdgePatch is a partial change to a edge. If Label is empty the label is
+// not changed. The properties in DeleteProperties are deleted after setting
+// SetProperties. If Version is set, the patch fails with a VersionError
+// unless it is the current version of the edge.
+type EdgePatch struct {
+	Label            string
+	SetProperties    map[string][]byte
+	DeleteProperties []string
+	Version          uint64
+}
+
+// apply returns a copy of the edge with the patch applied.
+func (p EdgePatch) apply(edge Edge) Edge {
+	patched := edge
+	if p.Label != "" {
+		patched.Label = p.Label
+	}
+
+	patched.Properties = patchProperties(edge.Properties, p.SetProperties, p.DeleteProperties)
+	patched.Version = p.Version
+
+	return patched
+}
+
+// patchProperties returns a copy of the properties with the keys set and
+// deleted.
+func patchProperties(props map[string][]byte, set map[string][]byte, deleted []string) map[string][]byte {
+	patched := make(map[string][]byte, len(props)+len(set))
+	for key, value := range props {
+		patched[key] = value
+	}
+
+	for key, value := range set {
+		patched[key] = value
+	}
+
+	for _, key := range deleted {
+		delete(patched, key)
+	}
+
+	return patched
+}
@@ -0,0 +1,334 @@
+package graph
+
+import (
+	"bytes"
+	"sort"
+)
+
+// Predicate is a condition used for filtering nodes and edges. Predicates
+// are built with HasLabel, HasSource, HasTarget, PropEquals, PropRange and
+// Degree, and combined with And, Or and Not.
+type Predicate interface {
+	// MatchNode returns true if the node meets the condition.
+	MatchNode(node Node) bool
+	// MatchEdge returns true if the edge meets the condition.
+	MatchEdge(edge Edge) bool
+}
+
+// andPredicate matches when all of the predicates match.
+type andPredicate []Predicate
+
+// And returns a predicate matching when all of the predicates match.
+// And without any predicates matches everything.
+func And(predicates ...Predicate) Predicate {
+	return andPredicate(predicates)
+}
+
+// MatchNode returns true if the node matches all of the predicates.
+func (p andPredicate) MatchNode(node Node) bool {
+	for _, child := range p {
+		if !child.MatchNode(node) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchEdge returns true if the edge matches all of the predicates.
+func (p andPredicate) MatchEdge(edge Edge) bool {
+	for _, child := range p {
+		if !child.MatchEdge(edge) {
+			return false
+		}
+	}
+	return true
+}
+
+// orPredicate matches when any of the predicates match.
+type orPredicate []Predicate
+
+// Or returns a predicate matching when any of the predicates match.
+// Or without any predicates matches nothing.
+func Or(predicates ...Predicate) Predicate {
+	return orPredicate(predicates)
+}
+
+// MatchNode returns true if the node matches any of the predicates.
+func (p orPredicate) MatchNode(node Node) bool {
+	for _, child := range p {
+		if child.MatchNode(node) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchEdge returns true if the edge matches any of the predicates.
+func (p orPredicate) MatchEdge(edge Edge) bool {
+	for _, child := range p {
+		if child.MatchEdge(edge) {
+			return true
+		}
+	}
+	return false
+}
+
+// notPredicate matches when the predicate does not match.
+type notPredicate struct {
+	predicate Predicate
+}
+
+// Not returns a predicate matching when the predicate does not match.
+func Not(predicate Predicate) Predicate {
+	return notPredicate{predicate: predicate}
+}
+
+// MatchNode returns true if the node does not match the predicate.
+func (p notPredicate) MatchNode(node Node) bool {
+	return !p.predicate.MatchNode(node)
+}
+
+// MatchEdge returns true if the edge does not match the predicate.
+func (p notPredicate) MatchEdge(edge Edge) bool {
+	return !p.predicate.MatchEdge(edge)
+}
+
+// labelPredicate matches nodes having the label and edges with the label.
+type labelPredicate struct {
+	label string
+}
+
+// HasLabel returns a predicate matching the nodes having the label and
+// the edges with the label.
+func HasLabel(label string) Predicate {
+	return labelPredicate{label: label}
+}
+
+// MatchNode returns true if the node has the label.
+func (p labelPredicate) MatchNode(node Node) bool {
+	return node.HasLabel(p.label)
+}
+
+// MatchEdge returns true if the edge has the label.
+func (p labelPredicate) MatchEdge(edge Edge) bool {
+	return edge.Label == p.label
+}
+
+// sourcePredicate matches edges leaving a node.
+type sourcePredicate struct {
+	uid string
+}
+
+// HasSource returns a predicate matching the edges leaving the node with
+// the uid. Nodes never match.
+func HasSource(uid string) Predicate {
+	return sourcePredicate{uid: uid}
+}
+
+// MatchNode always returns false.
+func (p sourcePredicate) MatchNode(node Node) bool {
+	return false
+}
+
+// MatchEdge returns true if the edge leaves the node.
+func (p sourcePredicate) MatchEdge(edge Edge) bool {
+	return edge.SourceUID == p.uid
+}
+
+// targetPredicate matches edges coming into a node.
+type targetPredicate struct {
+	uid string
+}
+
+// HasTarget returns a predicate matching the edges coming into the node
+// with the uid. Nodes never match.
+func HasTarget(uid string) Predicate {
+	return targetPredicate{uid: uid}
+}
+
+// MatchNode always returns false.
+func (p targetPredicate) MatchNode(node Node) bool {
+	return false
+}
+
+// MatchEdge returns true if the edge comes into the node.
+func (p targetPredicate) MatchEdge(edge Edge) bool {
+	return edge.TargetUID == p.uid
+}
+
+// propEqualsPredicate matches a exact property value.
+type propEqualsPredicate struct {
+	key   string
+	value []byte
+}
+
+// PropEquals returns a predicate matching the nodes and edges having the
+// property with exactly the value.
+func PropEquals(key string, value []byte) Predicate {
+	return propEqualsPredicate{key: key, value: value}
+}
+
+// MatchNode returns true if the node has the property value.
+func (p propEqualsPredicate) MatchNode(node Node) bool {
+	return p.match(node.Properties)
+}
+
+// MatchEdge returns true if the edge has the property value.
+func (p propEqualsPredicate) MatchEdge(edge Edge) bool {
+	return p.match(edge.Properties)
+}
+
+// match returns true if the properties have the property value.
+func (p propEqualsPredicate) match(props map[string][]byte) bool {
+	value, ok := props[p.key]
+	return ok && bytes.Equal(value, p.value)
+}
+
+// propRangePredicate matches a property value in a range.
+type propRangePredicate struct {
+	key    string
+	values valueRange
+}
+
+// PropRange returns a predicate matching the nodes and edges having the
+// property with a value in the range. Values are compared the same way
+// range indexes order them. The zero Range matches any value, so it
+// matches everything having the property.
+func PropRange(key string, r Range) Predicate {
+	return propRangePredicate{key: key, values: r.compile()}
+}
+
+// MatchNode returns true if the node has a property value in the range.
+func (p propRangePredicate) MatchNode(node Node) bool {
+	return p.match(node.Properties)
+}
+
+// MatchEdge returns true if the edge has a property value in the range.
+func (p propRangePredicate) MatchEdge(edge Edge) bool {
+	return p.match(edge.Properties)
+}
+
+// match returns true if the properties have a property value in the range.
+func (p propRangePredicate) match(props map[string][]byte) bool {
+	value, ok := props[p.key]
+	return ok && p.values.contains(decodeValue(value))
+}
+
+// degreePredicate matches nodes by their number of edges.
+type degreePredicate struct {
+	direction Direction
+	min, max  int
+}
+
+// Degree returns a predicate matching the nodes with between min and max
+// edges, inclusive, in the direction. A negative max is unbounded. A edge
+// from a node to itself is counted as both inbound and outbound. Edges
+// never match.
+func Degree(direction Direction, min, max int) Predicate {
+	return degreePredicate{direction: direction, min: min, max: max}
+}
+
+// MatchNode returns true if the node has between min and max edges.
+func (p degreePredicate) MatchNode(node Node) bool {
+	degree := 0
+	switch p.direction {
+	case BOTH:
+		degree = len(node.inEdges) + len(node.outEdges)
+	case IN:
+		degree = len(node.inEdges)
+	case OUT:
+		degree = len(node.outEdges)
+	}
+	return degree >= p.min && (p.max < 0 || degree <= p.max)
+}
+
+// MatchEdge always returns false.
+func (p degreePredicate) MatchEdge(edge Edge) bool {
+	return false
+}
+
+// needsEdges returns true if matching the predicate needs the edges of
+// the nodes. Predicates from outside the package are assumed to need them.
+func needsEdges(p Predicate) bool {
+	switch p := p.(type) {
+	case andPredicate:
+		for _, child := range p {
+			if needsEdges(child) {
+				return true
+			}
+		}
+		return false
+	case orPredicate:
+		for _, child := range p {
+			if needsEdges(child) {
+				return true
+			}
+		}
+		return false
+	case notPredicate:
+		return needsEdges(p.predicate)
+	case labelPredicate, sourcePredicate, targetPredicate, propEqualsPredicate, propRangePredicate:
+		return false
+	}
+	return true
+}
+
+// labelsPredicate returns the predicate matching nodes with any or all of
+// the labels. A empty list of labels matches everything.
+func labelsPredicate(labels []string, match LabelMatch) Predicate {
+	if len(labels) == 0 {
+		return And()
+	}
+
+	predicates := make([]Predicate, len(labels))
+	for i, label := range labels {
+		predicates[i] = HasLabel(label)
+	}
+
+	if match == ANY {
+		return Or(predicates...)
+	}
+	return And(predicates...)
+}
+
+// propsPredicates returns the predicates matching each of the property
+// values, in key order.
+func propsPredicates(props map[string][]byte) []Predicate {
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	predicates := make([]Predicate, len(keys))
+	for i, key := range keys {
+		predicates[i] = PropEquals(key, props[key])
+	}
+	return predicates
+}
+
+// NodesByPredicate returns the predicate matching the nodes filtered by
+// NodesBy, for combining with other predicates.
+func NodesByPredicate(labels []string, match LabelMatch, props map[string][]byte) Predicate {
+	return And(append([]Predicate{labelsPredicate(labels, match)}, propsPredicates(props)...)...)
+}
+
+// EdgesByPredicate returns the predicate matching the edges filtered by
+// EdgesBy, for combining with other predicates.
+func EdgesByPredicate(source string, labels []string, target string, props map[string][]byte) Predicate {
+	predicates := []Predicate{}
+
+	if source != "" {
+		predicates = append(predicates, HasSource(source))
+	}
+
+	if target != "" {
+		predicates = append(predicates, HasTarget(target))
+	}
+
+	if len(labels) > 0 {
+		predicates = append(predicates, labelsPredicate(labels, ANY))
+	}
+
+	return And(append(predicates, propsPredicates(props)...)...)
+}
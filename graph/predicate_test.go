@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredicate_MatchNode(t *testing.T) {
+	node := NewNode("node-1", []string{"person", "employee"}, KV{Key: "age", Value: []byte("21")}, KV{Key: "name", Value: []byte("foo")})
+	node.inEdges["edge-1"] = struct{}{}
+	node.outEdges["edge-2"] = struct{}{}
+	node.outEdges["edge-3"] = struct{}{}
+
+	tests := []struct {
+		name      string
+		predicate Predicate
+		expected  bool
+	}{
+		{"label", HasLabel("person"), true},
+		{"missing label", HasLabel("pet"), false},
+		{"equals", PropEquals("name", []byte("foo")), true},
+		{"equals bytes", PropEquals("age", []byte("21.0")), false},
+		{"range", PropRange("age", Range{Min: []byte("18"), Max: []byte("21.0")}), true},
+		{"range missing property", PropRange("height", Range{}), false},
+		{"prefix", PropRange("name", PrefixRange([]byte("f"))), true},
+		{"degree", Degree(BOTH, 3, 3), true},
+		{"degree in", Degree(IN, 2, -1), false},
+		{"degree out", Degree(OUT, 0, 2), true},
+		{"source", HasSource("node-1"), false},
+		{"and", And(HasLabel("person"), HasLabel("pet")), false},
+		{"or", Or(HasLabel("person"), HasLabel("pet")), true},
+		{"not", Not(HasLabel("pet")), true},
+		{"empty and", And(), true},
+		{"empty or", Or(), false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.predicate.MatchNode(node), tt.name)
+	}
+}
+
+func TestPredicate_MatchEdge(t *testing.T) {
+	edge := NewEdge("edge-1", "node-1", "knows", "node-2", KV{Key: "since", Value: []byte("2020")})
+
+	tests := []struct {
+		name      string
+		predicate Predicate
+		expected  bool
+	}{
+		{"label", HasLabel("knows"), true},
+		{"source", HasSource("node-1"), true},
+		{"target", HasTarget("node-1"), false},
+		{"range", PropRange("since", Range{Max: []byte("2020"), MaxExclusive: true}), false},
+		{"degree", Degree(BOTH, 0, -1), false},
+		{"and", And(HasSource("node-1"), HasTarget("node-2"), Not(PropEquals("since", []byte("2021")))), true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.predicate.MatchEdge(edge), tt.name)
+	}
+}
+
+func TestNodesWhere(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		g := New()
+		if indexed {
+			g.CreateIndex("person", "team")
+		}
+
+		g.AddNode("node-1", []string{"person"}, KV{Key: "team", Value: []byte("red")}, KV{Key: "age", Value: []byte("30")})
+		g.AddNode("node-2", []string{"person"}, KV{Key: "team", Value: []byte("blue")}, KV{Key: "age", Value: []byte("9")})
+		g.AddNode("node-3", []string{"person"}, KV{Key: "team", Value: []byte("red")}, KV{Key: "age", Value: []byte("100")})
+		g.AddNode("node-4", []string{"pet"}, KV{Key: "team", Value: []byte("red")})
+		g.AddEdge("edge-1", "node-1", "owns", "node-4")
+
+		tests := []struct {
+			predicate Predicate
+			expected  []string
+		}{
+			{nil, []string{"node-1", "node-2", "node-3", "node-4"}},
+			{And(HasLabel("person"), PropEquals("team", []byte("red"))), []string{"node-1", "node-3"}},
+			{And(Or(HasLabel("person"), HasLabel("pet")), PropEquals("team", []byte("red"))), []string{"node-1", "node-3", "node-4"}},
+			{And(HasLabel("person"), PropRange("age", Range{Min: []byte("10")})), []string{"node-1", "node-3"}},
+			{And(HasLabel("person"), Not(PropEquals("team", []byte("red")))), []string{"node-2"}},
+			{Degree(BOTH, 1, -1), []string{"node-1", "node-4"}},
+			{Or(), []string{}},
+		}
+
+		for _, tt := range tests {
+			assert.Equal(t, tt.expected, collectUIDs(g.NodesWhere(tt.predicate)), "indexed: %t %v", indexed, tt.predicate)
+		}
+	}
+}
+
+func TestEdgesWhere(t *testing.T) {
+	g := New()
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2", KV{Key: "since", Value: []byte("2019")})
+	g.AddEdge("edge-2", "node-2", "knows", "node-1", KV{Key: "since", Value: []byte("2021")})
+	g.AddEdge("edge-3", "node-1", "likes", "node-2")
+
+	tests := []struct {
+		predicate Predicate
+		expected  []string
+	}{
+		{nil, []string{"edge-1", "edge-2", "edge-3"}},
+		{HasSource("node-1"), []string{"edge-1", "edge-3"}},
+		{And(HasTarget("node-2"), HasLabel("knows")), []string{"edge-1"}},
+		{Or(HasSource("node-2"), HasLabel("likes")), []string{"edge-2", "edge-3"}},
+		{PropRange("since", Range{Min: []byte("2020")}), []string{"edge-2"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, collectUIDs(g.EdgesWhere(tt.predicate)), "%v", tt.predicate)
+	}
+}
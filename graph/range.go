@@ -0,0 +1,197 @@
+package graph
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// valueKind is the type a property value is ordered as.
+type valueKind int
+
+const (
+	// numberValue is a property value which parses as a number.
+	numberValue valueKind = iota
+	// stringValue is any other property value.
+	stringValue
+)
+
+// orderedValue is a property value decoded for ordering. Numbers are
+// ordered before strings, numbers are compared numerically and strings
+// are compared byte wise.
+type orderedValue struct {
+	kind   valueKind
+	number float64
+	text   string
+}
+
+// decodeValue decodes the raw property value into a ordered value.
+func decodeValue(value []byte) orderedValue {
+	if n, err := strconv.ParseFloat(string(value), 64); err == nil && !math.IsNaN(n) && !math.IsInf(n, 0) {
+		return orderedValue{kind: numberValue, number: n}
+	}
+	return orderedValue{kind: stringValue, text: string(value)}
+}
+
+// compare returns -1, 0 or 1 if v is less than, equal to or greater than other.
+func (v orderedValue) compare(other orderedValue) int {
+	switch {
+	case v.kind != other.kind:
+		if v.kind < other.kind {
+			return -1
+		}
+		return 1
+	case v.kind == numberValue:
+		switch {
+		case v.number < other.number:
+			return -1
+		case v.number > other.number:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(v.text, other.text)
+}
+
+// CompareValues compares two property values the same way range indexes
+// order them and returns -1, 0 or 1 if a is less than, equal to or
+// greater than b.
+func CompareValues(a, b []byte) int {
+	return decodeValue(a).compare(decodeValue(b))
+}
+
+// Range is a range of property values. A nil Min or Max leaves that end
+// of the range unbounded. Values which parse as numbers are ordered and
+// compared as numbers, so a bound only matches values of the same kind,
+// and a Prefix only matches string values.
+type Range struct {
+	Min          []byte
+	Max          []byte
+	MinExclusive bool
+	MaxExclusive bool
+	Prefix       []byte
+}
+
+// PrefixRange returns a range matching the string values starting with prefix.
+func PrefixRange(prefix []byte) Range {
+	return Range{Prefix: prefix}
+}
+
+// Contains returns true if the value is in the range.
+func (r Range) Contains(value []byte) bool {
+	return r.compile().contains(decodeValue(value))
+}
+
+// valueRange is a range with the bounds decoded.
+type valueRange struct {
+	min, max         *orderedValue
+	minExcl, maxExcl bool
+	prefix           *string
+}
+
+// compile decodes the range bounds.
+func (r Range) compile() valueRange {
+	vr := valueRange{minExcl: r.MinExclusive, maxExcl: r.MaxExclusive}
+
+	if r.Min != nil {
+		min := decodeValue(r.Min)
+		vr.min = &min
+	}
+
+	if r.Max != nil {
+		max := decodeValue(r.Max)
+		vr.max = &max
+	}
+
+	if r.Prefix != nil {
+		prefix := string(r.Prefix)
+		vr.prefix = &prefix
+	}
+
+	return vr
+}
+
+// kind returns the kind of values the range is restricted to, if any.
+func (r valueRange) kind() (valueKind, bool) {
+	switch {
+	case r.prefix != nil:
+		return stringValue, true
+	case r.min != nil:
+		return r.min.kind, true
+	case r.max != nil:
+		return r.max.kind, true
+	}
+	return 0, false
+}
+
+// contains returns true if the value is in the range.
+func (r valueRange) contains(v orderedValue) bool {
+	if r.prefix != nil && (v.kind != stringValue || !strings.HasPrefix(v.text, *r.prefix)) {
+		return false
+	}
+
+	if r.min != nil {
+		if v.kind != r.min.kind {
+			return false
+		}
+		if c := v.compare(*r.min); c < 0 || c == 0 && r.minExcl {
+			return false
+		}
+	}
+
+	if r.max != nil {
+		if v.kind != r.max.kind {
+			return false
+		}
+		if c := v.compare(*r.max); c > 0 || c == 0 && r.maxExcl {
+			return false
+		}
+	}
+
+	return true
+}
+
+// above returns true if the value, and every value ordered after it, is
+// past the upper end of the range.
+func (r valueRange) above(v orderedValue) bool {
+	if kind, ok := r.kind(); ok && v.kind > kind {
+		return true
+	}
+
+	if r.max != nil {
+		if c := v.compare(*r.max); c > 0 || c == 0 && r.maxExcl {
+			return true
+		}
+	}
+
+	return r.prefix != nil && v.kind == stringValue && v.text > *r.prefix && !strings.HasPrefix(v.text, *r.prefix)
+}
+
+// below returns true if the value, and every value ordered before it, is
+// past the lower end of the range.
+func (r valueRange) below(v orderedValue) bool {
+	if kind, ok := r.kind(); ok && v.kind < kind {
+		return true
+	}
+
+	if r.min != nil {
+		if c := v.compare(*r.min); c < 0 || c == 0 && r.minExcl {
+			return true
+		}
+	}
+
+	return r.prefix != nil && v.kind == stringValue && v.text < *r.prefix
+}
+
+// prefixEnd returns the smallest string greater than every string starting
+// with prefix. False is returned if there is no such string.
+func prefixEnd(prefix string) (string, bool) {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1]), true
+		}
+	}
+	return "", false
+}
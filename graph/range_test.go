@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompareValues(t *testing.T) {
+	assert.Equal(t, -1, CompareValues([]byte("9"), []byte("10")))
+	assert.Equal(t, 0, CompareValues([]byte("1.0"), []byte("1")))
+	assert.Equal(t, 1, CompareValues([]byte("b"), []byte("a")))
+	// numbers are ordered before strings
+	assert.Equal(t, -1, CompareValues([]byte("100"), []byte("abc")))
+}
+
+func TestRange_Contains(t *testing.T) {
+	r := Range{Min: []byte("10"), Max: []byte("20"), MaxExclusive: true}
+	assert.True(t, r.Contains([]byte("10")))
+	assert.True(t, r.Contains([]byte("19.5")))
+	assert.False(t, r.Contains([]byte("20")))
+	assert.False(t, r.Contains([]byte("9")))
+	assert.False(t, r.Contains([]byte("15abc")))
+
+	prefix := PrefixRange([]byte("fo"))
+	assert.True(t, prefix.Contains([]byte("foo")))
+	assert.False(t, prefix.Contains([]byte("bar")))
+
+	assert.True(t, Range{}.Contains([]byte("anything")))
+}
+
+func TestPrefixEnd(t *testing.T) {
+	end, ok := prefixEnd("ab")
+	assert.True(t, ok)
+	assert.Equal(t, "ac", end)
+
+	end, ok = prefixEnd("a\xff")
+	assert.True(t, ok)
+	assert.Equal(t, "b", end)
+
+	_, ok = prefixEnd("\xff")
+	assert.False(t, ok)
+}
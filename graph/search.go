@@ -0,0 +1,336 @@
+package graph
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/btree"
+)
+
+const (
+	// bm25K1 controls how quickly repeated terms stop adding to the score.
+	bm25K1 = 1.2
+	// bm25B controls how much long property values are penalised.
+	bm25B = 0.75
+	// prefixWeight scales the score of terms matched by a prefix.
+	prefixWeight = 0.7
+	// maxFuzzyEdits is the largest edit distance allowed for fuzzy terms.
+	maxFuzzyEdits = 2
+)
+
+// SearchIndexDef is a full-text search index over properties of nodes or
+// edges. Nodes with any of the labels, or edges with one of the labels,
+// are indexed. If labels is an empty list, all the nodes or edges are indexed.
+type SearchIndexDef struct {
+	Name       string   `json:"name"`
+	Type       ItemType `json:"type"`
+	Labels     []string `json:"labels"`
+	Properties []string `json:"properties"`
+}
+
+// SearchResult is a node or edge found by a search. Type tells if Node or
+// Edge is set.
+type SearchResult struct {
+	Type  ItemType
+	Score float64
+	Node  Node
+	Edge  Edge
+}
+
+// tokenize splits the text into lower cased words of letters and digits.
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// termItem is a indexed term kept in order for prefix matching.
+type termItem string
+
+// Less orders the terms byte wise.
+func (t termItem) Less(than btree.Item) bool {
+	return t < than.(termItem)
+}
+
+// searchDoc is the terms of a indexed node or edge.
+type searchDoc struct {
+	// freqs is the frequency of each term.
+	freqs map[string]int
+	// length is the total number of terms.
+	length int
+}
+
+// searchIndex is a inverted index of terms to the nodes or edges containing
+// them. The index spans all the shards, so it has its own lock.
+type searchIndex struct {
+	def  SearchIndexDef
+	lock sync.Mutex
+	docs map[string]searchDoc
+	// postings maps a term to the frequency of the term in each uid.
+	postings map[string]map[string]int
+	// lengths is the total number of terms in all the docs.
+	lengths int
+	terms   *btree.BTree
+}
+
+// newSearchIndex returns a new empty search index.
+func newSearchIndex(def SearchIndexDef) *searchIndex {
+	return &searchIndex{
+		def:      def,
+		docs:     make(map[string]searchDoc),
+		postings: make(map[string]map[string]int),
+		terms:    btree.New(32),
+	}
+}
+
+// covers returns true if a element with the labels is indexed.
+func (idx *searchIndex) covers(labels []string) bool {
+	if len(idx.def.Labels) == 0 {
+		return true
+	}
+
+	for _, label := range labels {
+		for _, indexed := range idx.def.Labels {
+			if label == indexed {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// add indexes the configured properties of the element.
+func (idx *searchIndex) add(uid string, labels []string, props map[string][]byte) {
+	if !idx.covers(labels) {
+		return
+	}
+
+	doc := searchDoc{freqs: make(map[string]int)}
+	for _, prop := range idx.def.Properties {
+		for _, term := range tokenize(string(props[prop])) {
+			doc.freqs[term]++
+			doc.length++
+		}
+	}
+
+	if doc.length == 0 {
+		return
+	}
+
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	idx.docs[uid] = doc
+	idx.lengths += doc.length
+
+	for term, freq := range doc.freqs {
+		uids, ok := idx.postings[term]
+		if !ok {
+			uids = make(map[string]int)
+			idx.postings[term] = uids
+			idx.terms.ReplaceOrInsert(termItem(term))
+		}
+		uids[uid] = freq
+	}
+}
+
+// remove removes the element from the index.
+func (idx *searchIndex) remove(uid string) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	doc, ok := idx.docs[uid]
+	if !ok {
+		return
+	}
+
+	delete(idx.docs, uid)
+	idx.lengths -= doc.length
+
+	for term := range doc.freqs {
+		uids := idx.postings[term]
+		delete(uids, uid)
+		if len(uids) == 0 {
+			delete(idx.postings, term)
+			idx.terms.Delete(termItem(term))
+		}
+	}
+}
+
+// searchTerm is a single term of a search query.
+type searchTerm struct {
+	text   string
+	prefix bool
+	edits  int
+}
+
+// parseSearchQuery parses the search query. Words ending in `*` match
+// terms starting with the word and words ending in `~` match terms within
+// one edit of the word, or `~2` within two edits.
+func parseSearchQuery(query string) []searchTerm {
+	terms := []searchTerm{}
+
+	for _, word := range strings.Fields(query) {
+		prefix := false
+		edits := 0
+
+		switch {
+		case strings.HasSuffix(word, "*"):
+			prefix = true
+			word = strings.TrimRight(word, "*")
+		case strings.Contains(word, "~"):
+			i := strings.LastIndex(word, "~")
+			edits = 1
+			if n, err := strconv.Atoi(word[i+1:]); err == nil {
+				edits = n
+			}
+			if edits > maxFuzzyEdits {
+				edits = maxFuzzyEdits
+			}
+			word = word[:i]
+		}
+
+		tokens := tokenize(word)
+		for i, token := range tokens {
+			term := searchTerm{text: token}
+			// The modifier only applies to the last token of the word.
+			if i == len(tokens)-1 {
+				term.prefix = prefix
+				term.edits = edits
+			}
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
+
+// expand returns the indexed terms matched by the search term with the
+// weight given to each of them.
+func (idx *searchIndex) expand(term searchTerm) map[string]float64 {
+	matched := make(map[string]float64)
+
+	if _, ok := idx.postings[term.text]; ok {
+		matched[term.text] = 1
+	}
+
+	if term.prefix {
+		idx.terms.AscendGreaterOrEqual(termItem(term.text), func(i btree.Item) bool {
+			t := string(i.(termItem))
+			if !strings.HasPrefix(t, term.text) {
+				return false
+			}
+			if t != term.text {
+				matched[t] = prefixWeight
+			}
+			return true
+		})
+	}
+
+	if term.edits > 0 {
+		text := []rune(term.text)
+		for t := range idx.postings {
+			if t == term.text {
+				continue
+			}
+			if d := editDistance(text, []rune(t), term.edits); d <= term.edits {
+				matched[t] = 1 / float64(1+d)
+			}
+		}
+	}
+
+	return matched
+}
+
+// search returns the uids matching the query with their relevance score.
+// The score is the BM25 score of the matched terms, scaled down for terms
+// matched by a prefix or fuzzy match.
+func (idx *searchIndex) search(query string) map[string]float64 {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+
+	scores := make(map[string]float64)
+	if len(idx.docs) == 0 {
+		return scores
+	}
+
+	count := float64(len(idx.docs))
+	avgLength := float64(idx.lengths) / count
+
+	for _, term := range parseSearchQuery(query) {
+		// best is the best score for this query term in each doc, so a
+		// fuzzy term matching several similar words is not counted twice.
+		best := make(map[string]float64)
+
+		for t, weight := range idx.expand(term) {
+			uids := idx.postings[t]
+			df := float64(len(uids))
+			idf := math.Log(1 + (count-df+0.5)/(df+0.5))
+
+			for uid, freq := range uids {
+				tf := float64(freq)
+				length := float64(idx.docs[uid].length)
+				score := weight * idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*length/avgLength))
+				if score > best[uid] {
+					best[uid] = score
+				}
+			}
+		}
+
+		for uid, score := range best {
+			scores[uid] += score
+		}
+	}
+
+	return scores
+}
+
+// editDistance returns the Levenshtein distance between a and b, or
+// max+1 once the distance is known to be larger than max.
+func editDistance(a, b []rune, max int) int {
+	if d := len(a) - len(b); d > max || -d > max {
+		return max + 1
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		smallest := curr[0]
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = prev[j-1] + cost
+			if prev[j]+1 < curr[j] {
+				curr[j] = prev[j] + 1
+			}
+			if curr[j-1]+1 < curr[j] {
+				curr[j] = curr[j-1] + 1
+			}
+
+			if curr[j] < smallest {
+				smallest = curr[j]
+			}
+		}
+
+		if smallest > max {
+			return max + 1
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
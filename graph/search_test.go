@@ -0,0 +1,35 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"hello", "wörld", "42"}, tokenize("Hello, WÖRLD! (42)"))
+	assert.Empty(t, tokenize(" -- "))
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	assert.Equal(
+		t,
+		[]searchTerm{
+			{text: "foo"},
+			{text: "ba", prefix: true},
+			{text: "jon", edits: 1},
+			{text: "smyth", edits: 2},
+			{text: "e"},
+			{text: "mail", prefix: true},
+		},
+		parseSearchQuery("Foo ba* jon~ smyth~2 e-mail*"),
+	)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance([]rune("smith"), []rune("smith"), 2))
+	assert.Equal(t, 1, editDistance([]rune("smith"), []rune("smyth"), 2))
+	assert.Equal(t, 1, editDistance([]rune("smith"), []rune("smiths"), 2))
+	assert.Equal(t, 3, editDistance([]rune("smith"), []rune("jones"), 2))
+	assert.Equal(t, 3, editDistance([]rune("ab"), []rune("abcdef"), 2))
+}
@@ -0,0 +1,226 @@
+package graph
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// shardCount is the number of shards the nodes and edges are spread over
+// by UID hash. It can be at most 32, the width of a shardSet.
+const shardCount = 16
+
+// shard is a part of the graph holding the nodes and edges with a UID
+// hashing to it in its storage, along with the range indexes of its nodes
+// and its changes waiting to be logged.
+type shard struct {
+	lock    sync.Mutex
+	store   Storage
+	ranges  map[IndexDef]*rangeIndex
+	pending []walEntry
+}
+
+// cloneRanges returns a copy of the range indexes of the shard, which is
+// safe to read without holding the lock.
+// The caller is expected to be holding the lock of the shard.
+func (s *shard) cloneRanges() map[IndexDef]*rangeIndex {
+	ranges := make(map[IndexDef]*rangeIndex, len(s.ranges))
+	for def, idx := range s.ranges {
+		ranges[def] = &rangeIndex{tree: idx.tree.Clone()}
+	}
+	return ranges
+}
+
+// shardIndex returns the index of the shard holding the uid, using the
+// 32 bit FNV-1a hash of the uid.
+func shardIndex(uid string) int {
+	h := uint32(2166136261)
+	for i := 0; i < len(uid); i++ {
+		h ^= uint32(uid[i])
+		h *= 16777619
+	}
+	return int(h % shardCount)
+}
+
+// shardSet is a set of shard indexes, one bit per shard.
+type shardSet uint32
+
+// shardsOf returns the set of shards holding the uids.
+func shardsOf(uids ...string) shardSet {
+	var set shardSet
+	for _, uid := range uids {
+		set |= 1 << uint(shardIndex(uid))
+	}
+	return set
+}
+
+// each calls fn with the indexes in the set in ascending order.
+func (set shardSet) each(fn func(int)) {
+	for set != 0 {
+		i := bits.TrailingZeros32(uint32(set))
+		fn(i)
+		set &^= 1 << uint(i)
+	}
+}
+
+// shard returns the shard holding the uid.
+func (g *Graph) shard(uid string) *shard {
+	return g.shards[shardIndex(uid)]
+}
+
+// node returns the graph node with the uid, without its edges. The caller
+// is expected to be holding the lock of the node shard.
+func (g *Graph) node(uid string) (Node, bool) {
+	return g.shard(uid).store.Node(uid)
+}
+
+// hasNode returns true if the graph has a node with the uid. The caller
+// is expected to be holding the lock of the node shard.
+func (g *Graph) hasNode(uid string) bool {
+	return g.shard(uid).store.HasNode(uid)
+}
+
+// edge returns the graph edge with the uid. The caller is expected to be
+// holding the lock of the edge shard.
+func (g *Graph) edge(uid string) (Edge, bool) {
+	return g.shard(uid).store.Edge(uid)
+}
+
+// hasEdge returns true if the graph has a edge with the uid. The caller
+// is expected to be holding the lock of the edge shard.
+func (g *Graph) hasEdge(uid string) bool {
+	return g.shard(uid).store.HasEdge(uid)
+}
+
+// sharded returns true if nodes and edges can be changed holding only the
+// locks of their shards, which is not the case with a storage backend
+// which is not concurrent. The range indexes are kept by shard, while the
+// full-text and unique constraint indexes spanning all the shards have
+// their own locks. The caller is expected to be holding the read lock.
+func (g *Graph) sharded() bool {
+	return g.storage.Concurrent()
+}
+
+// writeLock is held while changing the graph, either the write lock of the
+// graph or the read lock and the locks of a set of shards.
+type writeLock struct {
+	graph     *Graph
+	exclusive bool
+	shards    shardSet
+}
+
+// write locks the shards holding the uids for changing them. The shard
+// locks are taken in ascending order, so writers locking more than one
+// shard, such as when adding a edge between nodes in other shards, can
+// not deadlock. The write lock is taken instead if the graph can not be
+// changed shard by shard.
+func (g *Graph) write(uids ...string) writeLock {
+	g.lock.RLock()
+	if !g.sharded() {
+		g.lock.RUnlock()
+		return g.exclusive()
+	}
+
+	w := writeLock{graph: g, shards: shardsOf(uids...)}
+	w.shards.each(func(i int) {
+		g.shards[i].lock.Lock()
+	})
+	return w
+}
+
+// exclusive takes the write lock of the graph, for changes which need to
+// see all the shards.
+func (g *Graph) exclusive() writeLock {
+	g.lock.Lock()
+	g.locked = true
+	return writeLock{graph: g, exclusive: true}
+}
+
+// unlock logs and commits the changes as a new version of the graph and
+// releases the locks. If the changes can not be logged or committed, they
+// are undone and the error is returned.
+func (w writeLock) unlock() error {
+	g := w.graph
+	if w.exclusive {
+		return g.unlock()
+	}
+
+	err := g.save(w.shards, false)
+
+	for i := shardCount - 1; i >= 0; i-- {
+		if w.shards&(1<<uint(i)) != 0 {
+			g.shards[i].lock.Unlock()
+		}
+	}
+	g.lock.RUnlock()
+
+	return err
+}
+
+// release unlocks, setting err to the error logging or committing the
+// changes unless it is already set. If err is already set, the changes are
+// undone instead of committed, so a method failing partway leaves the
+// graph as it was. It is deferred by the methods changing the graph.
+func (w writeLock) release(err *error) {
+	if *err != nil {
+		w.rollback()
+	}
+
+	if uerr := w.unlock(); uerr != nil && *err == nil {
+		*err = uerr
+	}
+}
+
+// rollback undoes the changes made holding the locks since they were
+// taken, in the reverse order they were made.
+func (w writeLock) rollback() {
+	g := w.graph
+	shards := w.shards
+	if w.exclusive {
+		shards = 1<<shardCount - 1
+	}
+
+	g.undo(shards, g.takePending(shards), false)
+
+	// Undoing the changes to the schema leaves it as last committed.
+	if w.exclusive {
+		g.changed = false
+	}
+}
+
+// writeEdge locks the shards of the edge with the uid and of its source
+// and target nodes. The edge is looked up in the current snapshot to find
+// its nodes, and looked up again once locked in case it has since been
+// replaced by a edge between other nodes.
+func (g *Graph) writeEdge(uid string) writeLock {
+	for {
+		edge, err := g.Snapshot().Edge(uid)
+		if err != nil {
+			edge = Edge{UID: uid}
+		}
+
+		w := g.write(uid, edge.SourceUID, edge.TargetUID)
+		current, ok := g.edge(uid)
+		if !ok || current.SourceUID == edge.SourceUID && current.TargetUID == edge.TargetUID {
+			return w
+		}
+
+		w.unlock()
+	}
+}
+
+// newUID returns a new UID from the UID generator, which is not expected
+// to be safe for concurrent use.
+func (g *Graph) newUID() string {
+	g.uidLock.Lock()
+	defer g.uidLock.Unlock()
+	return g.generateUID()
+}
+
+// ensureUID returns the uid, or a new UID if it is empty. It is called
+// before taking the shard locks, as they depend on the uid.
+func (g *Graph) ensureUID(uid string) string {
+	if uid == "" {
+		return g.newUID()
+	}
+	return uid
+}
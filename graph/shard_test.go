@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardSet_each(t *testing.T) {
+	set := shardsOf("node-1", "node-2", "node-1")
+
+	indexes := []int{}
+	set.each(func(i int) {
+		indexes = append(indexes, i)
+	})
+
+	assert.Contains(t, indexes, shardIndex("node-1"))
+	assert.Contains(t, indexes, shardIndex("node-2"))
+	assert.True(t, sort.IntsAreSorted(indexes))
+}
+
+func TestGraph_concurrent_writes(t *testing.T) {
+	g := New(WithRetention(RetentionPolicy{Versions: 1000}))
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				source := fmt.Sprintf("node-%d-%d", w, i)
+				target := fmt.Sprintf("node-%d-%d", (w+1)%4, i)
+				g.AddNode(source, []string{"person"})
+				g.AddNode(target, []string{"person"})
+				g.AddEdge("", source, "knows", target)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 200, g.NodeCount())
+	assert.Equal(t, 200, g.EdgeCount())
+
+	// Every change is a version of its own, and every version is whole.
+	assert.Equal(t, uint64(400), g.Version())
+	for version := uint64(1); version <= g.Version(); version++ {
+		snap, err := g.SnapshotAtVersion(version)
+		assert.Nil(t, err)
+
+		iter := snap.Edges()
+		for iter.Next() {
+			edge := iter.Edge()
+			assert.True(t, snap.HasNode(edge.SourceUID), version)
+			assert.True(t, snap.HasNode(edge.TargetUID), version)
+		}
+	}
+}
+
+func TestGraph_concurrent_writes_schema(t *testing.T) {
+	g := New(WithRetention(RetentionPolicy{Versions: 1000}))
+	assert.Nil(t, g.CreateRangeIndex("person", "age"))
+	assert.Nil(t, g.CreateSearchIndex(SearchIndexDef{Name: "names", Type: NODE, Properties: []string{"name"}}))
+	assert.Nil(t, g.CreateConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "email"}))
+	assert.Nil(t, g.SetEdgeSchema(EdgeSchema{Label: "manages", MaxOut: 1}))
+
+	g.AddNode("boss", []string{"person"})
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	claimed := 0
+	managed := 0
+
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				uid := fmt.Sprintf("node-%d-%d", w, i)
+				_, err := g.AddNode(
+					uid,
+					[]string{"person"},
+					KV{Key: "age", Value: []byte(fmt.Sprintf("%d", i))},
+					KV{Key: "name", Value: []byte(fmt.Sprintf("worker%d", w))},
+					KV{Key: "email", Value: []byte(fmt.Sprintf("%d@example.com", i))},
+				)
+
+				// Only one of the writers can take each email.
+				lock.Lock()
+				if err == nil {
+					claimed++
+				}
+				lock.Unlock()
+
+				if err != nil {
+					g.AddNode(uid, []string{"person"}, KV{Key: "age", Value: []byte(fmt.Sprintf("%d", i))})
+				}
+
+				// The boss manages only one of the nodes.
+				if _, err := g.AddEdge("", "boss", "manages", uid); err == nil {
+					lock.Lock()
+					managed++
+					lock.Unlock()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, claimed)
+	assert.Equal(t, 1, managed)
+	assert.Empty(t, g.ValidateEdges())
+
+	// Only the nodes which took an email have a name.
+	results, err := g.Search("names", "worker0 worker1 worker2 worker3", 0)
+	assert.Nil(t, err)
+	assert.Len(t, results, 50)
+
+	// Every version has the range index of its nodes.
+	for version := uint64(1); version <= g.Version(); version++ {
+		snap, err := g.SnapshotAtVersion(version)
+		assert.Nil(t, err)
+
+		nodes := []Node{}
+		iter := snap.NodesByRange(RangeQuery{Label: "person", Property: "age"})
+		for iter.Next() {
+			nodes = append(nodes, iter.Value().(Node))
+		}
+
+		aged := 0
+		all := snap.Nodes()
+		for all.Next() {
+			if _, ok := all.Value().(Node).Properties["age"]; ok {
+				aged++
+			}
+		}
+
+		assert.Equal(t, aged, len(nodes), "version %d", version)
+		for i := 1; i < len(nodes); i++ {
+			assert.True(t, CompareValues(nodes[i-1].Properties["age"], nodes[i].Properties["age"]) <= 0)
+		}
+	}
+}
@@ -0,0 +1,663 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Snapshot is a immutable point-in-time view of a version of the graph.
+// Every change to the graph takes a snapshot, which only commits the
+// storage of the changed shards, and reading a snapshot does not block,
+// or get blocked by, changes to the graph. The nodes and edges returned
+// share their property values with the snapshot and should not be changed.
+//
+// Only the definitions of the full-text search indexes are part of a
+// snapshot, searching always uses the graph. With a storage backend which
+// is not versioned, a snapshot reads the storage as last committed, so it
+// may see changes made after it was taken, and nodes and edges may read as
+// missing if reading the storage fails, which is reported by Err.
+type Snapshot struct {
+	version       uint64
+	time          time.Time
+	shards        [shardCount]StorageView
+	ranges        [shardCount]map[IndexDef]*rangeIndex
+	indexes       []IndexDef
+	searchIndexes []SearchIndexDef
+	constraints   []Constraint
+	edgeSchemas   []EdgeSchema
+	readErr       *readError
+}
+
+// readError is the first error reading the storage of a snapshot.
+type readError struct {
+	lock sync.Mutex
+	err  error
+}
+
+// Snapshot returns a immutable point-in-time view of the current version
+// of the graph.
+func (g *Graph) Snapshot() *Snapshot {
+	g.historyLock.Lock()
+	defer g.historyLock.Unlock()
+	return g.history[len(g.history)-1]
+}
+
+// ReadSnapshot calls read with the current snapshot of the graph. With a
+// storage backend which is not versioned, changes to the graph wait until
+// read returns, so the snapshot does not change while it is read.
+func (g *Graph) ReadSnapshot(read func(s *Snapshot) error) error {
+	if !g.storage.Versioned() {
+		g.lock.RLock()
+		defer g.lock.RUnlock()
+	}
+	return read(g.Snapshot())
+}
+
+// takeSnapshot returns a snapshot of the graph as the version committed at
+// the time. The caller is expected to be holding the write lock.
+func (g *Graph) takeSnapshot(version uint64, committed time.Time) *Snapshot {
+	s := &Snapshot{version: version, time: committed}
+	for i, shard := range g.shards {
+		// Nothing is changed yet, so there is nothing to fail.
+		s.shards[i], _ = shard.store.Commit()
+	}
+
+	s.link()
+	g.snapshotSchema(s)
+	return s
+}
+
+// linkedView is a view reading the edges of other shards from the views of
+// the snapshot it is part of, or reporting its errors to the snapshot.
+type linkedView interface {
+	link(s *Snapshot) StorageView
+}
+
+// link links the views of the shards to the snapshot, so they do not use
+// the snapshot they were copied from.
+func (s *Snapshot) link() {
+	s.readErr = &readError{}
+	for i, v := range s.shards {
+		if v, ok := v.(linkedView); ok {
+			s.shards[i] = v.link(s)
+		}
+	}
+}
+
+// Err returns the first error reading the storage of the snapshot, after
+// which nodes and edges may read as missing. Reading the storage of the
+// graph in memory never fails.
+func (s *Snapshot) Err() error {
+	if s.readErr == nil {
+		return nil
+	}
+
+	s.readErr.lock.Lock()
+	defer s.readErr.lock.Unlock()
+	return s.readErr.err
+}
+
+// fail records the error reading the storage, unless there is already one.
+func (s *Snapshot) fail(err error) {
+	s.readErr.lock.Lock()
+	defer s.readErr.lock.Unlock()
+	if s.readErr.err == nil {
+		s.readErr.err = err
+	}
+}
+
+// snapshotSchema sets the range indexes and the index, constraint and edge
+// schema definitions of the snapshot. The caller is expected to be holding
+// the write lock.
+func (g *Graph) snapshotSchema(s *Snapshot) {
+	s.indexes = g.listIndexes()
+	s.searchIndexes = g.listSearchIndexes()
+	s.constraints = g.listConstraints()
+	s.edgeSchemas = g.listEdgeSchemas()
+
+	for i, shard := range g.shards {
+		s.ranges[i] = shard.cloneRanges()
+	}
+}
+
+// view returns a snapshot reading the storage of the graph rather than
+// committed views of it, for finding nodes and edges while holding the
+// write lock. The view is only valid until the graph is next changed.
+func (g *Graph) view() *Snapshot {
+	s := &Snapshot{}
+	for i, shard := range g.shards {
+		s.shards[i] = shard.store
+	}
+	return s
+}
+
+// rangeIndexes returns the range index of every shard with the definition,
+// or false if there is no such range index.
+func (s *Snapshot) rangeIndexes(def IndexDef) ([]*rangeIndex, bool) {
+	if _, ok := s.ranges[0][def]; !ok {
+		return nil, false
+	}
+
+	indexes := make([]*rangeIndex, shardCount)
+	for i, ranges := range s.ranges {
+		indexes[i] = ranges[def]
+	}
+	return indexes, true
+}
+
+// shard returns the view of the shard holding the uid.
+func (s *Snapshot) shard(uid string) StorageView {
+	return s.shards[shardIndex(uid)]
+}
+
+// mergeShards returns the uids of the cursors of all the shards in order.
+func (s *Snapshot) mergeShards(cursor func(v StorageView) Cursor) uidSource {
+	sources := make([]uidSource, shardCount)
+	for i, v := range s.shards {
+		sources[i] = uidSource(cursor(v))
+	}
+	return mergeSources(sources...)
+}
+
+// Version returns the version of the graph in the snapshot.
+func (s *Snapshot) Version() uint64 {
+	return s.version
+}
+
+// Time returns the time the version of the graph in the snapshot was committed.
+func (s *Snapshot) Time() time.Time {
+	return s.time
+}
+
+// attach returns the node with the edges attached to it.
+func (s *Snapshot) attach(node Node) Node {
+	node.inEdges = make(map[string]struct{})
+	node.outEdges = make(map[string]struct{})
+
+	v := s.shard(node.UID)
+	in := v.Adjacency(node.UID, IN, "")
+	for uid, ok := in(); ok; uid, ok = in() {
+		node.inEdges[uid] = struct{}{}
+	}
+
+	out := v.Adjacency(node.UID, OUT, "")
+	for uid, ok := out(); ok; uid, ok = out() {
+		node.outEdges[uid] = struct{}{}
+	}
+
+	return node
+}
+
+// node returns the node with the uid, without the edges attached.
+func (s *Snapshot) node(uid string) (Node, bool) {
+	return s.shard(uid).Node(uid)
+}
+
+// HasNode returns true if the snapshot has a node with the provided uid.
+func (s *Snapshot) HasNode(uid string) bool {
+	return s.shard(uid).HasNode(uid)
+}
+
+// Node returns the node with the provided uid.
+func (s *Snapshot) Node(uid string) (Node, error) {
+	node, ok := s.node(uid)
+	if !ok {
+		if err := s.Err(); err != nil {
+			return Node{}, fmt.Errorf("[GetNode] %w", err)
+		}
+		return Node{}, fmt.Errorf("[GetNode] No such node with UID %s found", uid)
+	}
+	return s.attach(node), nil
+}
+
+// Nodes returns a lazy node iterator with the nodes ordered by UID.
+func (s *Snapshot) Nodes() NodeIterator {
+	return s.NodesWhere(nil)
+}
+
+// nodeSource returns the nodes of the uids, with the edges attached,
+// skipping the nodes not matching the predicate.
+func (s *Snapshot) nodeSource(uids uidSource, p Predicate) itemSource {
+	// Attaching the edges walks the adjacency, so it is put off until
+	// the node is matched unless the predicate needs the edges.
+	early := needsEdges(p)
+
+	return func() (interface{}, bool) {
+		for uid, ok := uids(); ok; uid, ok = uids() {
+			node, found := s.node(uid)
+			if !found {
+				continue
+			}
+
+			if early {
+				node = s.attach(node)
+			}
+
+			if p.MatchNode(node) {
+				if !early {
+					node = s.attach(node)
+				}
+				return node, true
+			}
+		}
+		return nil, false
+	}
+}
+
+// allNodes returns the uids of all the nodes after the uid after.
+func (s *Snapshot) allNodes(after string) uidSource {
+	return s.mergeShards(func(v StorageView) Cursor {
+		return v.Nodes(after)
+	})
+}
+
+// nodeLabelSource returns the uids of the nodes with the label in all the
+// shards, starting after the uid after if it is not empty.
+func (s *Snapshot) nodeLabelSource(label, after string) uidSource {
+	return s.mergeShards(func(v StorageView) Cursor {
+		return v.NodesByLabel(label, after)
+	})
+}
+
+// edgeLabelSource returns the uids of the edges with the label in all the
+// shards, starting after the uid after if it is not empty.
+func (s *Snapshot) edgeLabelSource(label, after string) uidSource {
+	return s.mergeShards(func(v StorageView) Cursor {
+		return v.EdgesByLabel(label, after)
+	})
+}
+
+// propertySource returns the uids of the nodes with the property value in
+// the property index in all the shards, starting after the uid after if it
+// is not empty. False is returned if there is no such index.
+func (s *Snapshot) propertySource(def IndexDef, value []byte, after string) (uidSource, bool) {
+	sources := make([]uidSource, shardCount)
+	for i, v := range s.shards {
+		cursor, ok := v.NodesByProperty(def, value, after)
+		if !ok {
+			return nil, false
+		}
+		sources[i] = uidSource(cursor)
+	}
+	return mergeSources(sources...), true
+}
+
+// propIndexSource returns the uids of the nodes found using a property
+// index on the labels of the predicate and one of the property values.
+// False is returned if there are no indexes covering the labels.
+func (s *Snapshot) propIndexSource(p Predicate, props []propEqualsPredicate, after string) (uidSource, bool) {
+	switch p := p.(type) {
+	case labelPredicate:
+		for _, prop := range props {
+			def := IndexDef{Label: p.label, Property: prop.key}
+			if uids, ok := s.propertySource(def, prop.value, after); ok {
+				return uids, true
+			}
+		}
+	case andPredicate:
+		// A single index is enough as the nodes need to match every predicate.
+		for _, child := range p {
+			if uids, ok := s.propIndexSource(child, props, after); ok {
+				return uids, true
+			}
+		}
+	case orPredicate:
+		// Every predicate needs a index as a node only needs to match one.
+		if len(p) == 0 {
+			return nil, false
+		}
+
+		sources := make([]uidSource, len(p))
+		for i, child := range p {
+			uids, ok := s.propIndexSource(child, props, after)
+			if !ok {
+				return nil, false
+			}
+			sources[i] = uids
+		}
+		return mergeSources(sources...), true
+	}
+
+	return nil, false
+}
+
+// nodeCandidates returns the uids, in order, of the nodes which can match
+// the predicate, using the label and property indexes. The candidates
+// still need to be matched against the predicate. Only the uids after the
+// uid after are returned. False is returned if the indexes can not narrow
+// down the nodes.
+func (s *Snapshot) nodeCandidates(p Predicate, after string) (uidSource, bool) {
+	switch p := p.(type) {
+	case labelPredicate:
+		return s.nodeLabelSource(p.label, after), true
+	case andPredicate:
+		props := []propEqualsPredicate{}
+		for _, child := range p {
+			if prop, ok := child.(propEqualsPredicate); ok {
+				props = append(props, prop)
+			}
+		}
+
+		if len(props) > 0 {
+			for _, child := range p {
+				if uids, ok := s.propIndexSource(child, props, after); ok {
+					return uids, true
+				}
+			}
+		}
+
+		// Nodes matching all the predicates match the first one which
+		// can be looked up, so only it needs to be walked.
+		for _, child := range p {
+			if uids, ok := s.nodeCandidates(child, after); ok {
+				return uids, true
+			}
+		}
+	case orPredicate:
+		if len(p) == 0 {
+			return nil, false
+		}
+
+		sources := make([]uidSource, len(p))
+		for i, child := range p {
+			uids, ok := s.nodeCandidates(child, after)
+			if !ok {
+				return nil, false
+			}
+			sources[i] = uids
+		}
+		return mergeSources(sources...), true
+	}
+
+	return nil, false
+}
+
+// NodesWhere returns a lazy node iterator with the nodes matching the
+// predicate ordered by UID. A nil predicate matches all the nodes. The
+// label and property indexes are used to narrow down the nodes checked.
+func (s *Snapshot) NodesWhere(p Predicate) NodeIterator {
+	return s.NodesWhereAfter(p, "")
+}
+
+// NodesWhereAfter returns a lazy node iterator the same as NodesWhere, but
+// starting after the node with the uid after, which need not exist. Paging
+// through the nodes continues after the last node of the previous page.
+func (s *Snapshot) NodesWhereAfter(p Predicate, after string) NodeIterator {
+	if p == nil {
+		p = And()
+	}
+
+	return newNodeIterator(func() itemSource {
+		uids, ok := s.nodeCandidates(p, after)
+		if !ok {
+			uids = s.allNodes(after)
+		}
+		return s.nodeSource(uids, p)
+	}, s.Err)
+}
+
+// NodesBy returns a lazy node iterator with filtered nodes ordered by UID.
+// If labels is an empty list, then any label will be used.
+// Match decides if a node needs any or all of the labels.
+// If props is an empty map, no properties will be used for filtering.
+func (s *Snapshot) NodesBy(labels []string, match LabelMatch, props map[string][]byte) NodeIterator {
+	return s.NodesWhere(NodesByPredicate(labels, match, props))
+}
+
+// NodesByRange returns the nodes with the label and a property value in
+// the range, ordered by the value and then by UID. If there is a range
+// index on the label and property it is walked in order, stopping once
+// the limit is reached, otherwise the nodes with the label are filtered
+// and sorted.
+func (s *Snapshot) NodesByRange(q RangeQuery) NodeIterator {
+	return newNodeIterator(func() itemSource {
+		return sliceSource(s.nodesByRange(q))
+	}, s.Err)
+}
+
+// nodesByRange returns the nodes of the range query.
+func (s *Snapshot) nodesByRange(q RangeQuery) []interface{} {
+	vr := q.Range.compile()
+	nodes := []interface{}{}
+
+	// accept checks the node as the index may lag behind aliased property maps.
+	accept := func(node Node) bool {
+		value, ok := node.Properties[q.Property]
+		if !ok || !node.HasLabel(q.Label) || !vr.contains(decodeValue(value)) {
+			return false
+		}
+		return q.Filter == nil || q.Filter.MatchNode(node)
+	}
+
+	if indexes, ok := s.rangeIndexes(IndexDef{Label: q.Label, Property: q.Property, Type: RANGE}); ok {
+		scanRanges(indexes, q.Range, q.Descending, func(uid string) bool {
+			if node, ok := s.node(uid); ok {
+				if node = s.attach(node); accept(node) {
+					nodes = append(nodes, node)
+				}
+			}
+			return q.Limit <= 0 || len(nodes) < q.Limit
+		})
+		return nodes
+	}
+
+	next := s.nodeSource(s.nodeLabelSource(q.Label, ""), And())
+	for node, ok := next(); ok; node, ok = next() {
+		if accept(node.(Node)) {
+			nodes = append(nodes, node)
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i].(Node), nodes[j].(Node)
+		if c := CompareValues(a.Properties[q.Property], b.Properties[q.Property]); c != 0 {
+			return c < 0 != q.Descending
+		}
+		return a.UID < b.UID != q.Descending
+	})
+
+	if q.Limit > 0 && len(nodes) > q.Limit {
+		nodes = nodes[:q.Limit]
+	}
+
+	return nodes
+}
+
+// NodeCount returns the total number of nodes in the snapshot.
+func (s *Snapshot) NodeCount() int {
+	count := 0
+	for _, v := range s.shards {
+		count += v.NodeCount()
+	}
+	return count
+}
+
+// HasEdge returns true if the snapshot has a edge with the provided uid.
+func (s *Snapshot) HasEdge(uid string) bool {
+	return s.shard(uid).HasEdge(uid)
+}
+
+// Edge returns the edge with the provided uid.
+func (s *Snapshot) Edge(uid string) (Edge, error) {
+	edge, ok := s.shard(uid).Edge(uid)
+	if !ok {
+		if err := s.Err(); err != nil {
+			return Edge{}, fmt.Errorf("[GetEdge] %w", err)
+		}
+		return Edge{}, fmt.Errorf("[GetEdge] No such edge with UID %s found", uid)
+	}
+	return edge, nil
+}
+
+// Edges returns a lazy edge iterator with the edges ordered by UID.
+func (s *Snapshot) Edges() EdgeIterator {
+	return s.EdgesWhere(nil)
+}
+
+// adjacencySource returns the uids of the edges leaving the node if out
+// is true, otherwise of the edges coming into the node, starting after the
+// edge uid after if it is not empty.
+func (s *Snapshot) adjacencySource(uid string, out bool, after string) uidSource {
+	dir := IN
+	if out {
+		dir = OUT
+	}
+	return uidSource(s.shard(uid).Adjacency(uid, dir, after))
+}
+
+// edgeCandidates returns the uids, in order, of the edges which can match
+// the predicate, using the adjacency and label indexes. The candidates
+// still need to be matched against the predicate. Only the uids after the
+// uid after are returned. False is returned if the indexes can not narrow
+// down the edges.
+func (s *Snapshot) edgeCandidates(p Predicate, after string) (uidSource, bool) {
+	switch p := p.(type) {
+	case sourcePredicate:
+		return s.adjacencySource(p.uid, true, after), true
+	case targetPredicate:
+		return s.adjacencySource(p.uid, false, after), true
+	case labelPredicate:
+		return s.edgeLabelSource(p.label, after), true
+	case andPredicate:
+		for _, child := range p {
+			if uids, ok := s.edgeCandidates(child, after); ok {
+				return uids, true
+			}
+		}
+	case orPredicate:
+		if len(p) == 0 {
+			return nil, false
+		}
+
+		sources := make([]uidSource, len(p))
+		for i, child := range p {
+			uids, ok := s.edgeCandidates(child, after)
+			if !ok {
+				return nil, false
+			}
+			sources[i] = uids
+		}
+		return mergeSources(sources...), true
+	}
+
+	return nil, false
+}
+
+// allEdges returns the uids of all the edges after the uid after.
+func (s *Snapshot) allEdges(after string) uidSource {
+	return s.mergeShards(func(v StorageView) Cursor {
+		return v.Edges(after)
+	})
+}
+
+// EdgesWhere returns a lazy edge iterator with the edges matching the
+// predicate ordered by UID. A nil predicate matches all the edges. The
+// adjacency and label indexes are used to narrow down the edges checked.
+func (s *Snapshot) EdgesWhere(p Predicate) EdgeIterator {
+	return s.EdgesWhereAfter(p, "")
+}
+
+// EdgesWhereAfter returns a lazy edge iterator the same as EdgesWhere, but
+// starting after the edge with the uid after, which need not exist. Paging
+// through the edges continues after the last edge of the previous page.
+func (s *Snapshot) EdgesWhereAfter(p Predicate, after string) EdgeIterator {
+	if p == nil {
+		p = And()
+	}
+
+	return newEdgeIterator(func() itemSource {
+		uids, ok := s.edgeCandidates(p, after)
+		if !ok {
+			uids = s.allEdges(after)
+		}
+
+		return func() (interface{}, bool) {
+			for uid, ok := uids(); ok; uid, ok = uids() {
+				if edge, err := s.Edge(uid); err == nil && p.MatchEdge(edge) {
+					return edge, true
+				}
+			}
+			return nil, false
+		}
+	}, s.Err)
+}
+
+// EdgesBy returns a lazy edge iterator with filtered edges ordered by UID.
+// If source or target is empty, they are not used for filtering.
+// If labels is an empty list, then any label will be used.
+// If props is an empty map, no properties will be used for filtering.
+func (s *Snapshot) EdgesBy(source string, labels []string, target string, props map[string][]byte) EdgeIterator {
+	return s.EdgesWhere(EdgesByPredicate(source, labels, target, props))
+}
+
+// EdgeCount returns the total number of edges in the snapshot.
+func (s *Snapshot) EdgeCount() int {
+	count := 0
+	for _, v := range s.shards {
+		count += v.EdgeCount()
+	}
+	return count
+}
+
+// Indexes returns all the property index definitions sorted by label,
+// property and index type.
+func (s *Snapshot) Indexes() []IndexDef {
+	return append([]IndexDef{}, s.indexes...)
+}
+
+// SearchIndexes returns all the full-text search index definitions sorted by name.
+func (s *Snapshot) SearchIndexes() []SearchIndexDef {
+	return append([]SearchIndexDef{}, s.searchIndexes...)
+}
+
+// Constraints returns all the constraints in the same order as Graph.Constraints.
+func (s *Snapshot) Constraints() []Constraint {
+	return append([]Constraint{}, s.constraints...)
+}
+
+// EdgeSchemas returns all the edge schemas sorted by label.
+func (s *Snapshot) EdgeSchemas() []EdgeSchema {
+	return append([]EdgeSchema{}, s.edgeSchemas...)
+}
+
+// MarshalJSON marshals the snapshot into the same JSON format as the graph.
+func (s *Snapshot) MarshalJSON() ([]byte, error) {
+	type G struct {
+		Nodes         []Node           `json:"nodes"`
+		Edges         []Edge           `json:"edges"`
+		Indexes       []IndexDef       `json:"indexes,omitempty"`
+		SearchIndexes []SearchIndexDef `json:"search_indexes,omitempty"`
+		Constraints   []Constraint     `json:"constraints,omitempty"`
+		EdgeSchemas   []EdgeSchema     `json:"edge_schemas,omitempty"`
+	}
+
+	graph := G{
+		Nodes:         make([]Node, 0, s.NodeCount()),
+		Edges:         make([]Edge, 0, s.EdgeCount()),
+		Indexes:       s.indexes,
+		SearchIndexes: s.searchIndexes,
+		Constraints:   s.constraints,
+		EdgeSchemas:   s.edgeSchemas,
+	}
+
+	nodes := s.allNodes("")
+	for uid, ok := nodes(); ok; uid, ok = nodes() {
+		node, _ := s.node(uid)
+		graph.Nodes = append(graph.Nodes, node)
+	}
+
+	edges := s.allEdges("")
+	for uid, ok := edges(); ok; uid, ok = edges() {
+		edge, _ := s.Edge(uid)
+		graph.Edges = append(graph.Edges, edge)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(graph)
+}
@@ -0,0 +1,408 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrNoSnapshot is returned when restoring a graph from a directory
+// without any snapshot files.
+var ErrNoSnapshot = errors.New("No snapshot found")
+
+// snapshotCheckInterval is how often the snapshotter checks whether a
+// snapshot is due.
+const snapshotCheckInterval = 100 * time.Millisecond
+
+// SnapshotPolicy decides when the graph is saved to a snapshot file and
+// how many snapshot files are kept. A snapshot is saved once either of
+// the limits is reached, a zero limit is not used. If both limits are
+// zero, snapshots are only saved by Save and Close.
+type SnapshotPolicy struct {
+	// Interval is the time between snapshots.
+	Interval time.Duration
+	// Changes is the number of versions of the graph between snapshots.
+	Changes int
+	// Retain is the number of snapshot files kept, the latest is always kept.
+	Retain int
+}
+
+// snapshotFile is the name of a snapshot file, made of its sequence number
+// and the LSN of the last write-ahead log record in the snapshot.
+type snapshotFile struct {
+	path string
+	seq  uint64
+	lsn  uint64
+}
+
+// snapshotName returns the name of the snapshot file.
+func snapshotName(seq, lsn uint64) string {
+	return fmt.Sprintf("snapshot-%016x-%016x.json", seq, lsn)
+}
+
+// parseSnapshotName returns the sequence number and LSN of the snapshot
+// file name, or false if it is not a snapshot file name.
+func parseSnapshotName(name string) (uint64, uint64, bool) {
+	if !strings.HasPrefix(name, "snapshot-") || !strings.HasSuffix(name, ".json") {
+		return 0, 0, false
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(name, "snapshot-"), ".json"), "-")
+	if len(parts) != 2 {
+		return 0, 0, false
+	}
+
+	seq, err := strconv.ParseUint(parts[0], 16, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	lsn, err := strconv.ParseUint(parts[1], 16, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return seq, lsn, true
+}
+
+// listSnapshots returns the snapshot files in the directory, oldest first.
+// A directory which does not exist has no snapshot files.
+func listSnapshots(dir string) ([]snapshotFile, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []snapshotFile{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	files := []snapshotFile{}
+	for _, info := range infos {
+		if seq, lsn, ok := parseSnapshotName(info.Name()); ok && info.Mode().IsRegular() {
+			files = append(files, snapshotFile{path: filepath.Join(dir, info.Name()), seq: seq, lsn: lsn})
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].seq < files[j].seq
+	})
+
+	return files, nil
+}
+
+// snapshotContent is the content of a snapshot file, the graph in the
+// same JSON format as a dump and the LSN of the last write-ahead log
+// record in it.
+type snapshotContent struct {
+	LSN   uint64          `json:"lsn"`
+	Graph json.RawMessage `json:"graph"`
+}
+
+// RestoreSnapshot returns a graph made with the options and loaded from
+// the latest snapshot file in the directory, and the LSN of the last
+// write-ahead log record in it, for replaying the records after it. If the
+// latest file can not be loaded, the earlier files are tried in turn.
+func RestoreSnapshot(dir string, opts ...Option) (*Graph, uint64, error) {
+	files, err := listSnapshots(dir)
+	if err != nil {
+		return nil, 0, fmt.Errorf("[RestoreSnapshot] %w", err)
+	}
+
+	if len(files) == 0 {
+		return nil, 0, fmt.Errorf("[RestoreSnapshot] %w in %s", ErrNoSnapshot, dir)
+	}
+
+	errs := []string{}
+	for i := len(files) - 1; i >= 0; i-- {
+		// A new graph for each file, as a failed load may have left the
+		// graph part loaded.
+		g := New(opts...)
+		lsn, err := loadSnapshot(g, files[i].path)
+		if err == nil {
+			return g, lsn, nil
+		}
+
+		errs = append(errs, fmt.Sprintf("%s: %s", files[i].path, err))
+	}
+
+	return nil, 0, fmt.Errorf("[RestoreSnapshot] No snapshot could be loaded (%s)", strings.Join(errs, "; "))
+}
+
+// loadSnapshot loads the snapshot file into the graph and returns its LSN.
+func loadSnapshot(g *Graph, path string) (uint64, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	content := snapshotContent{}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return 0, err
+	}
+
+	if err := g.UnmarshalJSON(content.Graph); err != nil {
+		return 0, err
+	}
+
+	return content.LSN, nil
+}
+
+// Snapshotter saves the graph to snapshot files in a directory by the
+// snapshot policy. Each file is written to a temporary file which is
+// renamed once complete, so a crash never leaves a part written snapshot.
+// If the graph has a write-ahead log, the records in the oldest snapshot
+// kept are removed from the log once a snapshot is saved.
+type Snapshotter struct {
+	lock     sync.Mutex
+	graph    *Graph
+	dir      string
+	policy   SnapshotPolicy
+	seq      uint64
+	version  uint64
+	saved    time.Time
+	done     chan struct{}
+	stopped  sync.WaitGroup
+	closed   bool
+	closeErr error
+}
+
+// NewSnapshotter returns a snapshotter saving the graph to the directory,
+// creating it if it does not exist, and starts saving snapshots by the
+// policy. The graph as it is now is taken as saved.
+func NewSnapshotter(g *Graph, dir string, policy SnapshotPolicy) (*Snapshotter, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("[NewSnapshotter] %w", err)
+	}
+
+	files, err := listSnapshots(dir)
+	if err != nil {
+		return nil, fmt.Errorf("[NewSnapshotter] %w", err)
+	}
+
+	// Temporary files are left by a crash while saving.
+	temps, err := filepath.Glob(filepath.Join(dir, ".snapshot-*.tmp"))
+	if err != nil {
+		return nil, fmt.Errorf("[NewSnapshotter] %w", err)
+	}
+
+	for _, temp := range temps {
+		os.Remove(temp)
+	}
+
+	s := &Snapshotter{
+		graph:   g,
+		dir:     dir,
+		policy:  policy,
+		version: g.Version(),
+		saved:   g.now(),
+		done:    make(chan struct{}),
+	}
+
+	if len(files) > 0 {
+		s.seq = files[len(files)-1].seq
+	}
+
+	if policy.Interval > 0 || policy.Changes > 0 {
+		s.stopped.Add(1)
+		go s.run()
+	}
+
+	return s, nil
+}
+
+// run saves a snapshot whenever one is due until the snapshotter is closed.
+func (s *Snapshotter) run() {
+	defer s.stopped.Done()
+
+	ticker := time.NewTicker(snapshotCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			if !s.due() {
+				continue
+			}
+
+			if _, err := s.Save(); err != nil {
+				log.Printf("[Snapshotter] %s", err)
+			}
+		}
+	}
+}
+
+// due returns true if the graph has changed since the last snapshot and
+// a limit of the policy has been reached.
+func (s *Snapshotter) due() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	version := s.graph.Version()
+	if version == s.version {
+		return false
+	}
+
+	if s.policy.Changes > 0 && version-s.version >= uint64(s.policy.Changes) {
+		return true
+	}
+
+	return s.policy.Interval > 0 && s.graph.now().Sub(s.saved) >= s.policy.Interval
+}
+
+// Save saves the graph to a new snapshot file, removes the files no longer
+// kept and returns the path of the new file.
+func (s *Snapshotter) Save() (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path, err := s.save()
+	if err != nil {
+		return path, fmt.Errorf("[Save] %w", err)
+	}
+
+	return path, nil
+}
+
+// save saves the graph to a new snapshot file.
+// The caller is expected to be holding the snapshotter lock.
+func (s *Snapshotter) save() (string, error) {
+	g := s.graph
+
+	// Holding the write lock, no change is part way through being logged
+	// and committed, so the snapshot has exactly the logged changes up to
+	// the LSN.
+	g.lock.Lock()
+	snap := g.Snapshot()
+	wal := g.wal
+	var lsn uint64
+	if wal != nil {
+		lsn = wal.LSN()
+	}
+	g.lock.Unlock()
+
+	data, err := snap.MarshalJSON()
+	if err != nil {
+		return "", err
+	}
+
+	data, err = json.Marshal(snapshotContent{LSN: lsn, Graph: data})
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(s.dir, snapshotName(s.seq+1, lsn))
+	if err := writeFileAtomic(path, data); err != nil {
+		return "", err
+	}
+
+	s.seq++
+	s.version = snap.Version()
+	s.saved = g.now()
+
+	oldest, err := s.prune()
+	if err != nil {
+		return path, err
+	}
+
+	if wal != nil {
+		if err := wal.Truncate(oldest); err != nil {
+			return path, err
+		}
+	}
+
+	return path, nil
+}
+
+// prune removes the snapshot files no longer kept by the policy and
+// returns the LSN of the oldest file kept.
+// The caller is expected to be holding the snapshotter lock.
+func (s *Snapshotter) prune() (uint64, error) {
+	files, err := listSnapshots(s.dir)
+	if err != nil {
+		return 0, err
+	}
+
+	retain := s.policy.Retain
+	if retain < 1 {
+		retain = 1
+	}
+
+	if len(files) > retain {
+		for _, file := range files[:len(files)-retain] {
+			if err := os.Remove(file.path); err != nil {
+				return 0, err
+			}
+		}
+		files = files[len(files)-retain:]
+	}
+
+	return files[0].lsn, nil
+}
+
+// Close stops saving snapshots by the policy and saves a last snapshot if
+// the graph has changed since the last snapshot.
+func (s *Snapshotter) Close() error {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return s.closeErr
+	}
+	s.closed = true
+	s.lock.Unlock()
+
+	close(s.done)
+	s.stopped.Wait()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.graph.Version() != s.version {
+		if _, err := s.save(); err != nil {
+			s.closeErr = fmt.Errorf("[Close] %w", err)
+		}
+	}
+
+	return s.closeErr
+}
+
+// writeFileAtomic writes the data to a temporary file in the directory of
+// the path, flushes it to disk and renames it to the path.
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	file, err := ioutil.TempFile(dir, ".snapshot-*.tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	return syncDir(dir)
+}
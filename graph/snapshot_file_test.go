@@ -0,0 +1,264 @@
+package graph
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tempDir returns a new temporary directory and a function removing it.
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "draft-snapshot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir, func() { os.RemoveAll(dir) }
+}
+
+func TestParseSnapshotName(t *testing.T) {
+	seq, lsn, ok := parseSnapshotName(snapshotName(12, 345))
+	assert.True(t, ok)
+	assert.Equal(t, uint64(12), seq)
+	assert.Equal(t, uint64(345), lsn)
+
+	_, _, ok = parseSnapshotName(".snapshot-123.tmp")
+	assert.False(t, ok)
+
+	_, _, ok = parseSnapshotName("snapshot-xyz-1.json")
+	assert.False(t, ok)
+}
+
+func TestSnapshotter_Save(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	g := New()
+	g.CreateIndex("person", "name")
+	g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "name", Value: []byte("bar")})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+	g.PatchNode("node-1", NodePatch{SetProperties: map[string][]byte{"age": []byte("21")}})
+
+	s, err := NewSnapshotter(g, dir, SnapshotPolicy{})
+	assert.Nil(t, err)
+
+	path, err := s.Save()
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, snapshotName(1, 0)), path)
+	assert.Nil(t, s.Close())
+
+	actual, lsn, err := RestoreSnapshot(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), lsn)
+	assert.Equal(t, g.Snapshot().Indexes(), actual.Snapshot().Indexes())
+
+	expected, _ := g.Node("node-1")
+	node, err := actual.Node("node-1")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, node)
+	assert.Equal(t, uint64(2), node.Version)
+
+	edge, err := actual.Edge("edge-1")
+	assert.Nil(t, err)
+	assert.Equal(t, "knows", edge.Label)
+}
+
+func TestRestoreSnapshot_none(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	_, _, err := RestoreSnapshot(dir)
+	assert.True(t, errors.Is(err, ErrNoSnapshot))
+
+	_, _, err = RestoreSnapshot(filepath.Join(dir, "missing"))
+	assert.True(t, errors.Is(err, ErrNoSnapshot))
+}
+
+func TestRestoreSnapshot_corrupt(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	g := New()
+	s, err := NewSnapshotter(g, dir, SnapshotPolicy{Retain: 2})
+	assert.Nil(t, err)
+
+	g.AddNode("node-1", []string{"person"})
+	_, err = s.Save()
+	assert.Nil(t, err)
+
+	g.AddNode("node-2", []string{"person"})
+	path, err := s.Save()
+	assert.Nil(t, err)
+	assert.Nil(t, s.Close())
+
+	// The latest snapshot can not be loaded, so the one before it is.
+	assert.Nil(t, ioutil.WriteFile(path, []byte(`{"lsn": 2, "graph": {"nodes": [`), 0644))
+
+	actual, _, err := RestoreSnapshot(dir)
+	assert.Nil(t, err)
+	assert.True(t, actual.HasNode("node-1"))
+	assert.False(t, actual.HasNode("node-2"))
+}
+
+func TestSnapshotter_retain(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	g := New()
+	s, err := NewSnapshotter(g, dir, SnapshotPolicy{Retain: 2})
+	assert.Nil(t, err)
+
+	for i := 0; i < 4; i++ {
+		_, err := s.Save()
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, s.Close())
+
+	files, err := listSnapshots(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+	assert.Equal(t, uint64(3), files[0].seq)
+	assert.Equal(t, uint64(4), files[1].seq)
+
+	// A new snapshotter carries on numbering after the existing files.
+	s, err = NewSnapshotter(g, dir, SnapshotPolicy{Retain: 2})
+	assert.Nil(t, err)
+	path, err := s.Save()
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Join(dir, snapshotName(5, 0)), path)
+	assert.Nil(t, s.Close())
+}
+
+func TestSnapshotter_changes(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	g := New()
+	s, err := NewSnapshotter(g, dir, SnapshotPolicy{Changes: 3})
+	assert.Nil(t, err)
+	defer s.Close()
+
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	time.Sleep(3 * snapshotCheckInterval)
+
+	files, err := listSnapshots(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 0)
+
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(files) == 0 && time.Now().Before(deadline) {
+		time.Sleep(snapshotCheckInterval)
+		files, err = listSnapshots(dir)
+		assert.Nil(t, err)
+	}
+	assert.Len(t, files, 1)
+}
+
+func TestSnapshotter_interval(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	g := New()
+	s, err := NewSnapshotter(g, dir, SnapshotPolicy{Interval: time.Millisecond})
+	assert.Nil(t, err)
+	defer s.Close()
+
+	// Nothing is saved while the graph is unchanged.
+	time.Sleep(3 * snapshotCheckInterval)
+	files, err := listSnapshots(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 0)
+
+	g.AddNode("node-1", []string{"person"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(files) == 0 && time.Now().Before(deadline) {
+		time.Sleep(snapshotCheckInterval)
+		files, err = listSnapshots(dir)
+		assert.Nil(t, err)
+	}
+	assert.Len(t, files, 1)
+}
+
+func TestSnapshotter_Close(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	g := New()
+	s, err := NewSnapshotter(g, dir, SnapshotPolicy{})
+	assert.Nil(t, err)
+	assert.Nil(t, s.Close())
+
+	// The graph is unchanged, so there is nothing to save.
+	files, err := listSnapshots(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 0)
+
+	s, err = NewSnapshotter(g, dir, SnapshotPolicy{})
+	assert.Nil(t, err)
+	g.AddNode("node-1", []string{"person"})
+	assert.Nil(t, s.Close())
+	assert.Nil(t, s.Close())
+
+	files, err = listSnapshots(dir)
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestSnapshotter_recovery(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "draft.wal")
+
+	g, wal := openLogged(t, path)
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+
+	s, err := NewSnapshotter(g, dir, SnapshotPolicy{})
+	assert.Nil(t, err)
+	_, err = s.Save()
+	assert.Nil(t, err)
+
+	// The records in the snapshot are removed from the log.
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), info.Size())
+
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+	g.RemoveNode("node-2", Detach())
+	g.AddNode("node-3", []string{"person"})
+
+	// A crash, without saving a last snapshot.
+	assert.Nil(t, wal.Close())
+
+	actual, lsn, err := RestoreSnapshot(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), lsn)
+	assert.True(t, actual.HasNode("node-2"))
+
+	wal, err = OpenWAL(path)
+	assert.Nil(t, err)
+
+	count, err := wal.ReplayAfter(actual, lsn)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, count)
+
+	assert.True(t, actual.HasNode("node-1"))
+	assert.False(t, actual.HasNode("node-2"))
+	assert.True(t, actual.HasNode("node-3"))
+	assert.False(t, actual.HasEdge("edge-1"))
+
+	// The log carries on numbering after the snapshot.
+	actual.SetWAL(wal)
+	actual.AddNode("node-4", []string{"person"})
+	assert.Equal(t, uint64(6), wal.LSN())
+	assert.Nil(t, wal.Close())
+}
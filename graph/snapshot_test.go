@@ -0,0 +1,257 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collectUIDs returns the uids of the nodes or edges in the iterator.
+func collectUIDs(iter Iterator) []string {
+	uids := []string{}
+	for iter.Next() {
+		switch v := iter.Value().(type) {
+		case Node:
+			uids = append(uids, v.UID)
+		case Edge:
+			uids = append(uids, v.UID)
+		}
+	}
+	return uids
+}
+
+func TestSnapshot(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("node-bar", []string{"person"}, KV{Key: "name", Value: []byte("bar")})
+	g.AddEdge("edge-knows", "node-foo", "knows", "node-bar")
+
+	snap := g.Snapshot()
+
+	g.PatchNode("node-foo", NodePatch{SetProperties: map[string][]byte{"name": []byte("changed")}})
+	g.AddNode("node-baz", []string{"person"})
+	g.RemoveNode("node-bar", Detach())
+
+	foo, err := snap.Node("node-foo")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("foo"), foo.Properties["name"])
+	assert.Equal(t, uint64(1), foo.Version)
+	assert.Equal(t, []string{"edge-knows"}, foo.OutEdges())
+
+	bar, err := snap.Node("node-bar")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"edge-knows"}, bar.InEdges())
+
+	assert.Equal(t, false, snap.HasNode("node-baz"))
+	assert.Equal(t, true, snap.HasEdge("edge-knows"))
+	assert.Equal(t, 2, snap.NodeCount())
+	assert.Equal(t, 1, snap.EdgeCount())
+	assert.Equal(t, []string{"node-bar", "node-foo"}, collectUIDs(snap.Nodes()))
+
+	// The graph has moved on.
+	foo, err = g.Node("node-foo")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("changed"), foo.Properties["name"])
+	assert.Equal(t, false, g.HasEdge("edge-knows"))
+	assert.Equal(t, []string{"node-baz", "node-foo"}, collectUIDs(g.Snapshot().Nodes()))
+}
+
+func TestSnapshot_aliased_properties(t *testing.T) {
+	g := New()
+	node, _ := g.AddNode("node-foo", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+
+	snap := g.Snapshot()
+	node.Properties["name"] = []byte("changed")
+
+	foo, err := snap.Node("node-foo")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("foo"), foo.Properties["name"])
+}
+
+func TestSnapshot_NodesBy(t *testing.T) {
+	g := New()
+	g.CreateIndex("person", "name")
+	g.AddNode("node-foo", []string{"person", "employee"}, KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("node-bar", []string{"person"}, KV{Key: "name", Value: []byte("bar")})
+	g.AddNode("node-dog", []string{"animal"}, KV{Key: "name", Value: []byte("foo")})
+
+	snap := g.Snapshot()
+	g.AddNode("node-foo2", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+
+	tests := []struct {
+		labels   []string
+		match    LabelMatch
+		props    map[string][]byte
+		expected []string
+	}{
+		{nil, ALL, nil, []string{"node-bar", "node-dog", "node-foo"}},
+		{[]string{"person"}, ALL, nil, []string{"node-bar", "node-foo"}},
+		{[]string{"person", "employee"}, ALL, nil, []string{"node-foo"}},
+		{[]string{"employee", "animal"}, ANY, nil, []string{"node-dog", "node-foo"}},
+		{[]string{"person"}, ALL, map[string][]byte{"name": []byte("foo")}, []string{"node-foo"}},
+		{nil, ALL, map[string][]byte{"name": []byte("foo")}, []string{"node-dog", "node-foo"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, collectUIDs(snap.NodesBy(tt.labels, tt.match, tt.props)), fmt.Sprint(tt.labels, tt.props))
+	}
+}
+
+func TestSnapshot_EdgesBy(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+	g.AddNode("node-bar", []string{"person"})
+	g.AddNode("node-dog", []string{"animal"})
+	g.AddEdge("edge-knows", "node-foo", "knows", "node-bar")
+	g.AddEdge("edge-owns", "node-foo", "owns", "node-dog", KV{Key: "since", Value: []byte("2020")})
+	g.AddEdge("edge-likes", "node-bar", "likes", "node-dog")
+
+	snap := g.Snapshot()
+	g.RemoveEdge("edge-owns")
+
+	assert.Equal(t, []string{"edge-knows", "edge-likes", "edge-owns"}, collectUIDs(snap.Edges()))
+	assert.Equal(t, []string{"edge-knows", "edge-owns"}, collectUIDs(snap.EdgesBy("node-foo", nil, "", nil)))
+	assert.Equal(t, []string{"edge-likes", "edge-owns"}, collectUIDs(snap.EdgesBy("", nil, "node-dog", nil)))
+	assert.Equal(t, []string{"edge-owns"}, collectUIDs(snap.EdgesBy("node-foo", nil, "node-dog", nil)))
+	assert.Equal(t, []string{"edge-knows", "edge-likes"}, collectUIDs(snap.EdgesBy("", []string{"knows", "likes"}, "", nil)))
+	assert.Equal(t, []string{"edge-owns"}, collectUIDs(snap.EdgesBy("", nil, "", map[string][]byte{"since": []byte("2020")})))
+}
+
+func TestSnapshot_MarshalJSON(t *testing.T) {
+	g := New()
+	g.CreateRangeIndex("person", "age")
+	g.AddNode("node-foo", []string{"person"}, KV{Key: "age", Value: []byte("30")})
+	g.AddNode("node-bar", []string{"person"}, KV{Key: "age", Value: []byte("40")})
+	g.AddEdge("edge-knows", "node-foo", "knows", "node-bar")
+
+	snap := g.Snapshot()
+	g.RemoveNode("node-foo", Detach())
+
+	dump, err := json.Marshal(snap)
+	assert.Nil(t, err)
+
+	actual := New()
+	err = json.Unmarshal(dump, &actual)
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, actual.NodeCount())
+	assert.Equal(t, 1, actual.EdgeCount())
+	assert.Equal(t, []IndexDef{{Label: "person", Property: "age", Type: RANGE}}, actual.Indexes())
+
+	iter := snap.NodesByRange(RangeQuery{Label: "person", Property: "age", Descending: true})
+	assert.Equal(t, []string{"node-bar", "node-foo"}, collectUIDs(iter))
+}
+
+func TestSnapshot_concurrent_writes(t *testing.T) {
+	g := New()
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			source := fmt.Sprintf("node-%d-source", i)
+			target := fmt.Sprintf("node-%d-target", i)
+			edge := fmt.Sprintf("edge-%d", i)
+
+			g.AddNode(source, []string{"person"})
+			g.AddNode(target, []string{"person"})
+			g.AddEdge(edge, source, "knows", target)
+			if i%2 == 0 {
+				g.RemoveNode(source, Detach())
+			}
+		}
+		close(done)
+	}()
+
+	// Every edge in a snapshot has its nodes in the same snapshot.
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+
+		snap := g.Snapshot()
+		edges := snap.Edges()
+		for edges.Next() {
+			edge := edges.Value().(Edge)
+			assert.True(t, snap.HasNode(edge.SourceUID), edge.UID)
+			assert.True(t, snap.HasNode(edge.TargetUID), edge.UID)
+		}
+	}
+
+	wg.Wait()
+	assert.Equal(t, 100, g.Snapshot().EdgeCount())
+}
+
+func TestSnapshot_lazy_iterators(t *testing.T) {
+	g := New()
+	for i := 0; i < 3*cursorBatchSize; i++ {
+		labels := []string{"even"}
+		if i%2 == 1 {
+			labels = []string{"odd"}
+		}
+		g.AddNode(fmt.Sprintf("node-%03d", i), labels)
+	}
+
+	snap := g.Snapshot()
+
+	iter := snap.Nodes()
+	assert.Equal(t, 3*cursorBatchSize, iter.Size())
+	assert.Equal(t, 3*cursorBatchSize, len(collectUIDs(iter)))
+
+	// Labels matched with ANY are merged in uid order.
+	uids := collectUIDs(snap.NodesBy([]string{"odd", "even"}, ANY, nil))
+	assert.Equal(t, 3*cursorBatchSize, len(uids))
+	assert.Equal(t, "node-000", uids[0])
+	assert.Equal(t, "node-001", uids[1])
+
+	// Closing stops the iterator early.
+	iter = snap.NodesBy([]string{"odd"}, ALL, nil)
+	assert.Equal(t, "node-001", iter.Node().UID)
+	assert.Equal(t, true, iter.Next())
+	assert.Equal(t, true, iter.Next())
+	assert.Equal(t, "node-003", iter.Node().UID)
+	assert.Nil(t, iter.Close())
+	assert.Equal(t, false, iter.Next())
+}
+
+func TestSnapshot_NodesWhereAfter(t *testing.T) {
+	g := New()
+	g.CreateIndex("person", "team")
+	g.AddNode("node-1", []string{"person"}, KV{Key: "team", Value: []byte("red")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "team", Value: []byte("blue")})
+	g.AddNode("node-3", []string{"person"}, KV{Key: "team", Value: []byte("red")})
+	g.AddNode("node-4", []string{"pet"}, KV{Key: "team", Value: []byte("red")})
+	g.AddEdge("edge-1", "node-1", "owns", "node-4")
+	g.AddEdge("edge-2", "node-1", "knows", "node-2")
+	g.AddEdge("edge-3", "node-1", "knows", "node-3")
+
+	snap := g.Snapshot()
+
+	tests := []struct {
+		predicate Predicate
+		after     string
+		expected  []string
+	}{
+		{nil, "node-2", []string{"node-3", "node-4"}},
+		{nil, "node-0", []string{"node-1", "node-2", "node-3", "node-4"}},
+		{HasLabel("person"), "node-1", []string{"node-2", "node-3"}},
+		{And(HasLabel("person"), PropEquals("team", []byte("red"))), "node-1", []string{"node-3"}},
+		{Or(HasLabel("person"), HasLabel("pet")), "node-3", []string{"node-4"}},
+		{HasLabel("person"), "node-3", []string{}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, collectUIDs(snap.NodesWhereAfter(tt.predicate, tt.after)), "%v after %s", tt.predicate, tt.after)
+	}
+
+	assert.Equal(t, []string{"edge-2", "edge-3"}, collectUIDs(snap.EdgesWhereAfter(nil, "edge-1")))
+	assert.Equal(t, []string{"edge-3"}, collectUIDs(snap.EdgesWhereAfter(HasSource("node-1"), "edge-2")))
+	assert.Equal(t, []string{"edge-3"}, collectUIDs(snap.EdgesWhereAfter(HasLabel("knows"), "edge-2")))
+}
@@ -13,4 +13,11 @@ type Stat struct {
 	NumCPU        int
 	NumGoroutings int
 	MemStats      runtime.MemStats
+	// StoreBytes is a estimate of the memory used for storing the nodes,
+	// edges, their adjacency and the label, property and range indexes in
+	// bytes. Earlier versions share most of the store, the parts copied
+	// for them when changing the store are not counted.
+	StoreBytes int
+	// BytesPerElement is StoreBytes per node and edge.
+	BytesPerElement float64
 }
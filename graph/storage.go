@@ -0,0 +1,185 @@
+package graph
+
+// Cursor returns the next uid and true, or false when there are no more
+// uids. The uids are returned in order.
+type Cursor func() (string, bool)
+
+// readAll returns all the uids of the cursor.
+func readAll(c Cursor) []string {
+	uids := []string{}
+	for uid, ok := c(); ok; uid, ok = c() {
+		uids = append(uids, uid)
+	}
+	return uids
+}
+
+// StorageView reads the nodes, edges, adjacency and indexes of a shard of
+// the graph. The nodes returned do not have their edges attached.
+type StorageView interface {
+	// Node returns the node with the uid.
+	Node(uid string) (Node, bool)
+	// HasNode returns true if there is a node with the uid.
+	HasNode(uid string) bool
+	// Edge returns the edge with the uid.
+	Edge(uid string) (Edge, bool)
+	// HasEdge returns true if there is a edge with the uid.
+	HasEdge(uid string) bool
+	// NodeCount returns the number of nodes.
+	NodeCount() int
+	// EdgeCount returns the number of edges.
+	EdgeCount() int
+	// Nodes returns the uids of the nodes after the uid after.
+	Nodes(after string) Cursor
+	// Edges returns the uids of the edges after the uid after.
+	Edges(after string) Cursor
+	// Adjacency returns the uids of the edges coming into the node if the
+	// direction is IN, or leaving the node if it is OUT, after the edge
+	// uid after.
+	Adjacency(uid string, dir Direction, after string) Cursor
+	// NodesByLabel returns the uids of the nodes with the label after the
+	// uid after.
+	NodesByLabel(label, after string) Cursor
+	// EdgesByLabel returns the uids of the edges with the label after the
+	// uid after.
+	EdgesByLabel(label, after string) Cursor
+	// NodesByProperty returns the uids of the nodes found in the property
+	// index with the property value, after the uid after. False is
+	// returned if there is no such property index.
+	NodesByProperty(def IndexDef, value []byte, after string) (Cursor, bool)
+}
+
+// Storage stores the nodes and edges of a shard of the graph, along with
+// the adjacency of its nodes and its label and property indexes. A edge is
+// stored in the shard of its UID, while it is in the adjacency of its
+// source and target nodes in their shards. The graph holds the lock of a
+// shard while changing or reading its storage, other than the views
+// returned by Commit.
+type Storage interface {
+	StorageView
+	// PutNode adds or replaces the node, keeping the adjacency of a
+	// replaced node, and indexes its labels and indexed properties. If the
+	// node can not be stored, the error is returned and nothing changes.
+	PutNode(node Node) error
+	// DeleteNode removes the node, which has no edges left.
+	DeleteNode(uid string)
+	// PutEdge adds or replaces the edge and indexes its label. If the edge
+	// can not be stored, the error is returned and nothing changes.
+	PutEdge(edge Edge) error
+	// DeleteEdge removes the edge.
+	DeleteEdge(uid string)
+	// Link adds the edge to the adjacency of the node in the direction.
+	Link(uid string, dir Direction, edge string)
+	// Unlink removes the edge from the adjacency of the node.
+	Unlink(uid string, dir Direction, edge string)
+	// Degree returns the number of edges in the adjacency of the node.
+	Degree(uid string) int
+	// CreateIndex adds the property index and indexes the existing nodes.
+	CreateIndex(def IndexDef)
+	// DropIndex removes the property index.
+	DropIndex(def IndexDef)
+	// Indexes returns the definitions of the property indexes.
+	Indexes() []IndexDef
+	// Changed returns true if there are changes since the last commit.
+	Changed() bool
+	// Commit commits the changes and returns a view of the storage as
+	// committed, which is safe to read without holding the lock. If the
+	// changes can not be committed, the error is returned and the graph
+	// undoes and discards them.
+	Commit() (StorageView, error)
+	// Discard drops the changes since the last commit, for when they
+	// have been undone.
+	Discard()
+	// Size returns a estimate of the memory used in bytes, and the number
+	// of nodes and edges.
+	Size() (int, int)
+}
+
+// StorageBackend provides the storage of each shard of a graph.
+type StorageBackend interface {
+	// Shard returns the storage of the shard with the index.
+	Shard(index int) Storage
+	// Concurrent returns true if different shards can be changed at the
+	// same time, otherwise every change holds the write lock of the graph.
+	Concurrent() bool
+	// Versioned returns true if the committed views never change. If the
+	// views see later commits, no earlier versions of the graph are kept
+	// and reading a snapshot may see changes made after it was taken. A
+	// storage which is not versioned is expected to not be concurrent, so
+	// holding the read lock of the graph keeps out all changes.
+	Versioned() bool
+}
+
+// storedSchema is the schema of a graph stored by a storage backend, other
+// than the property indexes, which are stored by the shards.
+type storedSchema struct {
+	RangeIndexes  []IndexDef       `json:"range_indexes,omitempty"`
+	SearchIndexes []SearchIndexDef `json:"search_indexes,omitempty"`
+	Constraints   []Constraint     `json:"constraints,omitempty"`
+	EdgeSchemas   []EdgeSchema     `json:"edge_schemas,omitempty"`
+}
+
+// schemaBackend is a storage backend which stores the schema of the graph
+// along with the nodes and edges.
+type schemaBackend interface {
+	// schema returns the schema as last committed.
+	schema() storedSchema
+	// commitSchema commits the changes to the shards along with the
+	// schema. If they can not be committed, the error is returned and
+	// the schema is left as last committed.
+	commitSchema(schema storedSchema) error
+}
+
+// WithStorage sets the backend storing the nodes and edges of the graph,
+// which are kept in memory by default. Any nodes, edges and property
+// indexes already in the storage are part of the graph, along with the
+// schema if the backend stores it.
+func WithStorage(backend StorageBackend) Option {
+	return func(g *Graph) {
+		g.storage = backend
+	}
+}
+
+// storedSchema returns the schema of the graph to be stored by the storage
+// backend. The caller is expected to be holding the write lock.
+func (g *Graph) storedSchema() storedSchema {
+	s := storedSchema{
+		SearchIndexes: g.listSearchIndexes(),
+		Constraints:   g.listConstraints(),
+		EdgeSchemas:   g.listEdgeSchemas(),
+	}
+
+	for _, def := range g.listIndexes() {
+		if def.Type == RANGE {
+			s.RangeIndexes = append(s.RangeIndexes, def)
+		}
+	}
+
+	return s
+}
+
+// restoreSchema rebuilds the range, search and unique indexes of the schema
+// stored by the storage backend, before the first version is taken. The
+// definitions are committed along with the nodes and edges they index, so
+// they are not checked again.
+func (g *Graph) restoreSchema(backend schemaBackend) {
+	schema := backend.schema()
+	for _, def := range schema.RangeIndexes {
+		g.createIndex(def)
+	}
+
+	for _, def := range schema.SearchIndexes {
+		g.createSearchIndex(def)
+	}
+
+	for _, c := range schema.Constraints {
+		g.createConstraint(c)
+	}
+
+	for _, es := range schema.EdgeSchemas {
+		g.setEdgeSchema(es)
+	}
+
+	// Nothing has changed.
+	g.takePending(1<<shardCount - 1)
+	g.changed = false
+}
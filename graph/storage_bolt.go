@@ -0,0 +1,885 @@
+package graph
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/google/btree"
+	bolt "go.etcd.io/bbolt"
+)
+
+// The buckets of each shard in a bolt database. Keys made of more than one
+// part have each part but the last prefixed by its length, so a prefix of
+// the parts only matches keys with exactly those parts.
+const (
+	// boltNodes maps node uids to the nodes.
+	boltNodes = "nodes"
+	// boltEdges maps edge uids to the edges.
+	boltEdges = "edges"
+	// boltAdjacency has the keys node uid, direction and edge uid.
+	boltAdjacency = "adjacency"
+	// boltNodeLabels has the keys label and node uid.
+	boltNodeLabels = "node-labels"
+	// boltEdgeLabels has the keys label and edge uid.
+	boltEdgeLabels = "edge-labels"
+	// boltIndexes has the keys label and property of the property indexes.
+	boltIndexes = "indexes"
+	// boltProperties has the keys label, property, value and node uid.
+	boltProperties = "properties"
+	// boltMeta has the node and edge counts.
+	boltMeta = "meta"
+	// boltSchema is the bucket of the database, rather than of a shard,
+	// with the schema of the graph under the key schema.
+	boltSchema = "schema"
+)
+
+// boltBuckets are all the buckets of a shard.
+var boltBuckets = []string{boltNodes, boltEdges, boltAdjacency, boltNodeLabels, boltEdgeLabels, boltIndexes, boltProperties, boltMeta}
+
+// boltKey returns the key made of the parts, each prefixed by its length.
+func boltKey(parts ...string) string {
+	var b strings.Builder
+	buf := make([]byte, binary.MaxVarintLen64)
+	for _, part := range parts {
+		n := binary.PutUvarint(buf, uint64(len(part)))
+		b.Write(buf[:n])
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// splitBoltKey returns the length prefixed parts of the key.
+func splitBoltKey(key string) ([]string, bool) {
+	parts := []string{}
+	for len(key) > 0 {
+		size, n := binary.Uvarint([]byte(key))
+		if n <= 0 || uint64(len(key)-n) < size {
+			return nil, false
+		}
+		parts = append(parts, key[n:n+int(size)])
+		key = key[n+int(size):]
+	}
+	return parts, true
+}
+
+// adjacencyKey returns the key prefix of the edges of the node in the direction.
+func adjacencyKey(uid string, dir Direction) string {
+	return boltKey(uid, fmt.Sprint(int(dir)))
+}
+
+// propertyKey returns the key prefix of the nodes with the property value
+// in the property index.
+func propertyKey(def IndexDef, value []byte) string {
+	return boltKey(def.Label, def.Property, string(value))
+}
+
+// boltNode is a node as stored in a bolt database, keyed by its UID.
+type boltNode struct {
+	Labels     []string          `json:"labels"`
+	Properties map[string][]byte `json:"properties"`
+	Version    uint64            `json:"version"`
+}
+
+// boltEdge is a edge as stored in a bolt database, keyed by its UID.
+type boltEdge struct {
+	SourceUID  string            `json:"source_uid"`
+	Label      string            `json:"label"`
+	TargetUID  string            `json:"target_uid"`
+	Properties map[string][]byte `json:"properties"`
+	Version    uint64            `json:"version"`
+}
+
+// pendingItem is a change to a key of a bucket which is not yet committed.
+type pendingItem struct {
+	key     string
+	value   []byte
+	deleted bool
+}
+
+// Less orders the items by key.
+func (item pendingItem) Less(than btree.Item) bool {
+	return item.key < than.(pendingItem).key
+}
+
+// BoltStorage stores the shards of a graph in a bolt database file, so the
+// graph can be larger than the memory. The changes to a shard are kept in
+// memory until the version is committed, when the changes to all the
+// shards are written in a single transaction. The definitions of the
+// range and full-text search indexes, constraints and edge schemas are
+// written along with them, and the indexes are rebuilt by the graph using
+// the storage.
+//
+// As bolt has a single writer, every change to the graph holds its write
+// lock. The storage is not versioned: a snapshot reads the database as
+// last committed, each read in a transaction of its own, so no earlier
+// versions of the graph are kept. Errors reading the database from a
+// snapshot are returned by the snapshot.
+type BoltStorage struct {
+	db     *bolt.DB
+	shards [shardCount]*boltShard
+	// committedSchema is the schema as last committed, and pendingSchema
+	// the schema to write with the next commit, if it has changed.
+	committedSchema storedSchema
+	pendingSchema   []byte
+}
+
+// OpenBoltStorage opens or creates the bolt database at the path.
+func OpenBoltStorage(path string) (*BoltStorage, error) {
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("[OpenBoltStorage] %w", err)
+	}
+
+	s := &BoltStorage{db: db}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for i := range s.shards {
+			shard, err := openBoltShard(s, tx, i)
+			if err != nil {
+				return err
+			}
+			s.shards[i] = shard
+		}
+
+		bucket, err := tx.CreateBucketIfNotExists([]byte(boltSchema))
+		if err != nil {
+			return err
+		}
+
+		if data := bucket.Get([]byte(boltSchema)); data != nil {
+			if err := json.Unmarshal(data, &s.committedSchema); err != nil {
+				return fmt.Errorf("Invalid schema: %w", err)
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("[OpenBoltStorage] %w", err)
+	}
+
+	return s, nil
+}
+
+// Shard returns the storage of the shard with the index.
+func (s *BoltStorage) Shard(index int) Storage {
+	return s.shards[index]
+}
+
+// Concurrent returns false, as bolt has a single writer.
+func (s *BoltStorage) Concurrent() bool {
+	return false
+}
+
+// Versioned returns false, as the committed views read the database.
+func (s *BoltStorage) Versioned() bool {
+	return false
+}
+
+// Close closes the database. The graph can no longer be read or changed.
+func (s *BoltStorage) Close() error {
+	if err := s.db.Close(); err != nil {
+		return fmt.Errorf("[Close] %w", err)
+	}
+	return nil
+}
+
+// schema returns the schema as last committed.
+func (s *BoltStorage) schema() storedSchema {
+	return s.committedSchema
+}
+
+// commitSchema writes the changes to all the shards to the database along
+// with the schema.
+func (s *BoltStorage) commitSchema(schema storedSchema) error {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return fmt.Errorf("[BoltStorage] %w", err)
+	}
+
+	s.pendingSchema = data
+	defer func() { s.pendingSchema = nil }()
+
+	if err := s.commit(); err != nil {
+		return err
+	}
+
+	s.committedSchema = schema
+	return nil
+}
+
+// commit writes the changes to all the shards to the database, along with
+// the schema if it has changed. Nothing is written if reading the database
+// failed while changing a shard, as the changes may have been made reading
+// a node or edge as missing.
+func (s *BoltStorage) commit() error {
+	changed := []*boltShard{}
+	for _, shard := range s.shards {
+		if shard.err != nil {
+			return shard.err
+		}
+		if shard.Changed() {
+			changed = append(changed, shard)
+		}
+	}
+
+	if len(changed) == 0 && s.pendingSchema == nil {
+		return nil
+	}
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, shard := range changed {
+			if err := shard.flush(tx); err != nil {
+				return err
+			}
+		}
+
+		if s.pendingSchema != nil {
+			return tx.Bucket([]byte(boltSchema)).Put([]byte(boltSchema), s.pendingSchema)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("[BoltStorage] %w", err)
+	}
+
+	for _, shard := range changed {
+		shard.committed()
+	}
+	return nil
+}
+
+// boltShard is the storage of a shard in a bolt database, with the changes
+// since the last commit kept in memory by bucket.
+type boltShard struct {
+	boltReader
+	storage *BoltStorage
+	name    []byte
+	pending map[string]*btree.BTree
+	nodes   int
+	edges   int
+	indexes map[IndexDef]struct{}
+	// err is the first error reading the database while changing the
+	// shard, failing the next commit.
+	err error
+	// lock guards the committed counts and indexes read by the views.
+	lock             sync.RWMutex
+	committedNodes   int
+	committedEdges   int
+	committedIndexes map[IndexDef]struct{}
+}
+
+// openBoltShard creates the buckets of the shard with the index if they
+// do not exist and loads its counts and index definitions.
+func openBoltShard(storage *BoltStorage, tx *bolt.Tx, index int) (*boltShard, error) {
+	s := &boltShard{
+		storage: storage,
+		name:    []byte(fmt.Sprintf("shard-%02d", index)),
+		pending: make(map[string]*btree.BTree),
+		indexes: make(map[IndexDef]struct{}),
+	}
+	s.boltReader = boltReader{shard: s, pending: true}
+
+	root, err := tx.CreateBucketIfNotExists(s.name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range boltBuckets {
+		if _, err := root.CreateBucketIfNotExists([]byte(name)); err != nil {
+			return nil, err
+		}
+	}
+
+	meta := root.Bucket([]byte(boltMeta))
+	if v := meta.Get([]byte(boltNodes)); len(v) == 8 {
+		s.nodes = int(binary.BigEndian.Uint64(v))
+	}
+	if v := meta.Get([]byte(boltEdges)); len(v) == 8 {
+		s.edges = int(binary.BigEndian.Uint64(v))
+	}
+
+	err = root.Bucket([]byte(boltIndexes)).ForEach(func(k, v []byte) error {
+		parts, ok := splitBoltKey(string(k))
+		if !ok || len(parts) != 2 {
+			return fmt.Errorf("Invalid property index key %q", k)
+		}
+		s.indexes[IndexDef{Label: parts[0], Property: parts[1]}] = struct{}{}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.committed()
+	return s, nil
+}
+
+// put sets the key of the bucket to the value.
+func (s *boltShard) put(bucket, key string, value []byte) {
+	tree, ok := s.pending[bucket]
+	if !ok {
+		tree = btree.New(snapshotDegree)
+		s.pending[bucket] = tree
+	}
+	tree.ReplaceOrInsert(pendingItem{key: key, value: value})
+}
+
+// delete removes the key from the bucket.
+func (s *boltShard) delete(bucket, key string) {
+	tree, ok := s.pending[bucket]
+	if !ok {
+		tree = btree.New(snapshotDegree)
+		s.pending[bucket] = tree
+	}
+	tree.ReplaceOrInsert(pendingItem{key: key, deleted: true})
+}
+
+// flush writes the pending changes and the counts to the database.
+func (s *boltShard) flush(tx *bolt.Tx) error {
+	root := tx.Bucket(s.name)
+	for name, tree := range s.pending {
+		bucket := root.Bucket([]byte(name))
+
+		var err error
+		tree.Ascend(func(i btree.Item) bool {
+			item := i.(pendingItem)
+			if item.deleted {
+				err = bucket.Delete([]byte(item.key))
+			} else {
+				err = bucket.Put([]byte(item.key), item.value)
+			}
+			return err == nil
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	meta := root.Bucket([]byte(boltMeta))
+	for key, count := range map[string]int{boltNodes: s.nodes, boltEdges: s.edges} {
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, uint64(count))
+		if err := meta.Put([]byte(key), value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// committed drops the pending changes once written and updates the counts
+// and indexes read by the views.
+func (s *boltShard) committed() {
+	s.pending = make(map[string]*btree.BTree)
+
+	indexes := make(map[IndexDef]struct{}, len(s.indexes))
+	for def := range s.indexes {
+		indexes[def] = struct{}{}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.committedNodes = s.nodes
+	s.committedEdges = s.edges
+	s.committedIndexes = indexes
+}
+
+// indexNode adds the node to the label and property indexes.
+func (s *boltShard) indexNode(node Node) {
+	for _, label := range node.Labels {
+		s.put(boltNodeLabels, boltKey(label)+node.UID, []byte{})
+	}
+
+	for def := range s.indexes {
+		if value, ok := node.Properties[def.Property]; ok && node.HasLabel(def.Label) {
+			s.put(boltProperties, propertyKey(def, value)+node.UID, []byte{})
+		}
+	}
+}
+
+// unindexNode removes the node from the label and property indexes.
+func (s *boltShard) unindexNode(node Node) {
+	for _, label := range node.Labels {
+		s.delete(boltNodeLabels, boltKey(label)+node.UID)
+	}
+
+	for def := range s.indexes {
+		if value, ok := node.Properties[def.Property]; ok && node.HasLabel(def.Label) {
+			s.delete(boltProperties, propertyKey(def, value)+node.UID)
+		}
+	}
+}
+
+// PutNode adds or replaces the node, keeping the edges of a replaced node.
+func (s *boltShard) PutNode(node Node) error {
+	data, err := json.Marshal(boltNode{Labels: node.Labels, Properties: node.Properties, Version: node.Version})
+	if err != nil {
+		return fmt.Errorf("[BoltStorage] %w", err)
+	}
+
+	if current, ok := s.Node(node.UID); ok {
+		s.unindexNode(current)
+	} else {
+		s.nodes++
+	}
+
+	s.put(boltNodes, node.UID, data)
+	s.indexNode(node)
+	return nil
+}
+
+// DeleteNode removes the node.
+func (s *boltShard) DeleteNode(uid string) {
+	if current, ok := s.Node(uid); ok {
+		s.unindexNode(current)
+		s.delete(boltNodes, uid)
+		s.nodes--
+	}
+}
+
+// PutEdge adds or replaces the edge.
+func (s *boltShard) PutEdge(edge Edge) error {
+	data, err := json.Marshal(boltEdge{
+		SourceUID:  edge.SourceUID,
+		Label:      edge.Label,
+		TargetUID:  edge.TargetUID,
+		Properties: edge.Properties,
+		Version:    edge.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("[BoltStorage] %w", err)
+	}
+
+	if current, ok := s.Edge(edge.UID); ok {
+		s.delete(boltEdgeLabels, boltKey(current.Label)+edge.UID)
+	} else {
+		s.edges++
+	}
+
+	s.put(boltEdges, edge.UID, data)
+	s.put(boltEdgeLabels, boltKey(edge.Label)+edge.UID, []byte{})
+	return nil
+}
+
+// DeleteEdge removes the edge.
+func (s *boltShard) DeleteEdge(uid string) {
+	if current, ok := s.Edge(uid); ok {
+		s.delete(boltEdgeLabels, boltKey(current.Label)+uid)
+		s.delete(boltEdges, uid)
+		s.edges--
+	}
+}
+
+// Link adds the edge to the adjacency of the node.
+func (s *boltShard) Link(uid string, dir Direction, edge string) {
+	s.put(boltAdjacency, adjacencyKey(uid, dir)+edge, []byte{})
+}
+
+// Unlink removes the edge from the adjacency of the node.
+func (s *boltShard) Unlink(uid string, dir Direction, edge string) {
+	s.delete(boltAdjacency, adjacencyKey(uid, dir)+edge)
+}
+
+// Degree returns the number of edges attached to the node.
+func (s *boltShard) Degree(uid string) int {
+	return len(readAll(s.Adjacency(uid, IN, ""))) + len(readAll(s.Adjacency(uid, OUT, "")))
+}
+
+// CreateIndex adds the property index and indexes the existing nodes.
+func (s *boltShard) CreateIndex(def IndexDef) {
+	s.indexes[def] = struct{}{}
+	s.put(boltIndexes, boltKey(def.Label, def.Property), []byte{})
+
+	nodes := s.NodesByLabel(def.Label, "")
+	for uid, ok := nodes(); ok; uid, ok = nodes() {
+		node, _ := s.Node(uid)
+		if value, ok := node.Properties[def.Property]; ok {
+			s.put(boltProperties, propertyKey(def, value)+uid, []byte{})
+		}
+	}
+}
+
+// DropIndex removes the property index.
+func (s *boltShard) DropIndex(def IndexDef) {
+	delete(s.indexes, def)
+	s.delete(boltIndexes, boltKey(def.Label, def.Property))
+
+	prefix := boltKey(def.Label, def.Property)
+	keys := s.keys(boltProperties, prefix, "")
+	for key, ok := keys(); ok; key, ok = keys() {
+		s.delete(boltProperties, prefix+key)
+	}
+}
+
+// Indexes returns the definitions of the property indexes.
+func (s *boltShard) Indexes() []IndexDef {
+	defs := make([]IndexDef, 0, len(s.indexes))
+	for def := range s.indexes {
+		defs = append(defs, def)
+	}
+
+	sort.Slice(defs, func(i, j int) bool {
+		if defs[i].Label != defs[j].Label {
+			return defs[i].Label < defs[j].Label
+		}
+		return defs[i].Property < defs[j].Property
+	})
+
+	return defs
+}
+
+// Changed returns true if there are changes waiting to be committed, or
+// reading the database failed while changing the shard so the commit fails.
+func (s *boltShard) Changed() bool {
+	return len(s.pending) > 0 || s.err != nil
+}
+
+// Commit writes the changes of all the shards to the database and returns
+// a view reading the shard as committed. If the changes can not be
+// written, they are kept until discarded.
+func (s *boltShard) Commit() (StorageView, error) {
+	if err := s.storage.commit(); err != nil {
+		return nil, err
+	}
+	return boltReader{shard: s}, nil
+}
+
+// Discard drops the changes since the last commit.
+func (s *boltShard) Discard() {
+	s.pending = make(map[string]*btree.BTree)
+	s.err = nil
+
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	s.nodes = s.committedNodes
+	s.edges = s.committedEdges
+	s.indexes = make(map[IndexDef]struct{}, len(s.committedIndexes))
+	for def := range s.committedIndexes {
+		s.indexes[def] = struct{}{}
+	}
+}
+
+// Size returns a estimate of the memory used by the changes waiting to be
+// committed in bytes, and the number of nodes and edges.
+func (s *boltShard) Size() (int, int) {
+	size := 0
+	for _, tree := range s.pending {
+		size += treeSize(tree, unsafe.Sizeof(pendingItem{}))
+		tree.Ascend(func(i btree.Item) bool {
+			item := i.(pendingItem)
+			size += len(item.key) + len(item.value)
+			return true
+		})
+	}
+	return size, s.nodes + s.edges
+}
+
+// boltReader reads a shard in a bolt database. Pending is true for reading
+// the changes which are not yet committed along with the database, which
+// is only done while holding the lock of the shard. Otherwise the reader
+// is a view of the snapshot, which it is linked to.
+type boltReader struct {
+	shard    *boltShard
+	pending  bool
+	snapshot *Snapshot
+}
+
+// link returns the view reporting the errors reading the database to the
+// snapshot.
+func (r boltReader) link(s *Snapshot) StorageView {
+	r.snapshot = s
+	return r
+}
+
+// fail records the error reading the database. Reading the changes of the
+// shard fails its next commit, otherwise the error is returned by the
+// snapshot.
+func (r boltReader) fail(err error) {
+	err = fmt.Errorf("[BoltStorage] %w", err)
+	if !r.pending {
+		if r.snapshot != nil {
+			r.snapshot.fail(err)
+		}
+		return
+	}
+
+	if r.shard.err == nil {
+		r.shard.err = err
+	}
+}
+
+// get returns the value of the key in the bucket.
+func (r boltReader) get(bucket, key string) ([]byte, bool) {
+	if r.pending {
+		if tree, ok := r.shard.pending[bucket]; ok {
+			if item := tree.Get(pendingItem{key: key}); item != nil {
+				change := item.(pendingItem)
+				return change.value, !change.deleted
+			}
+		}
+	}
+
+	var value []byte
+	err := r.shard.storage.db.View(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(r.shard.name).Bucket([]byte(bucket)).Get([]byte(key)); v != nil {
+			value = append([]byte{}, v...)
+		}
+		return nil
+	})
+
+	if err != nil {
+		r.fail(err)
+		return nil, false
+	}
+
+	return value, value != nil
+}
+
+// keys returns the keys of the bucket with the prefix, after the prefix
+// followed by after, with the prefix removed.
+func (r boltReader) keys(bucket, prefix, after string) Cursor {
+	from := prefix + after
+	keys := r.stored(bucket, prefix, from, after != "")
+
+	if r.pending {
+		if tree, ok := r.shard.pending[bucket]; ok {
+			keys = mergePending(keys, newTreeCursor(tree, pendingItem{key: from}, after != "", func(i btree.Item) bool {
+				return !strings.HasPrefix(i.(pendingItem).key, prefix)
+			}))
+		}
+	}
+
+	return func() (string, bool) {
+		key, ok := keys()
+		if !ok {
+			return "", false
+		}
+		return key[len(prefix):], true
+	}
+}
+
+// stored returns the keys of the bucket in the database with the prefix,
+// starting from the key from, or after it if after is true. The keys are
+// read in batches, each in a read transaction of its own, so no
+// transaction is left open between batches.
+func (r boltReader) stored(bucket, prefix, from string, after bool) Cursor {
+	batch := []string{}
+	done := false
+
+	fill := func() {
+		err := r.shard.storage.db.View(func(tx *bolt.Tx) error {
+			c := tx.Bucket(r.shard.name).Bucket([]byte(bucket)).Cursor()
+			k, _ := c.Seek([]byte(from))
+			if after && k != nil && string(k) == from {
+				k, _ = c.Next()
+			}
+
+			for ; k != nil && len(batch) < cursorBatchSize; k, _ = c.Next() {
+				if !strings.HasPrefix(string(k), prefix) {
+					done = true
+					return nil
+				}
+				batch = append(batch, string(k))
+			}
+
+			done = done || k == nil
+			return nil
+		})
+
+		if err != nil {
+			r.fail(err)
+			done = true
+		}
+
+		if len(batch) > 0 {
+			from, after = batch[len(batch)-1], true
+		}
+	}
+
+	return func() (string, bool) {
+		if len(batch) == 0 && !done {
+			fill()
+		}
+
+		if len(batch) == 0 {
+			return "", false
+		}
+
+		key := batch[0]
+		batch = batch[1:]
+		return key, true
+	}
+}
+
+// mergePending returns the stored keys with the pending changes applied,
+// in order.
+func mergePending(stored Cursor, pending *treeCursor) Cursor {
+	key, stok := stored()
+	next := func() (pendingItem, bool) {
+		item, ok := pending.next()
+		if !ok {
+			return pendingItem{}, false
+		}
+		return item.(pendingItem), true
+	}
+	change, pok := next()
+
+	return func() (string, bool) {
+		for stok || pok {
+			if pok && (!stok || change.key <= key) {
+				current := change
+				if stok && change.key == key {
+					key, stok = stored()
+				}
+				change, pok = next()
+
+				if !current.deleted {
+					return current.key, true
+				}
+				continue
+			}
+
+			current := key
+			key, stok = stored()
+			return current, true
+		}
+		return "", false
+	}
+}
+
+// Node returns the node with the uid.
+func (r boltReader) Node(uid string) (Node, bool) {
+	data, ok := r.get(boltNodes, uid)
+	if !ok {
+		return Node{}, false
+	}
+
+	stored := boltNode{}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		r.fail(err)
+		return Node{}, false
+	}
+
+	node := Node{UID: uid, Labels: stored.Labels, Properties: stored.Properties, Version: stored.Version}
+	if node.Labels == nil {
+		node.Labels = []string{}
+	}
+	if node.Properties == nil {
+		node.Properties = make(map[string][]byte)
+	}
+
+	return node, true
+}
+
+// HasNode returns true if there is a node with the uid.
+func (r boltReader) HasNode(uid string) bool {
+	_, ok := r.get(boltNodes, uid)
+	return ok
+}
+
+// Edge returns the edge with the uid.
+func (r boltReader) Edge(uid string) (Edge, bool) {
+	data, ok := r.get(boltEdges, uid)
+	if !ok {
+		return Edge{}, false
+	}
+
+	stored := boltEdge{}
+	if err := json.Unmarshal(data, &stored); err != nil {
+		r.fail(err)
+		return Edge{}, false
+	}
+
+	edge := Edge{
+		UID:        uid,
+		SourceUID:  stored.SourceUID,
+		Label:      stored.Label,
+		TargetUID:  stored.TargetUID,
+		Properties: stored.Properties,
+		Version:    stored.Version,
+	}
+	if edge.Properties == nil {
+		edge.Properties = make(map[string][]byte)
+	}
+
+	return edge, true
+}
+
+// HasEdge returns true if there is a edge with the uid.
+func (r boltReader) HasEdge(uid string) bool {
+	_, ok := r.get(boltEdges, uid)
+	return ok
+}
+
+// NodeCount returns the number of nodes.
+func (r boltReader) NodeCount() int {
+	if r.pending {
+		return r.shard.nodes
+	}
+
+	r.shard.lock.RLock()
+	defer r.shard.lock.RUnlock()
+	return r.shard.committedNodes
+}
+
+// EdgeCount returns the number of edges.
+func (r boltReader) EdgeCount() int {
+	if r.pending {
+		return r.shard.edges
+	}
+
+	r.shard.lock.RLock()
+	defer r.shard.lock.RUnlock()
+	return r.shard.committedEdges
+}
+
+// Nodes returns the uids of the nodes after the uid after.
+func (r boltReader) Nodes(after string) Cursor {
+	return r.keys(boltNodes, "", after)
+}
+
+// Edges returns the uids of the edges after the uid after.
+func (r boltReader) Edges(after string) Cursor {
+	return r.keys(boltEdges, "", after)
+}
+
+// Adjacency returns the uids of the edges of the node in the direction.
+func (r boltReader) Adjacency(uid string, dir Direction, after string) Cursor {
+	return r.keys(boltAdjacency, adjacencyKey(uid, dir), after)
+}
+
+// NodesByLabel returns the uids of the nodes with the label.
+func (r boltReader) NodesByLabel(label, after string) Cursor {
+	return r.keys(boltNodeLabels, boltKey(label), after)
+}
+
+// EdgesByLabel returns the uids of the edges with the label.
+func (r boltReader) EdgesByLabel(label, after string) Cursor {
+	return r.keys(boltEdgeLabels, boltKey(label), after)
+}
+
+// NodesByProperty returns the uids of the nodes with the property value.
+func (r boltReader) NodesByProperty(def IndexDef, value []byte, after string) (Cursor, bool) {
+	indexes := r.shard.indexes
+	if !r.pending {
+		r.shard.lock.RLock()
+		indexes = r.shard.committedIndexes
+		r.shard.lock.RUnlock()
+	}
+
+	if _, ok := indexes[def]; !ok {
+		return nil, false
+	}
+	return r.keys(boltProperties, propertyKey(def, value), after), true
+}
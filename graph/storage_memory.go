@@ -0,0 +1,651 @@
+package graph
+
+import (
+	"sort"
+	"sync"
+	"unsafe"
+
+	"github.com/google/btree"
+)
+
+// snapshotDegree is the btree degree used by the snapshot trees.
+const snapshotDegree = 32
+
+// pageSize is the number of records in a page.
+const pageSize = 16
+
+// idItem is the internal id of the node or edge with the uid.
+type idItem struct {
+	uid string
+	id  elemID
+}
+
+// Less orders the ids by UID.
+func (item idItem) Less(than btree.Item) bool {
+	return item.uid < than.(idItem).uid
+}
+
+// pageItem is the page of node or edge records at the positions from
+// n*pageSize of a shard. A page is only changed in place by the shard if
+// it was copied in the generation of the shard, otherwise it may be shared
+// with committed views and is copied first.
+type pageItem struct {
+	n     int
+	gen   uint64
+	nodes *[pageSize]nodeRecord
+	edges *[pageSize]edgeRecord
+}
+
+// Less orders the pages by position.
+func (item pageItem) Less(than btree.Item) bool {
+	return item.n < than.(pageItem).n
+}
+
+// keyItem is a key, such as a label or a property value, and the uid of
+// a node or edge having that key.
+type keyItem struct {
+	key string
+	uid string
+}
+
+// Less orders the items by key and then uid.
+func (item keyItem) Less(than btree.Item) bool {
+	other := than.(keyItem)
+	if item.key != other.key {
+		return item.key < other.key
+	}
+	return item.uid < other.uid
+}
+
+// snapshotTrees holds the nodes, edges and indexes of a shard of the graph
+// in copy-on-write btrees, which are cloned in constant time when
+// committing. Only the parts of the trees changed after a commit are
+// copied, so the committed views and the shard share everything else. The
+// records are kept once, in pages by position, and the id trees map the
+// uids to the positions. Changed is set when the shard has changed since
+// the last commit.
+type snapshotTrees struct {
+	changed    bool
+	gen        uint64
+	nodeIDs    *btree.BTree
+	nodePages  *btree.BTree
+	edgeIDs    *btree.BTree
+	edgePages  *btree.BTree
+	nodeLabels *btree.BTree
+	edgeLabels *btree.BTree
+	nodeProps  map[IndexDef]*btree.BTree
+}
+
+// newSnapshotTrees returns new empty snapshot trees.
+func newSnapshotTrees() snapshotTrees {
+	return snapshotTrees{
+		nodeIDs:    btree.New(snapshotDegree),
+		nodePages:  btree.New(snapshotDegree),
+		edgeIDs:    btree.New(snapshotDegree),
+		edgePages:  btree.New(snapshotDegree),
+		nodeLabels: btree.New(snapshotDegree),
+		edgeLabels: btree.New(snapshotDegree),
+		nodeProps:  make(map[IndexDef]*btree.BTree),
+	}
+}
+
+// lookup returns the id of the uid in the id tree.
+func lookup(ids *btree.BTree, uid string) (elemID, bool) {
+	item := ids.Get(idItem{uid: uid})
+	if item == nil {
+		return 0, false
+	}
+	return item.(idItem).id, true
+}
+
+// nodeRecord returns the record of the node with the id.
+func (t *snapshotTrees) nodeRecord(id elemID) nodeRecord {
+	page := t.nodePages.Get(pageItem{n: id.pos() / pageSize}).(pageItem)
+	return page.nodes[id.pos()%pageSize]
+}
+
+// edgeRecord returns the record of the edge with the id.
+func (t *snapshotTrees) edgeRecord(id elemID) edgeRecord {
+	page := t.edgePages.Get(pageItem{n: id.pos() / pageSize}).(pageItem)
+	return page.edges[id.pos()%pageSize]
+}
+
+// setNodeRecord sets the record of the node with the id, copying its page
+// unless it was copied since the last commit.
+func (t *snapshotTrees) setNodeRecord(id elemID, rec nodeRecord) {
+	n := id.pos() / pageSize
+	page, ok := t.nodePages.Get(pageItem{n: n}).(pageItem)
+	if !ok || page.gen != t.gen {
+		records := new([pageSize]nodeRecord)
+		if ok {
+			*records = *page.nodes
+		}
+		page = pageItem{n: n, gen: t.gen, nodes: records}
+		t.nodePages.ReplaceOrInsert(page)
+	}
+	page.nodes[id.pos()%pageSize] = rec
+	t.changed = true
+}
+
+// setEdgeRecord sets the record of the edge with the id, copying its page
+// unless it was copied since the last commit.
+func (t *snapshotTrees) setEdgeRecord(id elemID, rec edgeRecord) {
+	n := id.pos() / pageSize
+	page, ok := t.edgePages.Get(pageItem{n: n}).(pageItem)
+	if !ok || page.gen != t.gen {
+		records := new([pageSize]edgeRecord)
+		if ok {
+			*records = *page.edges
+		}
+		page = pageItem{n: n, gen: t.gen, edges: records}
+		t.edgePages.ReplaceOrInsert(page)
+	}
+	page.edges[id.pos()%pageSize] = rec
+	t.changed = true
+}
+
+// indexNode adds the labels and indexed properties of the node.
+func (t *snapshotTrees) indexNode(node Node) {
+	for _, label := range node.Labels {
+		t.nodeLabels.ReplaceOrInsert(keyItem{key: label, uid: node.UID})
+	}
+
+	for def, tree := range t.nodeProps {
+		if value, ok := node.Properties[def.Property]; ok && node.HasLabel(def.Label) {
+			tree.ReplaceOrInsert(keyItem{key: string(value), uid: node.UID})
+		}
+	}
+}
+
+// unindexNode removes the labels and indexed properties of the node.
+func (t *snapshotTrees) unindexNode(node Node) {
+	for _, label := range node.Labels {
+		t.nodeLabels.Delete(keyItem{key: label, uid: node.UID})
+	}
+
+	for def, tree := range t.nodeProps {
+		if value, ok := node.Properties[def.Property]; ok && node.HasLabel(def.Label) {
+			tree.Delete(keyItem{key: string(value), uid: node.UID})
+		}
+	}
+}
+
+// clone returns a copy of the trees. Cloning a btree changes the
+// copy-on-write state of the original tree, so the caller is expected to
+// be holding the lock of the shard.
+func (t *snapshotTrees) clone() snapshotTrees {
+	c := snapshotTrees{
+		gen:        t.gen,
+		nodeIDs:    t.nodeIDs.Clone(),
+		nodePages:  t.nodePages.Clone(),
+		edgeIDs:    t.edgeIDs.Clone(),
+		edgePages:  t.edgePages.Clone(),
+		nodeLabels: t.nodeLabels.Clone(),
+		edgeLabels: t.edgeLabels.Clone(),
+		nodeProps:  make(map[IndexDef]*btree.BTree, len(t.nodeProps)),
+	}
+
+	for def, tree := range t.nodeProps {
+		c.nodeProps[def] = tree.Clone()
+	}
+
+	return c
+}
+
+// size returns a estimate of the memory used by the trees in bytes, other
+// than the pages. The values of the property indexes are copies, the other
+// keys and uids are shared with the records.
+func (t *snapshotTrees) size() int {
+	size := treeSize(t.nodeIDs, unsafe.Sizeof(idItem{}))
+	size += treeSize(t.nodePages, unsafe.Sizeof(pageItem{}))
+	size += treeSize(t.edgeIDs, unsafe.Sizeof(idItem{}))
+	size += treeSize(t.edgePages, unsafe.Sizeof(pageItem{}))
+	size += treeSize(t.nodeLabels, unsafe.Sizeof(keyItem{}))
+	size += treeSize(t.edgeLabels, unsafe.Sizeof(keyItem{}))
+
+	for _, tree := range t.nodeProps {
+		size += treeSize(tree, unsafe.Sizeof(keyItem{}))
+		tree.Ascend(func(i btree.Item) bool {
+			size += len(i.(keyItem).key)
+			return true
+		})
+	}
+
+	return size
+}
+
+// treeView reads a shard from its snapshot trees. The adjacency of the
+// nodes holds the ids of the edges, which may be in other shards, so
+// edgeUID returns the uid of the edge with a id.
+type treeView struct {
+	trees   snapshotTrees
+	symbols *symbolTable
+	edgeUID func(id elemID) string
+}
+
+// link returns the view reading the edges of other shards from the views
+// of the snapshot.
+func (v treeView) link(s *Snapshot) StorageView {
+	views := &s.shards
+	v.edgeUID = func(id elemID) string {
+		view := views[id.shard()].(treeView)
+		return view.trees.edgeRecord(id).uid
+	}
+	return v
+}
+
+// Node returns the node with the uid.
+func (v treeView) Node(uid string) (Node, bool) {
+	id, ok := lookup(v.trees.nodeIDs, uid)
+	if !ok {
+		return Node{}, false
+	}
+	return v.symbols.node(v.trees.nodeRecord(id)), true
+}
+
+// HasNode returns true if there is a node with the uid.
+func (v treeView) HasNode(uid string) bool {
+	return v.trees.nodeIDs.Has(idItem{uid: uid})
+}
+
+// Edge returns the edge with the uid.
+func (v treeView) Edge(uid string) (Edge, bool) {
+	id, ok := lookup(v.trees.edgeIDs, uid)
+	if !ok {
+		return Edge{}, false
+	}
+	return v.symbols.edge(v.trees.edgeRecord(id)), true
+}
+
+// HasEdge returns true if there is a edge with the uid.
+func (v treeView) HasEdge(uid string) bool {
+	return v.trees.edgeIDs.Has(idItem{uid: uid})
+}
+
+// NodeCount returns the number of nodes.
+func (v treeView) NodeCount() int {
+	return v.trees.nodeIDs.Len()
+}
+
+// EdgeCount returns the number of edges.
+func (v treeView) EdgeCount() int {
+	return v.trees.edgeIDs.Len()
+}
+
+// idCursor returns the uids of the id tree after the uid after.
+func idCursor(ids *btree.BTree, after string) Cursor {
+	cursor := newTreeCursor(ids, idItem{uid: after}, after != "", nil)
+	return func() (string, bool) {
+		item, ok := cursor.next()
+		if !ok {
+			return "", false
+		}
+		return item.(idItem).uid, true
+	}
+}
+
+// Nodes returns the uids of the nodes after the uid after.
+func (v treeView) Nodes(after string) Cursor {
+	return idCursor(v.trees.nodeIDs, after)
+}
+
+// Edges returns the uids of the edges after the uid after.
+func (v treeView) Edges(after string) Cursor {
+	return idCursor(v.trees.edgeIDs, after)
+}
+
+// Adjacency returns the uids of the edges of the node in the direction.
+func (v treeView) Adjacency(uid string, dir Direction, after string) Cursor {
+	uids := []string{}
+	if id, ok := lookup(v.trees.nodeIDs, uid); ok {
+		rec := v.trees.nodeRecord(id)
+		for _, edge := range *rec.adjacency(dir) {
+			if edgeUID := v.edgeUID(edge); edgeUID > after {
+				uids = append(uids, edgeUID)
+			}
+		}
+	}
+	sort.Strings(uids)
+
+	return func() (string, bool) {
+		if len(uids) == 0 {
+			return "", false
+		}
+		uid := uids[0]
+		uids = uids[1:]
+		return uid, true
+	}
+}
+
+// NodesByLabel returns the uids of the nodes with the label.
+func (v treeView) NodesByLabel(label, after string) Cursor {
+	return Cursor(keySource(v.trees.nodeLabels, label, after))
+}
+
+// EdgesByLabel returns the uids of the edges with the label.
+func (v treeView) EdgesByLabel(label, after string) Cursor {
+	return Cursor(keySource(v.trees.edgeLabels, label, after))
+}
+
+// NodesByProperty returns the uids of the nodes with the property value.
+func (v treeView) NodesByProperty(def IndexDef, value []byte, after string) (Cursor, bool) {
+	tree, ok := v.trees.nodeProps[def]
+	if !ok {
+		return nil, false
+	}
+	return Cursor(keySource(tree, string(value), after)), true
+}
+
+// memoryBackend keeps the shards of a graph in memory.
+type memoryBackend struct {
+	shards [shardCount]*memoryShard
+}
+
+// newMemoryBackend returns a new empty memory backend interning the labels
+// and property keys in the symbol table.
+func newMemoryBackend(symbols *symbolTable) *memoryBackend {
+	b := &memoryBackend{}
+	for i := range b.shards {
+		b.shards[i] = newMemoryShard(b, i, symbols)
+	}
+	return b
+}
+
+// edgeID returns the id of the edge with the uid, from the shard of the
+// edge.
+func (b *memoryBackend) edgeID(uid string) (elemID, bool) {
+	s := b.shards[shardIndex(uid)]
+	s.edgeLock.RLock()
+	defer s.edgeLock.RUnlock()
+	return lookup(s.trees.edgeIDs, uid)
+}
+
+// edgeUID returns the uid of the edge with the id, from the shard of the
+// edge.
+func (b *memoryBackend) edgeUID(id elemID) string {
+	s := b.shards[id.shard()]
+	s.edgeLock.RLock()
+	defer s.edgeLock.RUnlock()
+	return s.trees.edgeRecord(id).uid
+}
+
+// Shard returns the storage of the shard with the index.
+func (b *memoryBackend) Shard(index int) Storage {
+	return b.shards[index]
+}
+
+// Concurrent returns true, as each shard is changed on its own.
+func (b *memoryBackend) Concurrent() bool {
+	return true
+}
+
+// Versioned returns true, as the committed views are clones of the trees.
+func (b *memoryBackend) Versioned() bool {
+	return true
+}
+
+// memoryShard is the in-memory storage of a shard, kept in snapshot trees
+// shared with its committed views. The adjacency of the nodes is read
+// while holding the lock of the node shard only, so the edge lock guards
+// the edges of the shard against the writers of the shard.
+type memoryShard struct {
+	treeView
+	backend   *memoryBackend
+	index     int
+	edgeLock  sync.RWMutex
+	nodeCount int
+	freeNodes []int
+	edgeCount int
+	freeEdges []int
+}
+
+// newMemoryShard returns a new empty shard of the backend with the index.
+func newMemoryShard(backend *memoryBackend, index int, symbols *symbolTable) *memoryShard {
+	return &memoryShard{
+		treeView: treeView{trees: newSnapshotTrees(), symbols: symbols, edgeUID: backend.edgeUID},
+		backend:  backend,
+		index:    index,
+	}
+}
+
+// newNodeID returns the id of a free position for a node.
+func (s *memoryShard) newNodeID() elemID {
+	if n := len(s.freeNodes); n > 0 {
+		pos := s.freeNodes[n-1]
+		s.freeNodes = s.freeNodes[:n-1]
+		return newElemID(s.index, pos)
+	}
+
+	s.nodeCount++
+	return newElemID(s.index, s.nodeCount-1)
+}
+
+// newEdgeID returns the id of a free position for a edge.
+func (s *memoryShard) newEdgeID() elemID {
+	if n := len(s.freeEdges); n > 0 {
+		pos := s.freeEdges[n-1]
+		s.freeEdges = s.freeEdges[:n-1]
+		return newElemID(s.index, pos)
+	}
+
+	s.edgeCount++
+	return newElemID(s.index, s.edgeCount-1)
+}
+
+// PutNode adds or replaces the node, keeping the edges of a replaced node.
+func (s *memoryShard) PutNode(node Node) error {
+	rec := s.symbols.compactNode(node)
+	id, ok := lookup(s.trees.nodeIDs, node.UID)
+	if ok {
+		current := s.trees.nodeRecord(id)
+		rec.in, rec.out = current.in, current.out
+		s.trees.unindexNode(s.symbols.node(current))
+	} else {
+		id = s.newNodeID()
+		s.trees.nodeIDs.ReplaceOrInsert(idItem{uid: node.UID, id: id})
+	}
+
+	s.trees.setNodeRecord(id, rec)
+	s.trees.indexNode(node)
+	return nil
+}
+
+// DeleteNode removes the node, freeing its position for reuse.
+func (s *memoryShard) DeleteNode(uid string) {
+	id, ok := lookup(s.trees.nodeIDs, uid)
+	if !ok {
+		return
+	}
+
+	s.trees.unindexNode(s.symbols.node(s.trees.nodeRecord(id)))
+	s.trees.setNodeRecord(id, nodeRecord{})
+	s.trees.nodeIDs.Delete(idItem{uid: uid})
+	s.freeNodes = append(s.freeNodes, id.pos())
+}
+
+// PutEdge adds or replaces the edge.
+func (s *memoryShard) PutEdge(edge Edge) error {
+	rec := s.symbols.compactEdge(edge)
+
+	s.edgeLock.Lock()
+	defer s.edgeLock.Unlock()
+
+	id, ok := lookup(s.trees.edgeIDs, edge.UID)
+	if ok {
+		current := s.trees.edgeRecord(id)
+		s.trees.edgeLabels.Delete(keyItem{key: s.symbols.list()[current.label], uid: edge.UID})
+	} else {
+		id = s.newEdgeID()
+		s.trees.edgeIDs.ReplaceOrInsert(idItem{uid: edge.UID, id: id})
+	}
+
+	s.trees.setEdgeRecord(id, rec)
+	s.trees.edgeLabels.ReplaceOrInsert(keyItem{key: edge.Label, uid: edge.UID})
+	return nil
+}
+
+// DeleteEdge removes the edge, freeing its position for reuse.
+func (s *memoryShard) DeleteEdge(uid string) {
+	s.edgeLock.Lock()
+	defer s.edgeLock.Unlock()
+
+	id, ok := lookup(s.trees.edgeIDs, uid)
+	if !ok {
+		return
+	}
+
+	current := s.trees.edgeRecord(id)
+	s.trees.edgeLabels.Delete(keyItem{key: s.symbols.list()[current.label], uid: uid})
+	s.trees.setEdgeRecord(id, edgeRecord{})
+	s.trees.edgeIDs.Delete(idItem{uid: uid})
+	s.freeEdges = append(s.freeEdges, id.pos())
+}
+
+// Link adds the edge to the adjacency of the node, unless it is already
+// there. The edge is expected to be stored.
+func (s *memoryShard) Link(uid string, dir Direction, edge string) {
+	id, ok := lookup(s.trees.nodeIDs, uid)
+	if !ok {
+		return
+	}
+
+	edgeID, ok := s.backend.edgeID(edge)
+	if !ok {
+		return
+	}
+
+	rec := s.trees.nodeRecord(id)
+	ids := rec.adjacency(dir)
+	for _, linked := range *ids {
+		if linked == edgeID {
+			return
+		}
+	}
+
+	// The views sharing the slice never read past their own length.
+	*ids = append(*ids, edgeID)
+	s.trees.setNodeRecord(id, rec)
+}
+
+// Unlink removes the edge from the adjacency of the node. The edge is
+// expected to be stored.
+func (s *memoryShard) Unlink(uid string, dir Direction, edge string) {
+	id, ok := lookup(s.trees.nodeIDs, uid)
+	if !ok {
+		return
+	}
+
+	edgeID, ok := s.backend.edgeID(edge)
+	if !ok {
+		return
+	}
+
+	rec := s.trees.nodeRecord(id)
+	ids := rec.adjacency(dir)
+	for i, linked := range *ids {
+		if linked != edgeID {
+			continue
+		}
+
+		// The slice may be shared with views, so the rest is copied.
+		var rest []elemID
+		if len(*ids) > 1 {
+			rest = make([]elemID, 0, len(*ids)-1)
+			rest = append(append(rest, (*ids)[:i]...), (*ids)[i+1:]...)
+		}
+		*ids = rest
+		s.trees.setNodeRecord(id, rec)
+		return
+	}
+}
+
+// Degree returns the number of edges attached to the node.
+func (s *memoryShard) Degree(uid string) int {
+	id, ok := lookup(s.trees.nodeIDs, uid)
+	if !ok {
+		return 0
+	}
+
+	rec := s.trees.nodeRecord(id)
+	return len(rec.in) + len(rec.out)
+}
+
+// CreateIndex adds the property index and indexes the existing nodes.
+func (s *memoryShard) CreateIndex(def IndexDef) {
+	tree := btree.New(snapshotDegree)
+	nodes := s.NodesByLabel(def.Label, "")
+	for uid, ok := nodes(); ok; uid, ok = nodes() {
+		node, _ := s.Node(uid)
+		if value, ok := node.Properties[def.Property]; ok {
+			tree.ReplaceOrInsert(keyItem{key: string(value), uid: uid})
+		}
+	}
+
+	s.trees.nodeProps[def] = tree
+	s.trees.changed = true
+}
+
+// DropIndex removes the property index.
+func (s *memoryShard) DropIndex(def IndexDef) {
+	delete(s.trees.nodeProps, def)
+	s.trees.changed = true
+}
+
+// Indexes returns the definitions of the property indexes.
+func (s *memoryShard) Indexes() []IndexDef {
+	defs := make([]IndexDef, 0, len(s.trees.nodeProps))
+	for def := range s.trees.nodeProps {
+		defs = append(defs, def)
+	}
+	return defs
+}
+
+// Changed returns true if the shard has changed since the last commit.
+func (s *memoryShard) Changed() bool {
+	return s.trees.changed
+}
+
+// Commit returns a view of the shard over a clone of the snapshot trees,
+// and starts a new generation, so the pages are copied before changing
+// them. The view reads the edges of other shards once it is linked to
+// the views of a snapshot.
+func (s *memoryShard) Commit() (StorageView, error) {
+	s.edgeLock.Lock()
+	defer s.edgeLock.Unlock()
+
+	s.trees.changed = false
+	view := treeView{trees: s.trees.clone(), symbols: s.symbols}
+	s.trees.gen++
+	return view, nil
+}
+
+// Discard marks the shard as unchanged since the last commit.
+func (s *memoryShard) Discard() {
+	s.trees.changed = false
+}
+
+// Size returns a estimate of the memory used by the shard in bytes, and
+// the number of nodes and edges. The pages of records are counted along
+// with the trees and indexes, which share the labels, properties and uids
+// of the records.
+func (s *memoryShard) Size() (int, int) {
+	size := s.trees.nodePages.Len() * int(unsafe.Sizeof([pageSize]nodeRecord{}))
+	size += s.trees.edgePages.Len() * int(unsafe.Sizeof([pageSize]edgeRecord{}))
+	size += (cap(s.freeNodes) + cap(s.freeEdges)) * int(unsafe.Sizeof(0))
+
+	s.trees.nodeIDs.Ascend(func(i btree.Item) bool {
+		rec := s.trees.nodeRecord(i.(idItem).id)
+		size += recordSize(rec.uid, rec.props)
+		size += cap(rec.labels) * int(unsafe.Sizeof(symbol(0)))
+		size += (cap(rec.in) + cap(rec.out)) * int(unsafe.Sizeof(elemID(0)))
+		return true
+	})
+
+	s.trees.edgeIDs.Ascend(func(i btree.Item) bool {
+		rec := s.trees.edgeRecord(i.(idItem).id)
+		size += recordSize(rec.uid, rec.props)
+		return true
+	})
+
+	return size + s.trees.size(), s.trees.nodeIDs.Len() + s.trees.edgeIDs.Len()
+}
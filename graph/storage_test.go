@@ -0,0 +1,490 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	bolt "go.etcd.io/bbolt"
+)
+
+// openBolt returns a bolt storage in a new temporary directory, and a
+// function closing it and removing the directory.
+func openBolt(t *testing.T) (*BoltStorage, func()) {
+	dir, cleanup := tempDir(t)
+	store, err := OpenBoltStorage(filepath.Join(dir, "draft.db"))
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	return store, func() {
+		store.Close()
+		cleanup()
+	}
+}
+
+// fillGraph makes the same changes to the graph whatever its storage.
+func fillGraph(t *testing.T, g *Graph) {
+	t.Helper()
+
+	assert.Nil(t, g.CreateIndex("person", "name"))
+	for i := 0; i < 40; i++ {
+		_, err := g.AddNode(fmt.Sprintf("node-%02d", i), []string{"person"}, KV{Key: "name", Value: []byte(fmt.Sprintf("name-%d", i%5))})
+		assert.Nil(t, err)
+	}
+
+	for i := 1; i < 40; i++ {
+		_, err := g.AddEdge(fmt.Sprintf("edge-%02d", i), "node-00", "knows", fmt.Sprintf("node-%02d", i))
+		assert.Nil(t, err)
+	}
+
+	_, err := g.AddEdge("edge-self", "node-01", "likes", "node-01")
+	assert.Nil(t, err)
+
+	_, err = g.PatchNode("node-02", NodePatch{SetProperties: map[string][]byte{"name": []byte("renamed")}, AddLabels: []string{"admin"}})
+	assert.Nil(t, err)
+	_, err = g.PatchEdge("edge-03", EdgePatch{Label: "likes"})
+	assert.Nil(t, err)
+
+	assert.Nil(t, g.RemoveEdge("edge-04"))
+	assert.Nil(t, g.RemoveNode("node-01", Detach()))
+	assert.NotNil(t, g.RemoveNode("node-00"))
+
+	_, _, err = g.RemoveNodesWhere(PropEquals("name", []byte("name-3")), Detach())
+	assert.Nil(t, err)
+
+	assert.Nil(t, g.CreateIndex("admin", "name"))
+}
+
+func TestStorage_backends(t *testing.T) {
+	store, cleanup := openBolt(t)
+	defer cleanup()
+
+	expected := New()
+	fillGraph(t, expected)
+
+	actual := New(WithStorage(store))
+	fillGraph(t, actual)
+	assert.Nil(t, actual.Snapshot().Err())
+
+	expectedJSON, err := expected.MarshalJSON()
+	assert.Nil(t, err)
+	actualJSON, err := actual.MarshalJSON()
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+
+	assert.Equal(t, expected.NodeCount(), actual.NodeCount())
+	assert.Equal(t, expected.EdgeCount(), actual.EdgeCount())
+	checkAdjacency(t, actual)
+
+	queries := []Predicate{
+		HasLabel("admin"),
+		And(HasLabel("person"), PropEquals("name", []byte("name-2"))),
+		Or(HasLabel("admin"), PropEquals("name", []byte("name-4"))),
+		Degree(OUT, 10, -1),
+	}
+
+	for _, p := range queries {
+		assert.Equal(t, uids(expected.NodesWhere(p)), uids(actual.NodesWhere(p)))
+		assert.Equal(t, uids(expected.NodesWhereAfter(p, "node-10")), uids(actual.NodesWhereAfter(p, "node-10")))
+	}
+
+	for _, p := range []Predicate{HasLabel("likes"), HasSource("node-00"), HasTarget("node-05")} {
+		assert.Equal(t, edgeUIDs(expected.EdgesWhere(p)), edgeUIDs(actual.EdgesWhere(p)))
+	}
+
+	expectedNode, _ := expected.Node("node-00")
+	actualNode, err := actual.Node("node-00")
+	assert.Nil(t, err)
+	assert.Equal(t, expectedNode, actualNode)
+}
+
+// uids returns the uids of the nodes of the iterator.
+func uids(iter NodeIterator) []string {
+	found := []string{}
+	for iter.Next() {
+		found = append(found, iter.Node().UID)
+	}
+	return found
+}
+
+// edgeUIDs returns the uids of the edges of the iterator.
+func edgeUIDs(iter EdgeIterator) []string {
+	found := []string{}
+	for iter.Next() {
+		found = append(found, iter.Edge().UID)
+	}
+	return found
+}
+
+func TestBoltStorage_reopen(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "draft.db")
+
+	store, err := OpenBoltStorage(path)
+	assert.Nil(t, err)
+
+	g := New(WithStorage(store))
+	fillGraph(t, g)
+	expected, err := g.MarshalJSON()
+	assert.Nil(t, err)
+	assert.Nil(t, store.Close())
+
+	store, err = OpenBoltStorage(path)
+	assert.Nil(t, err)
+	defer store.Close()
+
+	g = New(WithStorage(store))
+	actual, err := g.MarshalJSON()
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+	assert.Equal(t, []IndexDef{{Label: "admin", Property: "name"}, {Label: "person", Property: "name"}}, g.Indexes())
+
+	// The stored property index is used.
+	iter := g.NodesWhere(And(HasLabel("person"), PropEquals("name", []byte("renamed"))))
+	assert.Equal(t, []string{"node-02"}, uids(iter))
+
+	// The graph carries on from where it was.
+	_, err = g.AddNode("node-40", []string{"person"})
+	assert.Nil(t, err)
+	assert.True(t, g.HasNode("node-40"))
+}
+
+func TestBoltStorage_reopen_schema(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "draft.db")
+
+	store, err := OpenBoltStorage(path)
+	assert.Nil(t, err)
+
+	g := New(WithStorage(store))
+	g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("foo")}, KV{Key: "age", Value: []byte("30")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "name", Value: []byte("bar")}, KV{Key: "age", Value: []byte("40")})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+
+	assert.Nil(t, g.CreateRangeIndex("person", "age"))
+	assert.Nil(t, g.CreateSearchIndex(SearchIndexDef{Name: "names", Type: NODE, Labels: []string{"person"}, Properties: []string{"name"}}))
+	unique := Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "name"}
+	assert.Nil(t, g.CreateConstraint(unique))
+	assert.Nil(t, g.SetEdgeSchema(EdgeSchema{Label: "knows", Sources: []string{"person"}, MaxOut: 1}))
+	assert.Nil(t, g.CreateConstraint(Constraint{Type: EXISTS, Item: NODE, Label: "person", Property: "age"}))
+	assert.Nil(t, g.DropConstraint(Constraint{Type: EXISTS, Item: NODE, Label: "person", Property: "age"}))
+
+	indexes := g.Indexes()
+	searches := g.SearchIndexes()
+	constraints := g.Constraints()
+	schemas := g.EdgeSchemas()
+	assert.Nil(t, store.Close())
+
+	store, err = OpenBoltStorage(path)
+	assert.Nil(t, err)
+	defer store.Close()
+
+	g = New(WithStorage(store))
+	assert.Equal(t, indexes, g.Indexes())
+	assert.Equal(t, searches, g.SearchIndexes())
+	assert.Equal(t, constraints, g.Constraints())
+	assert.Equal(t, schemas, g.EdgeSchemas())
+
+	// The indexes are rebuilt from the stored nodes.
+	_, ok := g.shard("node-2").ranges[IndexDef{Label: "person", Property: "age", Type: RANGE}]
+	assert.True(t, ok)
+	iter := g.NodesByRange(RangeQuery{Label: "person", Property: "age", Range: Range{Min: []byte("35")}})
+	assert.Equal(t, []string{"node-2"}, uids(iter))
+
+	results, err := g.Search("names", "foo", 0)
+	assert.Nil(t, err)
+	assert.Len(t, results, 1)
+
+	_, err = g.AddNode("node-3", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	assert.NotNil(t, err)
+
+	_, err = g.AddEdge("edge-2", "node-1", "knows", "node-2")
+	assert.NotNil(t, err)
+}
+
+func TestBoltStorage_Tx_rollback(t *testing.T) {
+	store, cleanup := openBolt(t)
+	defer cleanup()
+
+	g := New(WithStorage(store))
+	g.AddNode("node-1", []string{"person"})
+	version := g.Version()
+
+	tx := g.Begin()
+	tx.AddNode("node-2", []string{"person"})
+	tx.AddEdge("edge-1", "node-1", "knows", "node-2")
+	tx.AddNode("node-3", []string{"person"})
+	g.AddNode("node-3", []string{"person"})
+	assert.NotNil(t, tx.Commit())
+
+	assert.Equal(t, version+1, g.Version())
+	assert.Equal(t, 2, g.NodeCount())
+	assert.Equal(t, 0, g.EdgeCount())
+	assert.False(t, g.HasNode("node-2"))
+
+	node, err := g.Node("node-1")
+	assert.Nil(t, err)
+	assert.Empty(t, node.Edges())
+	assert.Nil(t, g.Snapshot().Err())
+}
+
+// reopenBolt closes the database of the storage and opens it again,
+// read-only if readOnly is true so committing fails.
+func reopenBolt(t *testing.T, store *BoltStorage, readOnly bool) {
+	t.Helper()
+	path := store.db.Path()
+	if err := store.db.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := bolt.Open(path, 0644, &bolt.Options{ReadOnly: readOnly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.db = db
+}
+
+func TestBoltStorage_commit_error(t *testing.T) {
+	store, cleanup := openBolt(t)
+	defer cleanup()
+
+	g := New(WithStorage(store))
+	assert.Nil(t, g.CreateRangeIndex("person", "age"))
+	assert.Nil(t, g.CreateConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "name"}))
+	g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("foo")}, KV{Key: "age", Value: []byte("1")})
+	version := g.Version()
+
+	reopenBolt(t, store, true)
+
+	_, err := g.AddNode("node-2", []string{"person"}, KV{Key: "name", Value: []byte("bar")}, KV{Key: "age", Value: []byte("2")})
+	assert.True(t, errors.Is(err, bolt.ErrDatabaseReadOnly))
+
+	_, err = g.UpdateNode(NewNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("baz")}))
+	assert.True(t, errors.Is(err, bolt.ErrDatabaseReadOnly))
+
+	// The changes are undone, including the indexes kept in memory.
+	assert.Equal(t, version, g.Version())
+	assert.Equal(t, 1, g.NodeCount())
+	assert.False(t, g.HasNode("node-2"))
+	assert.Equal(t, []string{"node-1"}, uids(g.NodesByRange(RangeQuery{Label: "person", Property: "age"})))
+
+	node, err := g.Node("node-1")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("foo"), node.Properties["name"])
+	assert.Nil(t, g.Snapshot().Err())
+
+	reopenBolt(t, store, false)
+
+	_, err = g.AddNode("node-3", []string{"person"}, KV{Key: "name", Value: []byte("bar")}, KV{Key: "age", Value: []byte("3")})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, g.NodeCount())
+	assert.Equal(t, []string{"node-1", "node-3"}, uids(g.NodesByRange(RangeQuery{Label: "person", Property: "age"})))
+}
+
+func TestBoltStorage_commit_error_wal(t *testing.T) {
+	store, cleanup := openBolt(t)
+	defer cleanup()
+
+	path, cleanupWAL := tempWAL(t)
+	defer cleanupWAL()
+
+	wal, err := OpenWAL(path)
+	assert.Nil(t, err)
+	defer wal.Close()
+
+	g := New(WithStorage(store))
+	g.SetWAL(wal)
+	g.AddNode("node-1", []string{"person"})
+
+	reopenBolt(t, store, true)
+	_, err = g.AddNode("node-2", []string{"person"})
+	assert.True(t, errors.Is(err, bolt.ErrDatabaseReadOnly))
+
+	// The change is logged before committing, so undoing it is logged too.
+	replay := replayed(t, path)
+	assert.True(t, replay.HasNode("node-1"))
+	assert.False(t, replay.HasNode("node-2"))
+}
+
+func TestBoltStorage_read_error(t *testing.T) {
+	store, cleanup := openBolt(t)
+	defer cleanup()
+
+	g := New(WithStorage(store))
+	g.AddNode("node-1", []string{"person"})
+	version := g.Version()
+
+	path := store.db.Path()
+	assert.Nil(t, store.db.Close())
+
+	// The node can not be read, so adding it fails rather than adding it
+	// again.
+	_, err := g.AddNode("node-1", []string{"person"})
+	assert.True(t, errors.Is(err, bolt.ErrDatabaseNotOpen))
+	assert.Equal(t, version, g.Version())
+	assert.Equal(t, 1, g.NodeCount())
+
+	// The error reading a snapshot is returned by the snapshot, rather
+	// than the node reading as missing.
+	snap := g.Snapshot()
+	assert.Nil(t, snap.Err())
+	_, err = snap.Node("node-1")
+	assert.True(t, errors.Is(err, bolt.ErrDatabaseNotOpen))
+	assert.True(t, errors.Is(snap.Err(), bolt.ErrDatabaseNotOpen))
+
+	iter := snap.Nodes()
+	assert.False(t, iter.Next())
+	assert.True(t, errors.Is(iter.Err(), bolt.ErrDatabaseNotOpen))
+
+	_, err = g.MarshalJSON()
+	assert.True(t, errors.Is(err, bolt.ErrDatabaseNotOpen))
+
+	db, err := bolt.Open(path, 0644, nil)
+	assert.Nil(t, err)
+	store.db = db
+
+	// The next version reads the database again.
+	_, err = g.AddNode("node-2", []string{"person"})
+	assert.Nil(t, err)
+	assert.True(t, g.HasNode("node-1"))
+	assert.Nil(t, g.Snapshot().Err())
+}
+
+func TestBoltStorage_not_versioned(t *testing.T) {
+	store, cleanup := openBolt(t)
+	defer cleanup()
+
+	g := New(WithStorage(store), WithRetention(RetentionPolicy{Versions: 10}))
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+
+	// Earlier versions would read the storage as last committed, and
+	// the current version may change while it is read.
+	assert.False(t, g.Versioned())
+	_, err := g.SnapshotAtVersion(1)
+	assert.True(t, errors.Is(err, ErrNotVersioned))
+
+	_, err = g.SnapshotAtVersion(2)
+	assert.True(t, errors.Is(err, ErrNotVersioned))
+
+	_, err = g.SnapshotAt(time.Now())
+	assert.True(t, errors.Is(err, ErrNotVersioned))
+
+	err = g.ReadSnapshot(func(s *Snapshot) error {
+		assert.Equal(t, 2, s.NodeCount())
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func TestBoltKey(t *testing.T) {
+	key := boltKey("a\x00b", "", "c")
+	parts, ok := splitBoltKey(key)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"a\x00b", "", "c"}, parts)
+
+	// A key prefix only matches keys with the same parts.
+	assert.NotEqual(t, boltKey("ab")[:2], boltKey("a", "b")[:2])
+
+	_, ok = splitBoltKey("\x05ab")
+	assert.False(t, ok)
+}
+
+func TestBoltStorage_keys(t *testing.T) {
+	store, cleanup := openBolt(t)
+	defer cleanup()
+
+	// More keys than a cursor batch, some committed and some pending.
+	shard := store.shards[0]
+	expected := []string{}
+	for i := 0; i < 200; i++ {
+		key := fmt.Sprintf("node-%03d", i)
+		shard.put(boltNodes, key, []byte("{}"))
+		if i%3 != 0 {
+			expected = append(expected, key)
+		}
+	}
+	shard.Commit()
+
+	for i := 0; i < 200; i += 3 {
+		shard.delete(boltNodes, fmt.Sprintf("node-%03d", i))
+	}
+	shard.put(boltNodes, "node-050a", []byte("{}"))
+	shard.put(boltNodes, "node-999", []byte("{}"))
+	shard.delete(boltNodes, "node-500")
+
+	pending := append([]string{}, expected...)
+	pending = append(pending, "node-050a", "node-999")
+	sort.Strings(pending)
+	assert.Equal(t, pending, readAll(shard.Nodes("")))
+	assert.Equal(t, pending[60:], readAll(shard.Nodes(pending[59])))
+
+	// The view only reads what is committed.
+	committed := boltReader{shard: shard}
+	assert.Len(t, readAll(committed.Nodes("")), 200)
+	assert.Equal(t, []string{"node-198", "node-199"}, readAll(committed.Nodes("node-197")))
+
+	shard.Discard()
+	assert.Len(t, readAll(shard.Nodes("")), 200)
+	assert.False(t, shard.Changed())
+}
+
+func TestBoltStorage_concurrent(t *testing.T) {
+	store, cleanup := openBolt(t)
+	defer cleanup()
+
+	g := New(WithStorage(store))
+	done := make(chan struct{})
+
+	var readers sync.WaitGroup
+	for r := 0; r < 2; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					iter := g.NodesWhere(HasLabel("person"))
+					for iter.Next() {
+						iter.Node()
+					}
+					assert.Nil(t, iter.Err())
+				}
+			}
+		}()
+	}
+
+	var writers sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		writers.Add(1)
+		go func(w int) {
+			defer writers.Done()
+			for i := 0; i < 25; i++ {
+				source := fmt.Sprintf("node-%d-%d", w, i)
+				target := fmt.Sprintf("node-%d-%d", (w+1)%4, i)
+				g.AddNode(source, []string{"person"})
+				g.AddNode(target, []string{"person"})
+				g.AddEdge("", source, "knows", target)
+			}
+		}(w)
+	}
+
+	writers.Wait()
+	close(done)
+	readers.Wait()
+
+	assert.Nil(t, g.Snapshot().Err())
+	assert.Equal(t, 100, g.NodeCount())
+	assert.Equal(t, 100, g.EdgeCount())
+	checkAdjacency(t, g)
+}
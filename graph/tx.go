@@ -0,0 +1,522 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrTxDone is returned when using a transaction which has already been
+// committed or rolled back.
+var ErrTxDone = errors.New("Transaction has already been committed or rolled back")
+
+// OpError is returned by Commit when a change in the transaction fails to
+// apply. Op is the index of the change in the order the changes were made.
+type OpError struct {
+	Op  int
+	Err error
+}
+
+// Error returns the error message.
+func (e OpError) Error() string {
+	return fmt.Sprintf("Change %d failed: %s", e.Op, e.Err)
+}
+
+// Unwrap returns the error of the change.
+func (e OpError) Unwrap() error {
+	return e.Err
+}
+
+// txOpType is the type of change made in a transaction.
+type txOpType int
+
+const (
+	addNodeOp txOpType = iota
+	updateNodeOp
+	removeNodeOp
+	addEdgeOp
+	updateEdgeOp
+	removeEdgeOp
+)
+
+// txOp is a single change made in a transaction.
+type txOp struct {
+	op   txOpType
+	node Node
+	edge Edge
+}
+
+// Tx is a transaction grouping changes to the graph. The changes are only
+// visible through the transaction until they are committed, and are then
+// applied to the graph all at once. If any of the changes fail to apply,
+// none of them are applied. The transaction reads the graph as it was
+// when the transaction began.
+type Tx struct {
+	lock     sync.Mutex
+	id       string
+	graph    *Graph
+	snapshot *Snapshot
+	ops      []txOp
+	// nodes and edges are the elements changed in the transaction, a nil
+	// value is a element removed in the transaction.
+	nodes map[string]*Node
+	edges map[string]*Edge
+	done  bool
+}
+
+// Begin starts a new transaction.
+func (g *Graph) Begin() *Tx {
+	return &Tx{
+		id:       g.newUID(),
+		graph:    g,
+		snapshot: g.Snapshot(),
+		nodes:    make(map[string]*Node),
+		edges:    make(map[string]*Edge),
+	}
+}
+
+// ID returns the unique transaction id.
+func (tx *Tx) ID() string {
+	return tx.id
+}
+
+// cloneNode returns a copy of the snapshot node with its own properties
+// and adjacency so it can be changed without changing the graph.
+func (s *Snapshot) cloneNode(uid string) (Node, bool) {
+	node, err := s.Node(uid)
+	if err != nil {
+		return Node{}, false
+	}
+
+	clone := NewNode(node.UID, node.Labels, convertPropertiesToKV(node.Properties)...)
+	clone.Version = node.Version
+	clone.inEdges = node.inEdges
+	clone.outEdges = node.outEdges
+
+	return clone, true
+}
+
+// node returns the node as seen by the transaction, staging a copy of the
+// graph node so its adjacency can be changed by the transaction.
+// The caller is expected to be holding the transaction lock.
+func (tx *Tx) node(uid string) (*Node, bool) {
+	if node, ok := tx.nodes[uid]; ok {
+		return node, node != nil
+	}
+
+	node, ok := tx.snapshot.cloneNode(uid)
+	if !ok {
+		return nil, false
+	}
+
+	tx.nodes[uid] = &node
+	return &node, true
+}
+
+// edge returns the edge as seen by the transaction.
+// The caller is expected to be holding the transaction lock.
+func (tx *Tx) edge(uid string) (Edge, bool) {
+	if edge, ok := tx.edges[uid]; ok {
+		if edge == nil {
+			return Edge{}, false
+		}
+		return *edge, true
+	}
+
+	edge, err := tx.snapshot.Edge(uid)
+	return edge, err == nil
+}
+
+// HasNode returns true if the node exists in the transaction.
+func (tx *Tx) HasNode(uid string) bool {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	_, ok := tx.node(uid)
+	return ok
+}
+
+// Node returns the node with the provided uid, including the changes
+// made in the transaction.
+func (tx *Tx) Node(uid string) (Node, error) {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	node, ok := tx.node(uid)
+	if !ok {
+		return Node{}, fmt.Errorf("[GetNode] No such node with UID %s found", uid)
+	}
+
+	return *node, nil
+}
+
+// AddNode adds a new node with zero or more labels in the transaction.
+// If uid is empty, a new UID is generated for the node.
+func (tx *Tx) AddNode(uid string, labels []string, kv ...KV) (Node, error) {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return Node{}, fmt.Errorf("[AddNode] %w", ErrTxDone)
+	}
+
+	uid = tx.graph.ensureUID(uid)
+
+	if _, ok := tx.node(uid); ok {
+		return Node{}, fmt.Errorf("[AddNode] Node UID %s already exists", uid)
+	}
+
+	node := NewNode(uid, labels, kv...)
+	tx.nodes[uid] = &node
+	tx.ops = append(tx.ops, txOp{op: addNodeOp, node: node})
+
+	return node, nil
+}
+
+// UpdateNode updates the node with the new node in the transaction.
+func (tx *Tx) UpdateNode(node Node) (Node, error) {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return node, fmt.Errorf("[UpdateNode] %w", ErrTxDone)
+	}
+
+	return tx.updateNode(node)
+}
+
+// PatchNode applies the patch to the node in the transaction.
+func (tx *Tx) PatchNode(uid string, patch NodePatch) (Node, error) {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return Node{}, fmt.Errorf("[PatchNode] %w", ErrTxDone)
+	}
+
+	current, ok := tx.node(uid)
+	if !ok {
+		return Node{}, fmt.Errorf("[PatchNode] [GetNode] No such node with UID %s found", uid)
+	}
+
+	node, err := tx.updateNode(patch.apply(*current))
+	if err != nil {
+		return node, fmt.Errorf("[PatchNode] %w", err)
+	}
+
+	return node, nil
+}
+
+// updateNode updates the node in the transaction.
+// The caller is expected to be holding the transaction lock.
+func (tx *Tx) updateNode(node Node) (Node, error) {
+	staged, ok := tx.node(node.UID)
+	if !ok {
+		return node, fmt.Errorf("[UpdateNode] Node does not exists, can not update node %s", node.UID)
+	}
+
+	if err := checkVersion(node.UID, node.Version, staged.Version); err != nil {
+		return node, fmt.Errorf("[UpdateNode] %w", err)
+	}
+
+	// Keep the adjacency as seen by the transaction.
+	node.inEdges = staged.inEdges
+	node.outEdges = staged.outEdges
+
+	// The expected version is checked again against the graph on commit.
+	tx.ops = append(tx.ops, txOp{op: updateNodeOp, node: node})
+
+	node.Version = staged.Version
+	tx.nodes[node.UID] = &node
+
+	return node, nil
+}
+
+// RemoveNode removes the node in the transaction. Removing a node with
+// edges attached fails, unless the Detach option is used.
+func (tx *Tx) RemoveNode(uid string, opts ...RemoveOption) error {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return fmt.Errorf("[RemoveNode] %w", ErrTxDone)
+	}
+
+	node, ok := tx.node(uid)
+	if !ok {
+		return fmt.Errorf("[RemoveNode] [GetNode] No such node with UID %s found", uid)
+	}
+
+	if newRemoveOptions(opts).detach {
+		for _, edgeUID := range node.Edges() {
+			// A edge from the node to itself is both in and outbound.
+			if _, ok := tx.edge(edgeUID); !ok {
+				continue
+			}
+
+			if err := tx.removeEdge(edgeUID); err != nil {
+				return fmt.Errorf("[RemoveNode] %w", err)
+			}
+		}
+	}
+
+	edgeCount := len(node.inEdges) + len(node.outEdges)
+	if edgeCount > 0 {
+		return fmt.Errorf("[RemoveNode] Can not remove node with edges attached (edge count: %d)", edgeCount)
+	}
+
+	tx.nodes[uid] = nil
+	tx.ops = append(tx.ops, txOp{op: removeNodeOp, node: Node{UID: uid}})
+
+	return nil
+}
+
+// HasEdge returns true if the edge exists in the transaction.
+func (tx *Tx) HasEdge(uid string) bool {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	_, ok := tx.edge(uid)
+	return ok
+}
+
+// Edge returns the edge with the provided uid, including the changes
+// made in the transaction.
+func (tx *Tx) Edge(uid string) (Edge, error) {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	edge, ok := tx.edge(uid)
+	if !ok {
+		return Edge{}, fmt.Errorf("[GetEdge] No such edge with UID %s found", uid)
+	}
+
+	return edge, nil
+}
+
+// AddEdge adds a new edge in the transaction.
+// If uid is empty, a new UID is generated for the edge.
+func (tx *Tx) AddEdge(uid, sourceUID, label, targetUID string, kv ...KV) (Edge, error) {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return Edge{}, fmt.Errorf("[AddEdge] %w", ErrTxDone)
+	}
+
+	source, ok := tx.node(sourceUID)
+	if !ok {
+		return Edge{}, fmt.Errorf("[AddEdge] No such node with UID %s", sourceUID)
+	}
+
+	target, ok := tx.node(targetUID)
+	if !ok {
+		return Edge{}, fmt.Errorf("[AddEdge] No such node with UID %s", targetUID)
+	}
+
+	uid = tx.graph.ensureUID(uid)
+
+	if _, ok := tx.edge(uid); ok {
+		return Edge{}, fmt.Errorf("[AddEdge] Edge UID %s already exists", uid)
+	}
+
+	edge := NewEdge(uid, sourceUID, label, targetUID, kv...)
+	tx.edges[uid] = &edge
+	tx.ops = append(tx.ops, txOp{op: addEdgeOp, edge: edge})
+
+	// (source)->(target)
+	source.outEdges[uid] = struct{}{}
+	target.inEdges[uid] = struct{}{}
+
+	return edge, nil
+}
+
+// UpdateEdge updates the edge with the new edge in the transaction.
+func (tx *Tx) UpdateEdge(edge Edge) (Edge, error) {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return edge, fmt.Errorf("[UpdateEdge] %w", ErrTxDone)
+	}
+
+	return tx.updateEdge(edge)
+}
+
+// PatchEdge applies the patch to the edge in the transaction.
+func (tx *Tx) PatchEdge(uid string, patch EdgePatch) (Edge, error) {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return Edge{}, fmt.Errorf("[PatchEdge] %w", ErrTxDone)
+	}
+
+	current, ok := tx.edge(uid)
+	if !ok {
+		return Edge{}, fmt.Errorf("[PatchEdge] [GetEdge] No such edge with UID %s found", uid)
+	}
+
+	edge, err := tx.updateEdge(patch.apply(current))
+	if err != nil {
+		return edge, fmt.Errorf("[PatchEdge] %w", err)
+	}
+
+	return edge, nil
+}
+
+// updateEdge updates the edge in the transaction.
+// The caller is expected to be holding the transaction lock.
+func (tx *Tx) updateEdge(edge Edge) (Edge, error) {
+	staged, ok := tx.edge(edge.UID)
+	if !ok {
+		return edge, fmt.Errorf("[UpdateEdge] Edge does not exists, can not update edge %s", edge.UID)
+	}
+
+	if err := checkVersion(edge.UID, edge.Version, staged.Version); err != nil {
+		return edge, fmt.Errorf("[UpdateEdge] %w", err)
+	}
+
+	if edge.SourceUID != staged.SourceUID || edge.TargetUID != staged.TargetUID {
+		return edge, fmt.Errorf("[UpdateEdge] Can not change the source or target of edge %s", edge.UID)
+	}
+
+	// The expected version is checked again against the graph on commit.
+	tx.ops = append(tx.ops, txOp{op: updateEdgeOp, edge: edge})
+
+	edge.Version = staged.Version
+	tx.edges[edge.UID] = &edge
+
+	return edge, nil
+}
+
+// RemoveEdge removes the edge in the transaction.
+func (tx *Tx) RemoveEdge(uid string) error {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return fmt.Errorf("[RemoveEdge] %w", ErrTxDone)
+	}
+
+	return tx.removeEdge(uid)
+}
+
+// removeEdge removes the edge in the transaction.
+// The caller is expected to be holding the transaction lock.
+func (tx *Tx) removeEdge(uid string) error {
+	edge, ok := tx.edge(uid)
+	if !ok {
+		return fmt.Errorf("[RemoveEdge] [GetEdge] No such edge with UID %s found", uid)
+	}
+
+	// (source)->(target)
+	if source, ok := tx.node(edge.SourceUID); ok {
+		delete(source.outEdges, uid)
+	}
+
+	if target, ok := tx.node(edge.TargetUID); ok {
+		delete(target.inEdges, uid)
+	}
+
+	tx.edges[uid] = nil
+	tx.ops = append(tx.ops, txOp{op: removeEdgeOp, edge: Edge{UID: uid}})
+
+	return nil
+}
+
+// Rollback discards all the changes made in the transaction.
+func (tx *Tx) Rollback() error {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return fmt.Errorf("[Rollback] %w", ErrTxDone)
+	}
+
+	tx.done = true
+	tx.ops = nil
+	tx.nodes = nil
+	tx.edges = nil
+
+	return nil
+}
+
+// Commit applies all the changes made in the transaction to the graph in
+// the order they were made. Readers see either none or all of the changes.
+// If any change fails, for example because of a constraint or because a
+// concurrent change removed a node, or the changes can not be logged to
+// the write-ahead log or committed to the storage, the changes already
+// applied are undone and the error is returned.
+func (tx *Tx) Commit() (err error) {
+	tx.lock.Lock()
+	defer tx.lock.Unlock()
+
+	if tx.done {
+		return fmt.Errorf("[Commit] %w", ErrTxDone)
+	}
+
+	tx.done = true
+
+	g := tx.graph
+	w := g.exclusive()
+	defer w.release(&err)
+
+	// The versions assigned while committing, so later changes to the
+	// same node or edge are not seen as a version conflict.
+	nodeVersions := make(map[string]uint64)
+	edgeVersions := make(map[string]uint64)
+
+	for i, op := range tx.ops {
+		switch op.op {
+		case updateNodeOp:
+			if version, ok := nodeVersions[op.node.UID]; ok {
+				op.node.Version = version
+			}
+		case updateEdgeOp:
+			if version, ok := edgeVersions[op.edge.UID]; ok {
+				op.edge.Version = version
+			}
+		}
+
+		// The changes already applied are undone when releasing the lock,
+		// so there is no new version.
+		if err := g.apply(op); err != nil {
+			return fmt.Errorf("[Commit] %w", OpError{Op: i, Err: err})
+		}
+
+		switch op.op {
+		case addNodeOp, updateNodeOp:
+			node, _ := g.node(op.node.UID)
+			nodeVersions[op.node.UID] = node.Version
+		case addEdgeOp, updateEdgeOp:
+			edge, _ := g.edge(op.edge.UID)
+			edgeVersions[op.edge.UID] = edge.Version
+		}
+	}
+
+	return nil
+}
+
+// apply applies the transaction change to the graph.
+// The caller is expected to be holding the write lock.
+func (g *Graph) apply(op txOp) error {
+	var err error
+	switch op.op {
+	case addNodeOp:
+		_, err = g.addNode(op.node.UID, op.node.Labels, convertPropertiesToKV(op.node.Properties)...)
+	case updateNodeOp:
+		_, err = g.updateNode(op.node)
+	case removeNodeOp:
+		err = g.removeNode(op.node.UID)
+	case addEdgeOp:
+		_, err = g.addEdge(op.edge.UID, op.edge.SourceUID, op.edge.Label, op.edge.TargetUID, convertPropertiesToKV(op.edge.Properties)...)
+	case updateEdgeOp:
+		_, err = g.updateEdge(op.edge)
+	case removeEdgeOp:
+		err = g.removeEdge(op.edge.UID)
+	default:
+		err = fmt.Errorf("Unknown transaction operation %d", op.op)
+	}
+	return err
+}
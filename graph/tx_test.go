@@ -0,0 +1,246 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTx_commit(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+
+	tx := g.Begin()
+	assert.NotEqual(t, "", tx.ID())
+
+	_, err := tx.AddNode("node-bar", []string{"person"}, KV{Key: "name", Value: []byte("bar")})
+	assert.Nil(t, err)
+
+	_, err = tx.AddEdge("edge-1", "node-foo", "knows", "node-bar")
+	assert.Nil(t, err)
+
+	foo, err := tx.Node("node-foo")
+	assert.Nil(t, err)
+	foo.Properties["age"] = []byte("21")
+	_, err = tx.UpdateNode(foo)
+	assert.Nil(t, err)
+
+	// The changes are only seen by the transaction before the commit.
+	assert.True(t, tx.HasNode("node-bar"))
+	assert.True(t, tx.HasEdge("edge-1"))
+	assert.False(t, g.HasNode("node-bar"))
+	assert.False(t, g.HasEdge("edge-1"))
+	node, _ := g.Node("node-foo")
+	assert.NotContains(t, node.Properties, "age")
+
+	assert.Nil(t, tx.Commit())
+
+	assert.True(t, g.HasNode("node-bar"))
+	assert.True(t, g.HasEdge("edge-1"))
+	node, _ = g.Node("node-foo")
+	assert.Equal(t, []byte("21"), node.Properties["age"])
+	assert.Contains(t, node.outEdges, "edge-1")
+
+	assert.True(t, errors.Is(tx.Commit(), ErrTxDone))
+	assert.True(t, errors.Is(tx.Rollback(), ErrTxDone))
+	_, err = tx.AddNode("node-baz", nil)
+	assert.True(t, errors.Is(err, ErrTxDone))
+}
+
+func TestTx_rollback(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+
+	tx := g.Begin()
+	tx.AddNode("node-bar", []string{"person"})
+	tx.RemoveNode("node-foo")
+
+	assert.False(t, tx.HasNode("node-foo"))
+	assert.Nil(t, tx.Rollback())
+
+	assert.True(t, g.HasNode("node-foo"))
+	assert.False(t, g.HasNode("node-bar"))
+	assert.True(t, errors.Is(tx.Commit(), ErrTxDone))
+}
+
+func TestTx_remove(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+	g.AddNode("node-bar", []string{"person"})
+	g.AddEdge("edge-1", "node-foo", "knows", "node-bar")
+
+	tx := g.Begin()
+	assert.NotNil(t, tx.RemoveNode("node-foo"))
+	assert.Nil(t, tx.RemoveEdge("edge-1"))
+	assert.Nil(t, tx.RemoveNode("node-foo"))
+	assert.NotNil(t, tx.RemoveNode("node-foo"))
+	assert.NotNil(t, tx.RemoveEdge("edge-1"))
+
+	// The graph adjacency is not changed by the transaction.
+	node, _ := g.Node("node-bar")
+	assert.Contains(t, node.inEdges, "edge-1")
+
+	assert.Nil(t, tx.Commit())
+	assert.False(t, g.HasNode("node-foo"))
+	assert.False(t, g.HasEdge("edge-1"))
+	node, _ = g.Node("node-bar")
+	assert.NotContains(t, node.inEdges, "edge-1")
+}
+
+func TestTx_commit_all_or_nothing(t *testing.T) {
+	g := New()
+	g.CreateConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "email"})
+	g.AddNode("node-foo", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+	g.AddNode("node-bar", []string{"person"}, KV{Key: "email", Value: []byte("bar@example.com")})
+	g.AddEdge("edge-1", "node-foo", "knows", "node-bar")
+
+	tx := g.Begin()
+	tx.AddNode("node-baz", []string{"person"}, KV{Key: "email", Value: []byte("baz@example.com")})
+	tx.AddEdge("edge-2", "node-baz", "knows", "node-foo")
+	tx.RemoveEdge("edge-1")
+	bar, _ := tx.Node("node-bar")
+	bar.Properties["email"] = []byte("bar@example.org")
+	tx.UpdateNode(bar)
+	tx.AddNode("node-qux", []string{"person"}, KV{Key: "email", Value: []byte("foo@example.com")})
+
+	var constraintErr ConstraintError
+	var opErr OpError
+	err := tx.Commit()
+	assert.True(t, errors.As(err, &constraintErr))
+	assert.True(t, errors.As(err, &opErr))
+	assert.Equal(t, 4, opErr.Op)
+
+	// None of the changes are applied.
+	assert.False(t, g.HasNode("node-baz"))
+	assert.False(t, g.HasEdge("edge-2"))
+	assert.True(t, g.HasEdge("edge-1"))
+	assert.False(t, g.HasNode("node-qux"))
+
+	bar, _ = g.Node("node-bar")
+	assert.Equal(t, []byte("bar@example.com"), bar.Properties["email"])
+	assert.Contains(t, bar.inEdges, "edge-1")
+
+	foo, _ := g.Node("node-foo")
+	assert.Equal(t, map[string]struct{}{"edge-1": {}}, foo.outEdges)
+	assert.Equal(t, map[string]struct{}{}, foo.inEdges)
+
+	// The unique index is restored, so the values are still taken.
+	_, err = g.AddNode("", []string{"person"}, KV{Key: "email", Value: []byte("bar@example.com")})
+	assert.NotNil(t, err)
+	_, err = g.AddNode("", []string{"person"}, KV{Key: "email", Value: []byte("baz@example.com")})
+	assert.Nil(t, err)
+}
+
+func TestTx_commit_conflict(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+
+	tx := g.Begin()
+	_, err := tx.AddNode("node-bar", []string{"person"})
+	assert.Nil(t, err)
+
+	// A concurrent change makes the transaction fail on commit.
+	g.AddNode("node-bar", []string{"company"})
+
+	assert.NotNil(t, tx.Commit())
+	node, _ := g.Node("node-bar")
+	assert.Equal(t, []string{"company"}, node.Labels)
+}
+
+func TestTx_version(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+
+	tx := g.Begin()
+	node, _ := tx.Node("node-foo")
+	assert.Equal(t, uint64(1), node.Version)
+
+	// Updating the same node twice in the transaction is not a conflict.
+	node.Properties["name"] = []byte("foo")
+	_, err := tx.UpdateNode(node)
+	assert.Nil(t, err)
+	_, err = tx.UpdateNode(node)
+	assert.Nil(t, err)
+
+	stale := node
+	stale.Version = 5
+	_, err = tx.UpdateNode(stale)
+	assert.True(t, errors.As(err, &VersionError{}))
+
+	assert.Nil(t, tx.Commit())
+	node, _ = g.Node("node-foo")
+	assert.Equal(t, uint64(3), node.Version)
+
+	// A concurrent update makes the commit fail.
+	tx = g.Begin()
+	node, _ = tx.Node("node-foo")
+	tx.UpdateNode(node)
+	g.UpdateNode(node)
+
+	assert.True(t, errors.As(tx.Commit(), &VersionError{}))
+	node, _ = g.Node("node-foo")
+	assert.Equal(t, uint64(4), node.Version)
+}
+
+func TestTx_patch(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("node-bar", []string{"person"})
+	g.AddEdge("edge-1", "node-foo", "knows", "node-bar")
+
+	tx := g.Begin()
+	_, err := tx.PatchNode("node-foo", NodePatch{SetProperties: map[string][]byte{"age": []byte("21")}})
+	assert.Nil(t, err)
+	_, err = tx.PatchEdge("edge-1", EdgePatch{Label: "likes"})
+	assert.Nil(t, err)
+
+	node, _ := g.Node("node-foo")
+	assert.NotContains(t, node.Properties, "age")
+
+	assert.Nil(t, tx.Commit())
+
+	node, _ = g.Node("node-foo")
+	assert.Equal(t, map[string][]byte{"name": []byte("foo"), "age": []byte("21")}, node.Properties)
+	assert.Equal(t, []string{"edge-1"}, node.OutEdges())
+
+	edge, _ := g.Edge("edge-1")
+	assert.Equal(t, "likes", edge.Label)
+}
+
+func TestTx_remove_detach(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+	g.AddNode("node-bar", []string{"person"})
+	g.AddEdge("edge-1", "node-foo", "knows", "node-bar")
+	g.AddEdge("edge-2", "node-foo", "likes", "node-foo")
+
+	tx := g.Begin()
+	assert.Nil(t, tx.RemoveNode("node-foo", Detach()))
+	assert.False(t, tx.HasEdge("edge-1"))
+	assert.True(t, g.HasEdge("edge-1"))
+
+	assert.Nil(t, tx.Commit())
+	assert.False(t, g.HasNode("node-foo"))
+	assert.Equal(t, 0, g.EdgeCount())
+}
+
+func TestTx_snapshot(t *testing.T) {
+	g := New()
+	g.AddNode("node-foo", []string{"person"})
+	g.AddNode("node-bar", []string{"person"})
+
+	tx := g.Begin()
+	_, err := tx.Node("node-foo")
+	assert.Nil(t, err)
+
+	// Changes made after the transaction began are not seen by it.
+	g.PatchNode("node-bar", NodePatch{AddLabels: []string{"admin"}})
+	g.AddEdge("edge-1", "node-foo", "knows", "node-bar")
+
+	node, err := tx.Node("node-bar")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"person"}, node.Labels)
+	assert.False(t, tx.HasEdge("edge-1"))
+	assert.Nil(t, tx.Rollback())
+}
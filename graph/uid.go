@@ -0,0 +1,83 @@
+package graph
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// crockford is the Crockford base32 alphabet used for encoding ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// UIDGenerator returns a new unique identifier each time it is called.
+// It is used for nodes and edges which are added without a UID.
+type UIDGenerator func() string
+
+// NewULIDGenerator returns a UIDGenerator producing lexically sortable
+// ULIDs (48 bit millisecond timestamp followed by 80 bits of entropy).
+// IDs generated within the same millisecond are monotonically increasing.
+func NewULIDGenerator() UIDGenerator {
+	var (
+		lock     sync.Mutex
+		lastTime uint64
+		entropy  [10]byte
+	)
+
+	return func() string {
+		lock.Lock()
+		defer lock.Unlock()
+
+		ms := uint64(time.Now().UTC().UnixNano() / int64(time.Millisecond))
+		lastTime = nextULIDTime(ms, lastTime, &entropy)
+
+		var id [16]byte
+		var ts [8]byte
+		binary.BigEndian.PutUint64(ts[:], lastTime)
+		copy(id[:6], ts[2:])
+		copy(id[6:], entropy[:])
+
+		return encodeULID(id)
+	}
+}
+
+// nextULIDTime returns the timestamp of the next ULID after a ULID with
+// the last timestamp and sets its entropy. Within the same (or a earlier)
+// millisecond the entropy is bumped to keep the ids sorted, and once the
+// entropy runs out the timestamp is moved on to the next millisecond.
+func nextULIDTime(ms, last uint64, entropy *[10]byte) uint64 {
+	if ms <= last {
+		for i := len(entropy) - 1; i >= 0; i-- {
+			entropy[i]++
+			if entropy[i] != 0 {
+				return last
+			}
+		}
+
+		ms = last + 1
+	}
+
+	if _, err := rand.Read(entropy[:]); err != nil {
+		panic(err)
+	}
+
+	return ms
+}
+
+// encodeULID encodes the 128 bit id as a 26 character Crockford base32 string.
+func encodeULID(id [16]byte) string {
+	out := make([]byte, 26)
+
+	// 128 bits do not divide into 5 bit groups, so the first character
+	// only carries the top 3 bits.
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(out)
+}
@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewULIDGenerator(t *testing.T) {
+	gen := NewULIDGenerator()
+
+	ids := make([]string, 1000)
+	seen := make(map[string]struct{})
+	for i := range ids {
+		ids[i] = gen()
+		assert.Len(t, ids[i], 26)
+		seen[ids[i]] = struct{}{}
+	}
+
+	assert.Len(t, seen, len(ids))
+	assert.True(t, sort.StringsAreSorted(ids))
+}
+
+func TestEncodeULID(t *testing.T) {
+	assert.Equal(t, "00000000000000000000000000", encodeULID([16]byte{}))
+	assert.Equal(
+		t,
+		"7ZZZZZZZZZZZZZZZZZZZZZZZZZ",
+		encodeULID([16]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}),
+	)
+}
+
+func TestAddNode_generated_uid(t *testing.T) {
+	g := New()
+	n1, err := g.AddNode("", []string{"person"})
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", n1.UID)
+
+	n2, err := g.AddNode("", []string{"person"})
+	assert.Nil(t, err)
+	assert.NotEqual(t, n1.UID, n2.UID)
+
+	assert.Equal(t, true, g.HasNode(n1.UID))
+	assert.Equal(t, true, g.HasNode(n2.UID))
+}
+
+func TestAddEdge_generated_uid(t *testing.T) {
+	g := New()
+	n1, _ := g.AddNode("node-1", []string{"person"})
+	n2, _ := g.AddNode("node-2", []string{"person"})
+
+	edge, err := g.AddEdge("", n1.UID, "knows", n2.UID)
+	assert.Nil(t, err)
+	assert.NotEqual(t, "", edge.UID)
+	assert.Equal(t, true, g.HasEdge(edge.UID))
+}
+
+func TestWithUIDGenerator(t *testing.T) {
+	count := 0
+	g := New(WithUIDGenerator(func() string {
+		count++
+		return fmt.Sprintf("uid-%d", count)
+	}))
+
+	n1, _ := g.AddNode("", []string{"person"})
+	n2, _ := g.AddNode("", []string{"person"})
+	edge, _ := g.AddEdge("", n1.UID, "knows", n2.UID)
+
+	assert.Equal(t, "uid-1", n1.UID)
+	assert.Equal(t, "uid-2", n2.UID)
+	assert.Equal(t, "uid-3", edge.UID)
+}
+
+func TestNextULIDTime(t *testing.T) {
+	entropy := [10]byte{9: 0xfe}
+	assert.Equal(t, uint64(100), nextULIDTime(99, 100, &entropy))
+	assert.Equal(t, [10]byte{9: 0xff}, entropy)
+
+	// The entropy is exhausted, so the next millisecond is used.
+	entropy = [10]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	assert.Equal(t, uint64(101), nextULIDTime(100, 100, &entropy))
+	assert.Equal(t, uint64(102), nextULIDTime(102, 101, &entropy))
+}
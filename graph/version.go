@@ -0,0 +1,58 @@
+package graph
+
+import "fmt"
+
+// VersionError is returned when updating a node or edge with a expected
+// version which is not the current version, meaning it has been changed
+// since it was read.
+type VersionError struct {
+	UID      string
+	Expected uint64
+	Actual   uint64
+}
+
+// Error returns the error message.
+func (e VersionError) Error() string {
+	return fmt.Sprintf("Version conflict on %s, expected version %d but found %d", e.UID, e.Expected, e.Actual)
+}
+
+// checkVersion returns a error if the expected version is set and is not
+// the actual version.
+func checkVersion(uid string, expected, actual uint64) error {
+	if expected != 0 && expected != actual {
+		return VersionError{UID: uid, Expected: expected, Actual: actual}
+	}
+	return nil
+}
+
+// copyNode adds a copy of the node keeping its version, if it has one.
+// It is used for copying nodes into a subgraph or loading a dump.
+func (g *Graph) copyNode(node Node) (added Node, err error) {
+	node.UID = g.ensureUID(node.UID)
+	w := g.write(node.UID)
+	defer w.release(&err)
+
+	added, err = g.addNode(node.UID, node.Labels, convertPropertiesToKV(node.Properties)...)
+	if err != nil || node.Version == 0 {
+		return added, err
+	}
+
+	added.Version = node.Version
+	return added, g.restoreNode(added)
+}
+
+// copyEdge adds a copy of the edge keeping its version, if it has one.
+// It is used for copying edges into a subgraph or loading a dump.
+func (g *Graph) copyEdge(edge Edge) (added Edge, err error) {
+	edge.UID = g.ensureUID(edge.UID)
+	w := g.write(edge.UID, edge.SourceUID, edge.TargetUID)
+	defer w.release(&err)
+
+	added, err = g.addEdge(edge.UID, edge.SourceUID, edge.Label, edge.TargetUID, convertPropertiesToKV(edge.Properties)...)
+	if err != nil || edge.Version == 0 {
+		return added, err
+	}
+
+	added.Version = edge.Version
+	return added, g.restoreEdge(added)
+}
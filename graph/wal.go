@@ -0,0 +1,760 @@
+package graph
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SyncPolicy decides when the changes written to the write-ahead log are
+// flushed to disk.
+type SyncPolicy int
+
+const (
+	// ALWAYS flushes every change to disk before it is visible.
+	ALWAYS SyncPolicy = iota
+	// INTERVAL flushes the changes to disk at a interval, a crash of the
+	// machine may lose the changes since the last flush.
+	INTERVAL
+	// NEVER leaves flushing the changes to the operating system.
+	NEVER
+)
+
+// String returns the sync policy name.
+func (p SyncPolicy) String() string {
+	switch p {
+	case ALWAYS:
+		return "always"
+	case INTERVAL:
+		return "interval"
+	case NEVER:
+		return "never"
+	}
+	return fmt.Sprintf("SyncPolicy(%d)", int(p))
+}
+
+// DefaultSyncInterval is the interval the INTERVAL sync policy flushes the
+// log at when no interval is given.
+const DefaultSyncInterval = time.Second
+
+// walHeaderSize is the size of the header of a log record, the length and
+// the checksum of the record.
+const walHeaderSize = 8
+
+// walMaxRecordSize is the size of the largest log record read, anything
+// larger is taken to be a corrupt length.
+const walMaxRecordSize = 1 << 30
+
+// walTable is the CRC-32 table used for the log record checksums.
+var walTable = crc32.MakeTable(crc32.Castagnoli)
+
+// errWALCorrupt is returned when reading a log record which is incomplete
+// or does not match its checksum.
+var errWALCorrupt = errors.New("Corrupt write-ahead log record")
+
+// walOp is the type of change recorded in the log.
+type walOp int
+
+const (
+	walPutNode walOp = iota
+	walRemoveNode
+	walPutEdge
+	walRemoveEdge
+	walCreateIndex
+	walDropIndex
+	walCreateConstraint
+	walDropConstraint
+	walCreateSearchIndex
+	walDropSearchIndex
+	walSetEdgeSchema
+	walRemoveEdgeSchema
+)
+
+// walEntry is a single change recorded in the log. Nodes and edges are
+// recorded as they are after the change, so replaying is not affected by
+// the checks made when the change was made. Name is the name of a dropped
+// search index or the label of a removed edge schema.
+type walEntry struct {
+	Op         walOp           `json:"op"`
+	UID        string          `json:"uid,omitempty"`
+	Node       *Node           `json:"node,omitempty"`
+	Edge       *Edge           `json:"edge,omitempty"`
+	Index      *IndexDef       `json:"index,omitempty"`
+	Constraint *Constraint     `json:"constraint,omitempty"`
+	Search     *SearchIndexDef `json:"search,omitempty"`
+	EdgeSchema *EdgeSchema     `json:"edge_schema,omitempty"`
+	Name       string          `json:"name,omitempty"`
+	seq        uint64
+	// undo undoes the change, for when it can not be logged or committed.
+	undo func()
+	// logged is true if the change is already in the log, as when
+	// replaying it.
+	logged bool
+}
+
+// walBatch is the changes made while holding a write lock, recorded as a
+// single log record so they are replayed all or nothing.
+type walBatch struct {
+	LSN     uint64     `json:"lsn"`
+	Entries []walEntry `json:"entries"`
+}
+
+// WALOption is a option for opening a write-ahead log.
+type WALOption func(*WAL)
+
+// WithSyncPolicy sets when the changes are flushed to disk. The interval
+// is only used by the INTERVAL policy, if it is zero DefaultSyncInterval
+// is used.
+func WithSyncPolicy(policy SyncPolicy, interval time.Duration) WALOption {
+	return func(w *WAL) {
+		w.policy = policy
+		w.interval = interval
+	}
+}
+
+// WAL is a append-only write-ahead log of the changes to the nodes, edges,
+// indexes and schema of a graph. Each record is the changes made while
+// holding a write lock, prefixed by its length and CRC-32 checksum. A
+// incomplete or corrupt record at the end of the log, such as from a crash
+// part way through writing it, is truncated when the log is opened.
+//
+// Once writing to the log fails, the log stops writing and every further
+// change to the graph fails and is undone, as it would be lost on a restart.
+type WAL struct {
+	lock     sync.Mutex
+	path     string
+	file     *os.File
+	size     int64
+	lsn      uint64
+	policy   SyncPolicy
+	interval time.Duration
+	dirty    bool
+	err      error
+	done     chan struct{}
+	stopped  sync.WaitGroup
+}
+
+// OpenWAL opens the write-ahead log at the path, creating it if it does
+// not exist.
+func OpenWAL(path string, opts ...WALOption) (*WAL, error) {
+	w := &WAL{path: path, done: make(chan struct{})}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	if w.interval <= 0 {
+		w.interval = DefaultSyncInterval
+	}
+
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("[OpenWAL] %w", err)
+	}
+
+	size, lsn, err := scanWAL(file)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("[OpenWAL] %w", err)
+	}
+
+	// Drop any incomplete record so the next record follows the last
+	// complete one.
+	if err := file.Truncate(size); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("[OpenWAL] %w", err)
+	}
+
+	if _, err := file.Seek(size, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("[OpenWAL] %w", err)
+	}
+
+	w.file = file
+	w.size = size
+	w.lsn = lsn
+
+	if w.policy == INTERVAL {
+		w.stopped.Add(1)
+		go w.syncEvery(w.interval)
+	}
+
+	return w, nil
+}
+
+// scanWAL reads the log from the start and returns the size of the
+// complete records and the LSN of the last record.
+func scanWAL(r io.Reader) (int64, uint64, error) {
+	reader := bufio.NewReader(r)
+
+	var size int64
+	var lsn uint64
+	for {
+		batch, n, err := readWALRecord(reader)
+		if err == io.EOF || errors.Is(err, errWALCorrupt) {
+			return size, lsn, nil
+		}
+
+		if err != nil {
+			return size, lsn, err
+		}
+
+		size += int64(n)
+		lsn = batch.LSN
+	}
+}
+
+// readWALRecord reads the next record and returns its batch and size.
+// io.EOF is returned at the end of the log.
+func readWALRecord(r *bufio.Reader) (walBatch, int, error) {
+	header := make([]byte, walHeaderSize)
+	if n, err := io.ReadFull(r, header); err != nil {
+		if err == io.EOF && n == 0 {
+			return walBatch{}, 0, io.EOF
+		}
+		if err == io.ErrUnexpectedEOF {
+			return walBatch{}, 0, errWALCorrupt
+		}
+		return walBatch{}, 0, err
+	}
+
+	length := binary.LittleEndian.Uint32(header[0:4])
+	if length > walMaxRecordSize {
+		return walBatch{}, 0, errWALCorrupt
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return walBatch{}, 0, errWALCorrupt
+		}
+		return walBatch{}, 0, err
+	}
+
+	checksum := crc32.Update(crc32.Checksum(header[0:4], walTable), walTable, payload)
+	if checksum != binary.LittleEndian.Uint32(header[4:8]) {
+		return walBatch{}, 0, errWALCorrupt
+	}
+
+	batch := walBatch{}
+	if err := json.Unmarshal(payload, &batch); err != nil {
+		return walBatch{}, 0, fmt.Errorf("%w: %s", errWALCorrupt, err)
+	}
+
+	return batch, walHeaderSize + len(payload), nil
+}
+
+// append writes the entries to the log as a single record. Once writing
+// has failed, the log stops writing and the error is kept for Err.
+func (w *WAL) append(entries []walEntry) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	batch := walBatch{LSN: w.lsn + 1, Entries: entries}
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		w.err = fmt.Errorf("[WAL] %w", err)
+		return w.err
+	}
+
+	record := make([]byte, walHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(payload)))
+	copy(record[walHeaderSize:], payload)
+	checksum := crc32.Update(crc32.Checksum(record[0:4], walTable), walTable, payload)
+	binary.LittleEndian.PutUint32(record[4:8], checksum)
+
+	if _, err := w.file.Write(record); err != nil {
+		w.err = fmt.Errorf("[WAL] %w", err)
+		return w.err
+	}
+
+	w.size += int64(len(record))
+	w.lsn = batch.LSN
+	w.dirty = true
+
+	if w.policy == ALWAYS {
+		return w.sync()
+	}
+
+	return nil
+}
+
+// sync flushes the log to disk if anything has been written since the
+// last flush. The caller is expected to be holding the log lock.
+func (w *WAL) sync() error {
+	if !w.dirty || w.err != nil {
+		return w.err
+	}
+
+	if err := w.file.Sync(); err != nil {
+		w.err = fmt.Errorf("[WAL] %w", err)
+		return w.err
+	}
+
+	w.dirty = false
+	return nil
+}
+
+// syncEvery flushes the log to disk at the interval until the log is closed.
+func (w *WAL) syncEvery(interval time.Duration) {
+	defer w.stopped.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			w.Sync()
+		}
+	}
+}
+
+// Sync flushes the log to disk.
+func (w *WAL) Sync() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.sync()
+}
+
+// Err returns the error which stopped the log writing, if any. The graph
+// can not be changed after the log stopped writing.
+func (w *WAL) Err() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.err
+}
+
+// LSN returns the log sequence number of the last record written.
+func (w *WAL) LSN() uint64 {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.lsn
+}
+
+// Close flushes the log to disk and closes it.
+func (w *WAL) Close() error {
+	close(w.done)
+	w.stopped.Wait()
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	err := w.sync()
+	if cerr := w.file.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("[WAL] %w", cerr)
+	}
+
+	if w.err == nil {
+		w.err = fmt.Errorf("[WAL] %w", os.ErrClosed)
+	}
+
+	return err
+}
+
+// Replay applies the changes recorded in the log to the graph, one version
+// of the graph per record, and returns the number of records replayed.
+// Nodes and edges are put as they were recorded, without any checks, so
+// replaying over a graph already having some of the changes is safe.
+func (w *WAL) Replay(g *Graph) (int, error) {
+	return w.ReplayAfter(g, 0)
+}
+
+// ReplayAfter applies the changes recorded in the log after the LSN to the
+// graph, such as the changes made since a snapshot of the graph was saved,
+// and returns the number of records replayed. The next record written is
+// numbered after the LSN, even if the log has no records after it.
+func (w *WAL) ReplayAfter(g *Graph, after uint64) (int, error) {
+	w.lock.Lock()
+	size := w.size
+	if w.lsn < after {
+		w.lsn = after
+	}
+	w.lock.Unlock()
+
+	file, err := os.Open(w.path)
+	if err != nil {
+		return 0, fmt.Errorf("[Replay] %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(io.LimitReader(file, size))
+
+	count := 0
+	for {
+		batch, _, err := readWALRecord(reader)
+		if err == io.EOF {
+			return count, nil
+		}
+
+		if err != nil {
+			return count, fmt.Errorf("[Replay] %w", err)
+		}
+
+		if batch.LSN <= after {
+			continue
+		}
+
+		if err := g.replay(batch); err != nil {
+			return count, fmt.Errorf("[Replay] LSN %d: %w", batch.LSN, err)
+		}
+
+		count++
+	}
+}
+
+// Truncate removes the records up to and including the LSN from the start
+// of the log, once they are no longer needed for recovery. The records
+// kept are copied to a temporary file which replaces the log.
+func (w *WAL) Truncate(lsn uint64) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	// The log is flushed so the records kept are durable in the new file.
+	if err := w.sync(); err != nil {
+		return err
+	}
+
+	offset, err := w.offsetAfter(lsn)
+	if err != nil {
+		return fmt.Errorf("[Truncate] %w", err)
+	}
+
+	if offset == 0 {
+		return nil
+	}
+
+	if err := w.rewriteFrom(offset); err != nil {
+		w.err = fmt.Errorf("[Truncate] %w", err)
+		return w.err
+	}
+
+	return nil
+}
+
+// offsetAfter returns the offset of the first record after the LSN.
+// The caller is expected to be holding the log lock.
+func (w *WAL) offsetAfter(lsn uint64) (int64, error) {
+	file, err := os.Open(w.path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(io.LimitReader(file, w.size))
+
+	var offset int64
+	for {
+		batch, n, err := readWALRecord(reader)
+		if err == io.EOF {
+			return offset, nil
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		if batch.LSN > lsn {
+			return offset, nil
+		}
+
+		offset += int64(n)
+	}
+}
+
+// rewriteFrom replaces the log with the records from the offset on.
+// The caller is expected to be holding the log lock.
+func (w *WAL) rewriteFrom(offset int64) error {
+	if _, err := w.file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	temp := w.path + ".tmp"
+	file, err := os.OpenFile(temp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	size, err := io.Copy(file, io.LimitReader(w.file, w.size-offset))
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if err == nil {
+		err = os.Rename(temp, w.path)
+	}
+
+	if err == nil {
+		err = syncDir(filepath.Dir(w.path))
+	}
+
+	if err != nil {
+		file.Close()
+		os.Remove(temp)
+		return err
+	}
+
+	w.file.Close()
+	w.file = file
+	w.size = size
+	return nil
+}
+
+// syncDir flushes the directory to disk, so a file renamed into it is durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// SetWAL logs every further change to the graph to the write-ahead log.
+// A nil log stops logging.
+func (g *Graph) SetWAL(w *WAL) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.wal = w
+}
+
+// record adds the change of the node or edge with the uid to the pending
+// changes of its shard, which are logged when the lock is released. Undo
+// undoes the change if it can not be logged or committed.
+// The caller is expected to be holding the lock of the shard.
+func (g *Graph) record(uid string, entry walEntry, undo func()) {
+	shard := g.shard(uid)
+	entry.seq = atomic.AddUint64(&g.walSeq, 1)
+	entry.undo = undo
+	shard.pending = append(shard.pending, entry)
+}
+
+// recordSchema adds the change of the indexes or schema to the pending
+// changes, the same as record. Changes to the schema are made holding the
+// write lock, so they are kept with the changes of the first shard.
+// The caller is expected to be holding the write lock.
+func (g *Graph) recordSchema(entry walEntry, undo func()) {
+	entry.seq = atomic.AddUint64(&g.walSeq, 1)
+	entry.undo = undo
+	g.shards[0].pending = append(g.shards[0].pending, entry)
+}
+
+// takePending removes the pending changes of the shards and returns them
+// in the order they were made. The caller is expected to be holding the
+// locks of the shards.
+func (g *Graph) takePending(shards shardSet) []walEntry {
+	entries := []walEntry{}
+	shards.each(func(i int) {
+		entries = append(entries, g.shards[i].pending...)
+		g.shards[i].pending = nil
+	})
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].seq < entries[j].seq
+	})
+	return entries
+}
+
+// logEntries writes the changes which are not already logged to the log,
+// if there is one.
+func (g *Graph) logEntries(entries []walEntry) error {
+	if g.wal == nil {
+		return nil
+	}
+
+	unlogged := make([]walEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.logged {
+			unlogged = append(unlogged, entry)
+		}
+	}
+
+	if len(unlogged) == 0 {
+		return nil
+	}
+	return g.wal.append(unlogged)
+}
+
+// undo undoes the changes in the reverse order they were made and discards
+// the changes of the shards since the last commit. If the changes are
+// already logged, the changes undoing them are logged as well, so
+// replaying the log leaves them undone. The caller is expected to be
+// holding the locks of the shards.
+func (g *Graph) undo(shards shardSet, entries []walEntry, logged bool) {
+	for i := len(entries) - 1; i >= 0; i-- {
+		entries[i].undo()
+	}
+
+	// Undoing the changes records them again. If they can not be logged,
+	// the log has failed and no further changes are logged.
+	undone := g.takePending(shards)
+	if logged {
+		g.logEntries(undone)
+	}
+
+	shards.each(func(i int) {
+		g.shards[i].store.Discard()
+	})
+}
+
+// markLogged marks the pending changes of all the shards as logged.
+// The caller is expected to be holding the write lock.
+func (g *Graph) markLogged() {
+	for _, shard := range g.shards {
+		for i := range shard.pending {
+			shard.pending[i].logged = true
+		}
+	}
+}
+
+// replay applies the batch of logged changes as a new version of the graph.
+func (g *Graph) replay(batch walBatch) (err error) {
+	w := g.exclusive()
+	defer w.release(&err)
+
+	// The changes are already logged.
+	defer g.markLogged()
+
+	for _, entry := range batch.Entries {
+		switch entry.Op {
+		case walPutNode:
+			if entry.Node == nil {
+				return fmt.Errorf("Missing node")
+			}
+			if err := g.restoreNode(*entry.Node); err != nil {
+				return err
+			}
+
+		case walRemoveNode:
+			if !g.hasNode(entry.UID) {
+				continue
+			}
+			if err := g.removeNode(entry.UID); err != nil {
+				return err
+			}
+
+		case walPutEdge:
+			if entry.Edge == nil {
+				return fmt.Errorf("Missing edge")
+			}
+
+			edge := *entry.Edge
+			if !g.hasNode(edge.SourceUID) || !g.hasNode(edge.TargetUID) {
+				return fmt.Errorf("Missing source or target node of edge %s", edge.UID)
+			}
+
+			// A edge can not be moved, so a edge between other nodes is
+			// removed first.
+			if current, ok := g.edge(edge.UID); ok && (current.SourceUID != edge.SourceUID || current.TargetUID != edge.TargetUID) {
+				g.removeEdge(edge.UID)
+			}
+			if err := g.restoreEdge(edge); err != nil {
+				return err
+			}
+
+		case walRemoveEdge:
+			if !g.hasEdge(entry.UID) {
+				continue
+			}
+			if err := g.removeEdge(entry.UID); err != nil {
+				return err
+			}
+
+		default:
+			if err := g.replaySchema(entry); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// replaySchema applies the logged change of the indexes or schema. A index
+// or constraint which already exists, or no longer exists when dropping
+// it, is skipped so replaying over a graph having the change is safe.
+// The caller is expected to be holding the write lock.
+func (g *Graph) replaySchema(entry walEntry) error {
+	switch entry.Op {
+	case walCreateIndex, walDropIndex:
+		if entry.Index == nil {
+			return fmt.Errorf("Missing index")
+		}
+
+		_, exists := g.nodeProps[*entry.Index]
+		if entry.Index.Type == RANGE {
+			_, exists = g.nodeRanges[*entry.Index]
+		}
+
+		if entry.Op == walCreateIndex && !exists {
+			g.createIndex(*entry.Index)
+		}
+
+		if entry.Op == walDropIndex && exists {
+			g.dropIndex(*entry.Index)
+		}
+
+	case walCreateConstraint, walDropConstraint:
+		if entry.Constraint == nil {
+			return fmt.Errorf("Missing constraint")
+		}
+
+		_, exists := g.constraints[*entry.Constraint]
+		if entry.Op == walCreateConstraint && !exists {
+			return g.createConstraint(*entry.Constraint)
+		}
+
+		if entry.Op == walDropConstraint && exists {
+			g.dropConstraint(*entry.Constraint)
+		}
+
+	case walCreateSearchIndex:
+		if entry.Search == nil {
+			return fmt.Errorf("Missing search index")
+		}
+
+		if _, exists := g.searches[entry.Search.Name]; !exists {
+			return g.createSearchIndex(*entry.Search)
+		}
+
+	case walDropSearchIndex:
+		if _, exists := g.searches[entry.Name]; exists {
+			g.dropSearchIndex(entry.Name)
+		}
+
+	case walSetEdgeSchema:
+		if entry.EdgeSchema == nil {
+			return fmt.Errorf("Missing edge schema")
+		}
+		g.setEdgeSchema(*entry.EdgeSchema)
+
+	case walRemoveEdgeSchema:
+		if _, exists := g.edgeSchemas[entry.Name]; exists {
+			g.removeEdgeSchema(entry.Name)
+		}
+
+	default:
+		return fmt.Errorf("Unknown log operation %d", entry.Op)
+	}
+
+	return nil
+}
@@ -0,0 +1,397 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// tempWAL returns the path of a write-ahead log in a new temporary
+// directory, and a function removing the directory.
+func tempWAL(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "draft-wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "draft.wal"), func() { os.RemoveAll(dir) }
+}
+
+// openLogged returns a new graph logging to a new write-ahead log opened at the path.
+func openLogged(t *testing.T, path string, opts ...WALOption) (*Graph, *WAL) {
+	wal, err := OpenWAL(path, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := New()
+	g.SetWAL(wal)
+	return g, wal
+}
+
+// replayed returns a new graph with the write-ahead log at the path replayed.
+func replayed(t *testing.T, path string) *Graph {
+	wal, err := OpenWAL(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wal.Close()
+
+	g := New()
+	if _, err := wal.Replay(g); err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestWAL_Replay(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path)
+
+	g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "name", Value: []byte("bar")})
+	g.AddNode("node-3", []string{"animal"})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+	g.AddEdge("edge-2", "node-1", "owns", "node-3")
+	g.PatchNode("node-2", NodePatch{SetProperties: map[string][]byte{"age": []byte("21")}})
+	g.UpdateEdge(Edge{UID: "edge-1", SourceUID: "node-1", Label: "likes", TargetUID: "node-2", Properties: map[string][]byte{}})
+	g.RemoveEdge("edge-2")
+	g.RemoveNode("node-3")
+	assert.Equal(t, uint64(9), wal.LSN())
+	assert.Nil(t, wal.Close())
+
+	actual := replayed(t, path)
+	assert.Equal(t, g.Version(), actual.Version())
+	assert.Equal(t, 2, actual.NodeCount())
+	assert.Equal(t, 1, actual.EdgeCount())
+
+	expected, _ := g.Node("node-2")
+	node, err := actual.Node("node-2")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, node)
+
+	edge, err := actual.Edge("edge-1")
+	assert.Nil(t, err)
+	assert.Equal(t, Edge{UID: "edge-1", SourceUID: "node-1", Label: "likes", TargetUID: "node-2", Properties: map[string][]byte{}, Version: 2}, edge)
+	assert.False(t, actual.HasNode("node-3"))
+}
+
+func TestWAL_Replay_detach(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path)
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+	assert.Nil(t, g.RemoveNode("node-1", Detach()))
+	assert.Nil(t, wal.Close())
+
+	actual := replayed(t, path)
+	assert.Equal(t, 1, actual.NodeCount())
+	assert.Equal(t, 0, actual.EdgeCount())
+
+	node, err := actual.Node("node-2")
+	assert.Nil(t, err)
+	assert.Empty(t, node.Edges())
+}
+
+func TestWAL_Replay_tx(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path)
+
+	tx := g.Begin()
+	tx.AddNode("node-1", []string{"person"})
+	tx.AddNode("node-2", []string{"person"})
+	tx.AddEdge("edge-1", "node-1", "knows", "node-2")
+	assert.Nil(t, tx.Commit())
+
+	// The transaction is rolled back, so nothing of it is logged.
+	tx = g.Begin()
+	tx.AddNode("node-3", []string{"person"})
+	tx.AddNode("node-4", []string{"person"})
+	g.AddNode("node-4", []string{"person"})
+	assert.NotNil(t, tx.Commit())
+
+	assert.Equal(t, uint64(2), wal.LSN())
+	assert.Nil(t, wal.Close())
+
+	actual := replayed(t, path)
+	assert.Equal(t, 3, actual.NodeCount())
+	assert.Equal(t, 1, actual.EdgeCount())
+	assert.False(t, actual.HasNode("node-3"))
+}
+
+func TestWAL_torn_record(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path)
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	assert.Nil(t, wal.Close())
+
+	info, err := os.Stat(path)
+	assert.Nil(t, err)
+
+	// Cut the last record short, as a crash part way through writing it would.
+	assert.Nil(t, os.Truncate(path, info.Size()-3))
+
+	g, wal = openLogged(t, path)
+	assert.Equal(t, uint64(1), wal.LSN())
+	g.AddNode("node-3", []string{"person"})
+	assert.Nil(t, wal.Close())
+
+	actual := replayed(t, path)
+	assert.True(t, actual.HasNode("node-1"))
+	assert.False(t, actual.HasNode("node-2"))
+	assert.True(t, actual.HasNode("node-3"))
+}
+
+func TestWAL_Replay_failed_batch(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	wal, err := OpenWAL(path)
+	assert.Nil(t, err)
+
+	node := NewNode("node-1", []string{"person"})
+	edge := NewEdge("edge-1", "node-1", "knows", "node-2")
+	assert.Nil(t, wal.append([]walEntry{{Op: walPutNode, Node: &node}, {Op: walPutEdge, Edge: &edge}}))
+	assert.Nil(t, wal.Close())
+
+	wal, err = OpenWAL(path)
+	assert.Nil(t, err)
+	defer wal.Close()
+
+	g := New()
+	version := g.Version()
+
+	// The edge is missing its target, so the node added before it in the
+	// same batch is undone.
+	_, err = wal.Replay(g)
+	assert.NotNil(t, err)
+	assert.Equal(t, version, g.Version())
+	assert.False(t, g.HasNode("node-1"))
+	assert.Equal(t, 0, g.NodeCount())
+}
+
+func TestWAL_checksum(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path)
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	assert.Nil(t, wal.Close())
+
+	data, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+
+	// Flip a byte of the last record.
+	data[len(data)-2] ^= 0xff
+	assert.Nil(t, ioutil.WriteFile(path, data, 0644))
+
+	actual := replayed(t, path)
+	assert.True(t, actual.HasNode("node-1"))
+	assert.False(t, actual.HasNode("node-2"))
+}
+
+func TestWAL_sync_policies(t *testing.T) {
+	for _, policy := range []SyncPolicy{ALWAYS, INTERVAL, NEVER} {
+		t.Run(policy.String(), func(t *testing.T) {
+			path, cleanup := tempWAL(t)
+			defer cleanup()
+
+			g, wal := openLogged(t, path, WithSyncPolicy(policy, time.Millisecond))
+			g.AddNode("node-1", []string{"person"})
+			time.Sleep(5 * time.Millisecond)
+			assert.Nil(t, wal.Err())
+			assert.Nil(t, wal.Close())
+
+			assert.True(t, replayed(t, path).HasNode("node-1"))
+		})
+	}
+}
+
+func TestWAL_closed(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path)
+	assert.Nil(t, wal.Close())
+	version := g.Version()
+
+	// The change would not be durable, so it fails and is undone.
+	_, err := g.AddNode("node-1", []string{"person"})
+	assert.True(t, errors.Is(err, os.ErrClosed))
+	assert.True(t, errors.Is(wal.Err(), os.ErrClosed))
+	assert.False(t, g.HasNode("node-1"))
+	assert.Equal(t, version, g.Version())
+}
+
+func TestWAL_closed_undo(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path)
+
+	assert.Nil(t, g.CreateRangeIndex("person", "age"))
+	assert.Nil(t, g.CreateSearchIndex(SearchIndexDef{Name: "names", Type: NODE, Properties: []string{"name"}}))
+	assert.Nil(t, g.CreateConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "name"}))
+	assert.Nil(t, g.SetEdgeSchema(EdgeSchema{Label: "knows", MaxOut: 2}))
+
+	g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("foo")}, KV{Key: "age", Value: []byte("21")})
+	g.AddNode("node-2", []string{"person"}, KV{Key: "name", Value: []byte("bar")}, KV{Key: "age", Value: []byte("42")})
+	g.AddEdge("edge-1", "node-1", "knows", "node-2")
+	g.AddEdge("edge-2", "node-2", "knows", "node-1")
+
+	expected, err := g.Snapshot().MarshalJSON()
+	assert.Nil(t, err)
+	version := g.Version()
+
+	assert.Nil(t, wal.Close())
+
+	_, err = g.PatchNode("node-1", NodePatch{SetProperties: map[string][]byte{"name": []byte("baz"), "age": []byte("7")}})
+	assert.NotNil(t, err)
+	_, err = g.AddEdge("edge-3", "node-1", "knows", "node-2")
+	assert.NotNil(t, err)
+	assert.NotNil(t, g.RemoveNode("node-2", Detach()))
+	assert.NotNil(t, g.DropRangeIndex("person", "age"))
+	assert.NotNil(t, g.DropSearchIndex("names"))
+	assert.NotNil(t, g.DropConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "name"}))
+	assert.NotNil(t, g.SetEdgeSchema(EdgeSchema{Label: "knows", MaxOut: 1}))
+	assert.NotNil(t, g.CreateIndex("person", "name"))
+
+	tx := g.Begin()
+	tx.AddNode("node-3", []string{"person"}, KV{Key: "name", Value: []byte("baz")})
+	tx.AddEdge("edge-4", "node-3", "knows", "node-1")
+	assert.True(t, errors.Is(tx.Commit(), os.ErrClosed))
+
+	actual, err := g.Snapshot().MarshalJSON()
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+	assert.Equal(t, version, g.Version())
+
+	// The indexes and constraints are as they were.
+	_, err = g.AddNode("node-4", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	assert.NotNil(t, err)
+
+	results, err := g.Search("names", "baz", 0)
+	assert.Nil(t, err)
+	assert.Empty(t, results)
+
+	iter := g.NodesByRange(RangeQuery{Label: "person", Property: "age"})
+	uids := []string{}
+	for iter.Next() {
+		uids = append(uids, iter.Node().UID)
+	}
+	assert.Equal(t, []string{"node-1", "node-2"}, uids)
+}
+
+func TestWAL_Replay_schema(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path)
+
+	g.AddNode("node-1", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	assert.Nil(t, g.CreateIndex("person", "name"))
+	assert.Nil(t, g.CreateRangeIndex("person", "age"))
+	assert.Nil(t, g.CreateRangeIndex("person", "name"))
+	assert.Nil(t, g.DropRangeIndex("person", "name"))
+	assert.Nil(t, g.CreateConstraint(Constraint{Type: UNIQUE, Item: NODE, Label: "person", Property: "name"}))
+	assert.Nil(t, g.CreateConstraint(Constraint{Type: EXISTS, Item: NODE, Label: "person", Property: "name"}))
+	assert.Nil(t, g.DropConstraint(Constraint{Type: EXISTS, Item: NODE, Label: "person", Property: "name"}))
+	assert.Nil(t, g.CreateSearchIndex(SearchIndexDef{Name: "names", Type: NODE, Properties: []string{"name"}}))
+	assert.Nil(t, g.CreateSearchIndex(SearchIndexDef{Name: "labels", Type: EDGE, Properties: []string{"label"}}))
+	assert.Nil(t, g.DropSearchIndex("labels"))
+	assert.Nil(t, g.SetEdgeSchema(EdgeSchema{Label: "knows", MaxOut: 2}))
+	assert.Nil(t, g.SetEdgeSchema(EdgeSchema{Label: "likes"}))
+	assert.Nil(t, g.RemoveEdgeSchema("likes"))
+	assert.Nil(t, wal.Close())
+
+	actual := replayed(t, path)
+	assert.Equal(t, g.Indexes(), actual.Indexes())
+	assert.Equal(t, g.Constraints(), actual.Constraints())
+	assert.Equal(t, g.SearchIndexes(), actual.SearchIndexes())
+	assert.Equal(t, g.EdgeSchemas(), actual.EdgeSchemas())
+
+	// The unique index is built from the replayed nodes.
+	_, err := actual.AddNode("node-2", []string{"person"}, KV{Key: "name", Value: []byte("foo")})
+	assert.NotNil(t, err)
+
+	// Replaying over the graph having the changes is safe.
+	replay, err := OpenWAL(path)
+	assert.Nil(t, err)
+	defer replay.Close()
+	_, err = replay.Replay(actual)
+	assert.Nil(t, err)
+	assert.Equal(t, g.Indexes(), actual.Indexes())
+}
+
+func TestWAL_concurrent_writes(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path, WithSyncPolicy(NEVER, 0))
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				source := fmt.Sprintf("node-%d-%d", w, i)
+				target := fmt.Sprintf("node-%d-%d", (w+1)%4, i)
+				g.AddNode(source, []string{"person"})
+				g.AddNode(target, []string{"person"})
+				g.AddEdge(fmt.Sprintf("edge-%d-%d", w, i), source, "knows", target)
+				if i%5 == 0 {
+					g.RemoveEdge(fmt.Sprintf("edge-%d-%d", w, i))
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	assert.Nil(t, wal.Close())
+
+	// Replaying fails if a edge is logged before its nodes.
+	actual := replayed(t, path)
+	assert.Equal(t, g.NodeCount(), actual.NodeCount())
+	assert.Equal(t, g.EdgeCount(), actual.EdgeCount())
+	checkAdjacency(t, actual)
+}
+
+func TestWAL_Truncate(t *testing.T) {
+	path, cleanup := tempWAL(t)
+	defer cleanup()
+
+	g, wal := openLogged(t, path)
+	g.AddNode("node-1", []string{"person"})
+	g.AddNode("node-2", []string{"person"})
+	g.AddNode("node-3", []string{"person"})
+
+	assert.Nil(t, wal.Truncate(2))
+	assert.Equal(t, uint64(3), wal.LSN())
+
+	// The log is still appended to after truncating it.
+	g.AddNode("node-4", []string{"person"})
+	assert.Nil(t, wal.Close())
+
+	actual := replayed(t, path)
+	assert.False(t, actual.HasNode("node-1"))
+	assert.False(t, actual.HasNode("node-2"))
+	assert.True(t, actual.HasNode("node-3"))
+	assert.True(t, actual.HasNode("node-4"))
+}
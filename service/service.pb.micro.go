@@ -45,19 +45,37 @@ type GraphService interface {
 	// AddNode adds a node to the graph.
 	AddNode(ctx context.Context, in *NodeReq, opts ...client.CallOption) (*NodeResp, error)
 	// RemoveNode remove a node from the graph.
-	RemoveNode(ctx context.Context, in *UIDReq, opts ...client.CallOption) (*RemoveResp, error)
+	RemoveNode(ctx context.Context, in *RemoveNodeReq, opts ...client.CallOption) (*RemoveResp, error)
+	// RemoveNodes removes all the nodes matching a filter from the graph.
+	RemoveNodes(ctx context.Context, in *RemoveNodesReq, opts ...client.CallOption) (*RemoveNodesResp, error)
 	// Node returns the node if found.
 	Node(ctx context.Context, in *NodeReq, opts ...client.CallOption) (*NodeResp, error)
-	// Nodes returns all the node in the graph.
+	// UpdateNode patches the labels and properties of a node. If the
+	// version is set and is not the current version, the update fails
+	// with a FailedPrecondition error.
+	UpdateNode(ctx context.Context, in *UpdateNodeReq, opts ...client.CallOption) (*NodeResp, error)
+	// Nodes returns all the node in the graph, continuing after the page
+	// token if it is set.
 	Nodes(ctx context.Context, in *NodesReq, opts ...client.CallOption) (Graph_NodesService, error)
+	// NodesPage returns a page of the nodes in the graph, for clients
+	// which can not use streams.
+	NodesPage(ctx context.Context, in *NodesReq, opts ...client.CallOption) (*NodesPageResp, error)
 	// AddEdge adds a edge to the graph.
 	AddEdge(ctx context.Context, in *EdgeReq, opts ...client.CallOption) (*EdgeResp, error)
 	// RemoveEdge remove a edge from the graph.
 	RemoveEdge(ctx context.Context, in *UIDReq, opts ...client.CallOption) (*RemoveResp, error)
 	// Edge returns the edge if found.
 	Edge(ctx context.Context, in *EdgeReq, opts ...client.CallOption) (*EdgeResp, error)
-	// Edges returns all the edges in the graph.
+	// UpdateEdge patches the label and properties of a edge. If the
+	// version is set and is not the current version, the update fails
+	// with a FailedPrecondition error.
+	UpdateEdge(ctx context.Context, in *UpdateEdgeReq, opts ...client.CallOption) (*EdgeResp, error)
+	// Edges returns all the edges in the graph, continuing after the page
+	// token if it is set.
 	Edges(ctx context.Context, in *EdgesReq, opts ...client.CallOption) (Graph_EdgesService, error)
+	// EdgesPage returns a page of the edges in the graph, for clients
+	// which can not use streams.
+	EdgesPage(ctx context.Context, in *EdgesReq, opts ...client.CallOption) (*EdgesPageResp, error)
 	// Stats returns some stats about the service.
 	Stats(ctx context.Context, in *StatsReq, opts ...client.CallOption) (*StatsResp, error)
 	// Query sends a query to the graph and returns a dump
@@ -65,6 +83,28 @@ type GraphService interface {
 	Query(ctx context.Context, in *QueryReq, opts ...client.CallOption) (*DumpResp, error)
 	// Dump the graph.
 	Dump(ctx context.Context, in *DumpReq, opts ...client.CallOption) (*DumpResp, error)
+	// Search streams the full-text search results, best match first.
+	Search(ctx context.Context, in *SearchReq, opts ...client.CallOption) (Graph_SearchService, error)
+	// Schema creates and drops schema constraints and returns all the
+	// constraints. Adding or updating nodes and edges which violate a
+	// constraint fails with a FailedPrecondition error.
+	Schema(ctx context.Context, in *SchemaReq, opts ...client.CallOption) (*SchemaResp, error)
+	// BeginTx starts a transaction and returns the transaction id. Node
+	// and edge requests with the transaction id are only visible in the
+	// transaction until it is committed.
+	BeginTx(ctx context.Context, in *TxReq, opts ...client.CallOption) (*TxResp, error)
+	// Commit applies all the changes in the transaction, or none of them
+	// if any of the changes fail.
+	Commit(ctx context.Context, in *TxReq, opts ...client.CallOption) (*TxResp, error)
+	// Rollback discards all the changes in the transaction.
+	Rollback(ctx context.Context, in *TxReq, opts ...client.CallOption) (*TxResp, error)
+	// Mutate applies a batch of mutations in order, either all of them
+	// are applied or none are.
+	Mutate(ctx context.Context, in *MutateReq, opts ...client.CallOption) (*MutateResp, error)
+	// Ingest loads a stream of node and edge records in chunks. Edges
+	// may reference nodes later in the stream. The summary is returned
+	// when the stream is closed.
+	Ingest(ctx context.Context, opts ...client.CallOption) (Graph_IngestService, error)
 }
 
 type graphService struct {
@@ -89,7 +129,7 @@ func (c *graphService) AddNode(ctx context.Context, in *NodeReq, opts ...client.
 	return out, nil
 }
 
-func (c *graphService) RemoveNode(ctx context.Context, in *UIDReq, opts ...client.CallOption) (*RemoveResp, error) {
+func (c *graphService) RemoveNode(ctx context.Context, in *RemoveNodeReq, opts ...client.CallOption) (*RemoveResp, error) {
 	req := c.c.NewRequest(c.name, "Graph.RemoveNode", in)
 	out := new(RemoveResp)
 	err := c.c.Call(ctx, req, out, opts...)
@@ -99,6 +139,16 @@ func (c *graphService) RemoveNode(ctx context.Context, in *UIDReq, opts ...clien
 	return out, nil
 }
 
+func (c *graphService) RemoveNodes(ctx context.Context, in *RemoveNodesReq, opts ...client.CallOption) (*RemoveNodesResp, error) {
+	req := c.c.NewRequest(c.name, "Graph.RemoveNodes", in)
+	out := new(RemoveNodesResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *graphService) Node(ctx context.Context, in *NodeReq, opts ...client.CallOption) (*NodeResp, error) {
 	req := c.c.NewRequest(c.name, "Graph.Node", in)
 	out := new(NodeResp)
@@ -109,6 +159,16 @@ func (c *graphService) Node(ctx context.Context, in *NodeReq, opts ...client.Cal
 	return out, nil
 }
 
+func (c *graphService) UpdateNode(ctx context.Context, in *UpdateNodeReq, opts ...client.CallOption) (*NodeResp, error) {
+	req := c.c.NewRequest(c.name, "Graph.UpdateNode", in)
+	out := new(NodeResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *graphService) Nodes(ctx context.Context, in *NodesReq, opts ...client.CallOption) (Graph_NodesService, error) {
 	req := c.c.NewRequest(c.name, "Graph.Nodes", &NodesReq{})
 	stream, err := c.c.Stream(ctx, req, opts...)
@@ -158,6 +218,16 @@ func (x *graphServiceNodes) Recv() (*NodeResp, error) {
 	return m, nil
 }
 
+func (c *graphService) NodesPage(ctx context.Context, in *NodesReq, opts ...client.CallOption) (*NodesPageResp, error) {
+	req := c.c.NewRequest(c.name, "Graph.NodesPage", in)
+	out := new(NodesPageResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *graphService) AddEdge(ctx context.Context, in *EdgeReq, opts ...client.CallOption) (*EdgeResp, error) {
 	req := c.c.NewRequest(c.name, "Graph.AddEdge", in)
 	out := new(EdgeResp)
@@ -188,6 +258,16 @@ func (c *graphService) Edge(ctx context.Context, in *EdgeReq, opts ...client.Cal
 	return out, nil
 }
 
+func (c *graphService) UpdateEdge(ctx context.Context, in *UpdateEdgeReq, opts ...client.CallOption) (*EdgeResp, error) {
+	req := c.c.NewRequest(c.name, "Graph.UpdateEdge", in)
+	out := new(EdgeResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *graphService) Edges(ctx context.Context, in *EdgesReq, opts ...client.CallOption) (Graph_EdgesService, error) {
 	req := c.c.NewRequest(c.name, "Graph.Edges", &EdgesReq{})
 	stream, err := c.c.Stream(ctx, req, opts...)
@@ -237,6 +317,16 @@ func (x *graphServiceEdges) Recv() (*EdgeResp, error) {
 	return m, nil
 }
 
+func (c *graphService) EdgesPage(ctx context.Context, in *EdgesReq, opts ...client.CallOption) (*EdgesPageResp, error) {
+	req := c.c.NewRequest(c.name, "Graph.EdgesPage", in)
+	out := new(EdgesPageResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *graphService) Stats(ctx context.Context, in *StatsReq, opts ...client.CallOption) (*StatsResp, error) {
 	req := c.c.NewRequest(c.name, "Graph.Stats", in)
 	out := new(StatsResp)
@@ -267,25 +357,183 @@ func (c *graphService) Dump(ctx context.Context, in *DumpReq, opts ...client.Cal
 	return out, nil
 }
 
+func (c *graphService) Search(ctx context.Context, in *SearchReq, opts ...client.CallOption) (Graph_SearchService, error) {
+	req := c.c.NewRequest(c.name, "Graph.Search", &SearchReq{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &graphServiceSearch{stream}, nil
+}
+
+type Graph_SearchService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*SearchResp, error)
+}
+
+type graphServiceSearch struct {
+	stream client.Stream
+}
+
+func (x *graphServiceSearch) Close() error {
+	return x.stream.Close()
+}
+
+func (x *graphServiceSearch) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *graphServiceSearch) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *graphServiceSearch) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *graphServiceSearch) Recv() (*SearchResp, error) {
+	m := new(SearchResp)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *graphService) Schema(ctx context.Context, in *SchemaReq, opts ...client.CallOption) (*SchemaResp, error) {
+	req := c.c.NewRequest(c.name, "Graph.Schema", in)
+	out := new(SchemaResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphService) BeginTx(ctx context.Context, in *TxReq, opts ...client.CallOption) (*TxResp, error) {
+	req := c.c.NewRequest(c.name, "Graph.BeginTx", in)
+	out := new(TxResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphService) Commit(ctx context.Context, in *TxReq, opts ...client.CallOption) (*TxResp, error) {
+	req := c.c.NewRequest(c.name, "Graph.Commit", in)
+	out := new(TxResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphService) Rollback(ctx context.Context, in *TxReq, opts ...client.CallOption) (*TxResp, error) {
+	req := c.c.NewRequest(c.name, "Graph.Rollback", in)
+	out := new(TxResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphService) Mutate(ctx context.Context, in *MutateReq, opts ...client.CallOption) (*MutateResp, error) {
+	req := c.c.NewRequest(c.name, "Graph.Mutate", in)
+	out := new(MutateResp)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *graphService) Ingest(ctx context.Context, opts ...client.CallOption) (Graph_IngestService, error) {
+	req := c.c.NewRequest(c.name, "Graph.Ingest", &IngestReq{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &graphServiceIngest{stream}, nil
+}
+
+type Graph_IngestService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*IngestReq) error
+}
+
+type graphServiceIngest struct {
+	stream client.Stream
+}
+
+func (x *graphServiceIngest) Close() error {
+	return x.stream.Close()
+}
+
+func (x *graphServiceIngest) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *graphServiceIngest) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *graphServiceIngest) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *graphServiceIngest) Send(m *IngestReq) error {
+	return x.stream.Send(m)
+}
+
 // Server API for Graph service
 
 type GraphHandler interface {
 	// AddNode adds a node to the graph.
 	AddNode(context.Context, *NodeReq, *NodeResp) error
 	// RemoveNode remove a node from the graph.
-	RemoveNode(context.Context, *UIDReq, *RemoveResp) error
+	RemoveNode(context.Context, *RemoveNodeReq, *RemoveResp) error
+	// RemoveNodes removes all the nodes matching a filter from the graph.
+	RemoveNodes(context.Context, *RemoveNodesReq, *RemoveNodesResp) error
 	// Node returns the node if found.
 	Node(context.Context, *NodeReq, *NodeResp) error
-	// Nodes returns all the node in the graph.
+	// UpdateNode patches the labels and properties of a node. If the
+	// version is set and is not the current version, the update fails
+	// with a FailedPrecondition error.
+	UpdateNode(context.Context, *UpdateNodeReq, *NodeResp) error
+	// Nodes returns all the node in the graph, continuing after the page
+	// token if it is set.
 	Nodes(context.Context, *NodesReq, Graph_NodesStream) error
+	// NodesPage returns a page of the nodes in the graph, for clients
+	// which can not use streams.
+	NodesPage(context.Context, *NodesReq, *NodesPageResp) error
 	// AddEdge adds a edge to the graph.
 	AddEdge(context.Context, *EdgeReq, *EdgeResp) error
 	// RemoveEdge remove a edge from the graph.
 	RemoveEdge(context.Context, *UIDReq, *RemoveResp) error
 	// Edge returns the edge if found.
 	Edge(context.Context, *EdgeReq, *EdgeResp) error
-	// Edges returns all the edges in the graph.
+	// UpdateEdge patches the label and properties of a edge. If the
+	// version is set and is not the current version, the update fails
+	// with a FailedPrecondition error.
+	UpdateEdge(context.Context, *UpdateEdgeReq, *EdgeResp) error
+	// Edges returns all the edges in the graph, continuing after the page
+	// token if it is set.
 	Edges(context.Context, *EdgesReq, Graph_EdgesStream) error
+	// EdgesPage returns a page of the edges in the graph, for clients
+	// which can not use streams.
+	EdgesPage(context.Context, *EdgesReq, *EdgesPageResp) error
 	// Stats returns some stats about the service.
 	Stats(context.Context, *StatsReq, *StatsResp) error
 	// Query sends a query to the graph and returns a dump
@@ -293,21 +541,55 @@ type GraphHandler interface {
 	Query(context.Context, *QueryReq, *DumpResp) error
 	// Dump the graph.
 	Dump(context.Context, *DumpReq, *DumpResp) error
+	// Search streams the full-text search results, best match first.
+	Search(context.Context, *SearchReq, Graph_SearchStream) error
+	// Schema creates and drops schema constraints and returns all the
+	// constraints. Adding or updating nodes and edges which violate a
+	// constraint fails with a FailedPrecondition error.
+	Schema(context.Context, *SchemaReq, *SchemaResp) error
+	// BeginTx starts a transaction and returns the transaction id. Node
+	// and edge requests with the transaction id are only visible in the
+	// transaction until it is committed.
+	BeginTx(context.Context, *TxReq, *TxResp) error
+	// Commit applies all the changes in the transaction, or none of them
+	// if any of the changes fail.
+	Commit(context.Context, *TxReq, *TxResp) error
+	// Rollback discards all the changes in the transaction.
+	Rollback(context.Context, *TxReq, *TxResp) error
+	// Mutate applies a batch of mutations in order, either all of them
+	// are applied or none are.
+	Mutate(context.Context, *MutateReq, *MutateResp) error
+	// Ingest loads a stream of node and edge records in chunks. Edges
+	// may reference nodes later in the stream. The summary is returned
+	// when the stream is closed.
+	Ingest(context.Context, Graph_IngestStream) error
 }
 
 func RegisterGraphHandler(s server.Server, hdlr GraphHandler, opts ...server.HandlerOption) error {
 	type graph interface {
 		AddNode(ctx context.Context, in *NodeReq, out *NodeResp) error
-		RemoveNode(ctx context.Context, in *UIDReq, out *RemoveResp) error
+		RemoveNode(ctx context.Context, in *RemoveNodeReq, out *RemoveResp) error
+		RemoveNodes(ctx context.Context, in *RemoveNodesReq, out *RemoveNodesResp) error
 		Node(ctx context.Context, in *NodeReq, out *NodeResp) error
+		UpdateNode(ctx context.Context, in *UpdateNodeReq, out *NodeResp) error
 		Nodes(ctx context.Context, stream server.Stream) error
+		NodesPage(ctx context.Context, in *NodesReq, out *NodesPageResp) error
 		AddEdge(ctx context.Context, in *EdgeReq, out *EdgeResp) error
 		RemoveEdge(ctx context.Context, in *UIDReq, out *RemoveResp) error
 		Edge(ctx context.Context, in *EdgeReq, out *EdgeResp) error
+		UpdateEdge(ctx context.Context, in *UpdateEdgeReq, out *EdgeResp) error
 		Edges(ctx context.Context, stream server.Stream) error
+		EdgesPage(ctx context.Context, in *EdgesReq, out *EdgesPageResp) error
 		Stats(ctx context.Context, in *StatsReq, out *StatsResp) error
 		Query(ctx context.Context, in *QueryReq, out *DumpResp) error
 		Dump(ctx context.Context, in *DumpReq, out *DumpResp) error
+		Search(ctx context.Context, stream server.Stream) error
+		Schema(ctx context.Context, in *SchemaReq, out *SchemaResp) error
+		BeginTx(ctx context.Context, in *TxReq, out *TxResp) error
+		Commit(ctx context.Context, in *TxReq, out *TxResp) error
+		Rollback(ctx context.Context, in *TxReq, out *TxResp) error
+		Mutate(ctx context.Context, in *MutateReq, out *MutateResp) error
+		Ingest(ctx context.Context, stream server.Stream) error
 	}
 	type Graph struct {
 		graph
@@ -324,14 +606,22 @@ func (h *graphHandler) AddNode(ctx context.Context, in *NodeReq, out *NodeResp)
 	return h.GraphHandler.AddNode(ctx, in, out)
 }
 
-func (h *graphHandler) RemoveNode(ctx context.Context, in *UIDReq, out *RemoveResp) error {
+func (h *graphHandler) RemoveNode(ctx context.Context, in *RemoveNodeReq, out *RemoveResp) error {
 	return h.GraphHandler.RemoveNode(ctx, in, out)
 }
 
+func (h *graphHandler) RemoveNodes(ctx context.Context, in *RemoveNodesReq, out *RemoveNodesResp) error {
+	return h.GraphHandler.RemoveNodes(ctx, in, out)
+}
+
 func (h *graphHandler) Node(ctx context.Context, in *NodeReq, out *NodeResp) error {
 	return h.GraphHandler.Node(ctx, in, out)
 }
 
+func (h *graphHandler) UpdateNode(ctx context.Context, in *UpdateNodeReq, out *NodeResp) error {
+	return h.GraphHandler.UpdateNode(ctx, in, out)
+}
+
 func (h *graphHandler) Nodes(ctx context.Context, stream server.Stream) error {
 	m := new(NodesReq)
 	if err := stream.Recv(m); err != nil {
@@ -372,6 +662,10 @@ func (x *graphNodesStream) Send(m *NodeResp) error {
 	return x.stream.Send(m)
 }
 
+func (h *graphHandler) NodesPage(ctx context.Context, in *NodesReq, out *NodesPageResp) error {
+	return h.GraphHandler.NodesPage(ctx, in, out)
+}
+
 func (h *graphHandler) AddEdge(ctx context.Context, in *EdgeReq, out *EdgeResp) error {
 	return h.GraphHandler.AddEdge(ctx, in, out)
 }
@@ -384,6 +678,10 @@ func (h *graphHandler) Edge(ctx context.Context, in *EdgeReq, out *EdgeResp) err
 	return h.GraphHandler.Edge(ctx, in, out)
 }
 
+func (h *graphHandler) UpdateEdge(ctx context.Context, in *UpdateEdgeReq, out *EdgeResp) error {
+	return h.GraphHandler.UpdateEdge(ctx, in, out)
+}
+
 func (h *graphHandler) Edges(ctx context.Context, stream server.Stream) error {
 	m := new(EdgesReq)
 	if err := stream.Recv(m); err != nil {
@@ -424,6 +722,10 @@ func (x *graphEdgesStream) Send(m *EdgeResp) error {
 	return x.stream.Send(m)
 }
 
+func (h *graphHandler) EdgesPage(ctx context.Context, in *EdgesReq, out *EdgesPageResp) error {
+	return h.GraphHandler.EdgesPage(ctx, in, out)
+}
+
 func (h *graphHandler) Stats(ctx context.Context, in *StatsReq, out *StatsResp) error {
 	return h.GraphHandler.Stats(ctx, in, out)
 }
@@ -435,3 +737,103 @@ func (h *graphHandler) Query(ctx context.Context, in *QueryReq, out *DumpResp) e
 func (h *graphHandler) Dump(ctx context.Context, in *DumpReq, out *DumpResp) error {
 	return h.GraphHandler.Dump(ctx, in, out)
 }
+
+func (h *graphHandler) Search(ctx context.Context, stream server.Stream) error {
+	m := new(SearchReq)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.GraphHandler.Search(ctx, m, &graphSearchStream{stream})
+}
+
+type Graph_SearchStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*SearchResp) error
+}
+
+type graphSearchStream struct {
+	stream server.Stream
+}
+
+func (x *graphSearchStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *graphSearchStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *graphSearchStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *graphSearchStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *graphSearchStream) Send(m *SearchResp) error {
+	return x.stream.Send(m)
+}
+
+func (h *graphHandler) Schema(ctx context.Context, in *SchemaReq, out *SchemaResp) error {
+	return h.GraphHandler.Schema(ctx, in, out)
+}
+
+func (h *graphHandler) BeginTx(ctx context.Context, in *TxReq, out *TxResp) error {
+	return h.GraphHandler.BeginTx(ctx, in, out)
+}
+
+func (h *graphHandler) Commit(ctx context.Context, in *TxReq, out *TxResp) error {
+	return h.GraphHandler.Commit(ctx, in, out)
+}
+
+func (h *graphHandler) Rollback(ctx context.Context, in *TxReq, out *TxResp) error {
+	return h.GraphHandler.Rollback(ctx, in, out)
+}
+
+func (h *graphHandler) Mutate(ctx context.Context, in *MutateReq, out *MutateResp) error {
+	return h.GraphHandler.Mutate(ctx, in, out)
+}
+
+func (h *graphHandler) Ingest(ctx context.Context, stream server.Stream) error {
+	return h.GraphHandler.Ingest(ctx, &graphIngestStream{stream})
+}
+
+type Graph_IngestStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Recv() (*IngestReq, error)
+}
+
+type graphIngestStream struct {
+	stream server.Stream
+}
+
+func (x *graphIngestStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *graphIngestStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *graphIngestStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *graphIngestStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *graphIngestStream) Recv() (*IngestReq, error) {
+	m := new(IngestReq)
+	if err := x.stream.Recv(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}